@@ -28,6 +28,12 @@ type SearchArgs struct {
 	// a static representation of our job tree independently of any resolvers.
 	CodeMonitorID *graphql.ID
 
+	// RepoResultsOnly, if set, constructs a job tree limited to repo results
+	// (RepoSearch and ComputeExcludedRepos), skipping text, structural, and
+	// symbol search jobs. Callers that only need repository metadata (e.g.,
+	// repo pickers) use this to avoid unnecessary job construction.
+	RepoResultsOnly bool
+
 	// For tests
 	Settings *schema.Settings
 }
@@ -69,10 +75,11 @@ func NewBatchSearchImplementer(ctx context.Context, db database.DB, args *Search
 	}
 
 	return &searchResolver{
-		db:           db,
-		SearchInputs: inputs,
-		zoekt:        search.Indexed(),
-		searcherURLs: search.SearcherURLs(),
+		db:              db,
+		SearchInputs:    inputs,
+		zoekt:           search.Indexed(),
+		searcherURLs:    search.SearcherURLs(),
+		repoResultsOnly: args.RepoResultsOnly,
 	}, nil
 }
 
@@ -85,8 +92,9 @@ type searchResolver struct {
 	SearchInputs *run.SearchInputs
 	db           database.DB
 
-	zoekt        zoekt.Streamer
-	searcherURLs *endpoint.Map
+	zoekt           zoekt.Streamer
+	searcherURLs    *endpoint.Map
+	repoResultsOnly bool
 }
 
 func (r *searchResolver) Inputs() run.SearchInputs {
@@ -95,6 +103,16 @@ func (r *searchResolver) Inputs() run.SearchInputs {
 
 var MockDecodedViewerFinalSettings *schema.Settings
 
+type viewerSettingsKey struct{}
+
+// WithViewerSettings returns a context that overrides the settings returned by
+// DecodedViewerFinalSettings. Unlike MockDecodedViewerFinalSettings, the
+// override is scoped to the context, so parallel tests can resolve distinct
+// settings.
+func WithViewerSettings(ctx context.Context, settings *schema.Settings) context.Context {
+	return context.WithValue(ctx, viewerSettingsKey{}, settings)
+}
+
 // DecodedViewerFinalSettings returns the final (merged) settings for the viewer
 func DecodedViewerFinalSettings(ctx context.Context, db database.DB) (_ *schema.Settings, err error) {
 	tr, ctx := trace.New(ctx, "decodedViewerFinalSettings", "")
@@ -102,6 +120,9 @@ func DecodedViewerFinalSettings(ctx context.Context, db database.DB) (_ *schema.
 		tr.SetError(err)
 		tr.Finish()
 	}()
+	if settings, ok := ctx.Value(viewerSettingsKey{}).(*schema.Settings); ok {
+		return settings, nil
+	}
 	if MockDecodedViewerFinalSettings != nil {
 		return MockDecodedViewerFinalSettings, nil
 	}
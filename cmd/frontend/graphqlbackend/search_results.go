@@ -445,6 +445,7 @@ func (r *searchResolver) JobArgs() *job.Args {
 		OnSourcegraphDotCom: envvar.SourcegraphDotComMode(),
 		Zoekt:               r.zoekt,
 		SearcherURLs:        r.searcherURLs,
+		RepoResultsOnly:     r.repoResultsOnly,
 	}
 }
 
@@ -659,6 +660,48 @@ func (r *searchResolver) Stats(ctx context.Context) (stats *searchResultsStats,
 	return stats, nil
 }
 
+// streamStatsInterval is the minimum time between partial stats snapshots
+// emitted by StreamStats. The first event always produces a snapshot.
+var streamStatsInterval = time.Second
+
+// StreamStats runs the search job tree and emits periodic partial statistics
+// snapshots (approximate result count so far) to onPartial while results
+// stream in. It complements Stats, which remains the terminal call for final,
+// cacheable statistics.
+func (r *searchResolver) StreamStats(ctx context.Context, onPartial func(partial *searchResultsStats)) error {
+	j, err := job.ToSearchJob(r.JobArgs(), r.SearchInputs.Query)
+	if err != nil {
+		return err
+	}
+	return r.streamStats(ctx, j, onPartial)
+}
+
+func (r *searchResolver) streamStats(ctx context.Context, j job.Job, onPartial func(*searchResultsStats)) error {
+	var (
+		mu         sync.Mutex
+		matchCount int
+		last       time.Time
+	)
+	stream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		mu.Lock()
+		matchCount += len(event.Results)
+		if time.Since(last) < streamStatsInterval {
+			mu.Unlock()
+			return
+		}
+		last = time.Now()
+		snapshot := &searchResultsStats{
+			JApproximateResultCount: strconv.Itoa(matchCount) + "+",
+			sr:                      r,
+		}
+		mu.Unlock()
+		onPartial(snapshot)
+	})
+
+	_, err := j.Run(ctx, r.db, stream)
+	return err
+}
+
 // isContextError returns true if ctx.Err() is not nil or if err
 // is an error caused by context cancelation or timeout.
 func isContextError(ctx context.Context, err error) bool {
@@ -6,7 +6,9 @@ import (
 	"os"
 	"sort"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	mockrequire "github.com/derision-test/go-mockgen/testutil/require"
 	"github.com/google/go-cmp/cmp"
@@ -787,3 +789,41 @@ func TestSubRepoFiltering(t *testing.T) {
 		})
 	}
 }
+
+type fakeStreamingJob struct {
+	events int
+}
+
+func (j *fakeStreamingJob) Run(ctx context.Context, db database.DB, s streaming.Sender) (*search.Alert, error) {
+	for i := 0; i < j.events; i++ {
+		s.Send(streaming.SearchEvent{Results: result.Matches{&result.RepoMatch{Name: "repo", ID: 1}}})
+		time.Sleep(5 * time.Millisecond)
+	}
+	return nil, nil
+}
+
+func (j *fakeStreamingJob) Name() string { return "FakeStreamingJob" }
+
+func TestStreamStats(t *testing.T) {
+	orig := streamStatsInterval
+	streamStatsInterval = 10 * time.Millisecond
+	defer func() { streamStatsInterval = orig }()
+
+	r := &searchResolver{db: database.NewMockDB()}
+
+	var (
+		mu        sync.Mutex
+		snapshots []*searchResultsStats
+	)
+	err := r.streamStats(context.Background(), &fakeStreamingJob{events: 5}, func(partial *searchResultsStats) {
+		mu.Lock()
+		snapshots = append(snapshots, partial)
+		mu.Unlock()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) == 0 {
+		t.Fatal("expected at least one partial snapshot before completion")
+	}
+}
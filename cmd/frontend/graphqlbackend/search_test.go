@@ -418,3 +418,31 @@ func zoektRPC(t testing.TB, s zoekt.Streamer) zoekt.Streamer {
 	})
 	return cl
 }
+
+func TestDecodedViewerFinalSettings_ContextOverride(t *testing.T) {
+	t.Parallel()
+
+	settingsA := &schema.Settings{}
+	settingsB := &schema.Settings{}
+
+	for _, tc := range []struct {
+		name     string
+		settings *schema.Settings
+	}{
+		{name: "contextA", settings: settingsA},
+		{name: "contextB", settings: settingsB},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := WithViewerSettings(context.Background(), tc.settings)
+			got, err := DecodedViewerFinalSettings(ctx, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.settings {
+				t.Errorf("got %p, want %p", got, tc.settings)
+			}
+		})
+	}
+}
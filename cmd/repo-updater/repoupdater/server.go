@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/inconshreveable/log15"
@@ -51,6 +52,9 @@ type Server struct {
 		// ScheduleRepos schedules new permissions syncing requests for given repositories.
 		ScheduleRepos(ctx context.Context, repoIDs ...api.RepoID)
 	}
+	// ExternalServicesChanged, if set, is invoked whenever an external service
+	// was created or its config updated, i.e. on /sync-external-service.
+	ExternalServicesChanged func()
 }
 
 // Handler returns the http.Handler that should be used to serve requests.
@@ -63,11 +67,238 @@ func (s *Server) Handler() http.Handler {
 	mux.HandleFunc("/repo-lookup", s.handleRepoLookup)
 	mux.HandleFunc("/enqueue-repo-update", s.handleEnqueueRepoUpdate)
 	mux.HandleFunc("/sync-external-service", s.handleExternalServiceSync)
+	mux.HandleFunc("/check-external-service-connection", s.handleExternalServiceCheckConnection)
+	mux.HandleFunc("/repos-pending-deletion", s.handleReposPendingDeletion)
+	mux.HandleFunc("/external-services-summary", s.handleExternalServicesSummary)
+	mux.HandleFunc("/external-service-schedule", s.handleExternalServiceSchedule)
+	mux.HandleFunc("/external-service-repos", s.handleExternalServiceRepos)
 	mux.HandleFunc("/enqueue-changeset-sync", s.handleEnqueueChangesetSync)
 	mux.HandleFunc("/schedule-perms-sync", s.handleSchedulePermsSync)
 	return mux
 }
 
+func (s *Server) handleExternalServiceCheckConnection(w http.ResponseWriter, r *http.Request) {
+	var req protocol.ExternalServiceCheckConnectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	svc, err := s.Store.ExternalServiceStore.GetByID(ctx, req.ExternalServiceID)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			respond(w, http.StatusNotFound, err)
+			return
+		}
+		respond(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	var sourcer repos.Sourcer
+	if sourcer = s.Sourcer; sourcer == nil {
+		sourcer = repos.NewSourcer(httpcli.ExternalClientFactory, repos.WithDB(s.Handle().DB()))
+	}
+	src, err := sourcer(svc)
+	if err != nil {
+		respond(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	result := &protocol.ExternalServiceCheckConnectionResult{}
+	if err := src.CheckConnection(ctx); err != nil {
+		result.Error = err.Error()
+	}
+	respond(w, http.StatusOK, result)
+}
+
+// handleReposPendingDeletion is a debug endpoint listing repos that have been
+// soft deleted but whose clones haven't been purged from gitserver yet, so
+// operators can see what's scheduled for removal and intervene.
+func (s *Server) handleReposPendingDeletion(w http.ResponseWriter, r *http.Request) {
+	pending, err := s.Store.ListReposPendingDeletion(r.Context())
+	if err != nil {
+		respond(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	type repoPendingDeletion struct {
+		Name      string    `json:"name"`
+		DeletedAt time.Time `json:"deletedAt"`
+	}
+	result := make([]repoPendingDeletion, 0, len(pending))
+	for _, repo := range pending {
+		result = append(result, repoPendingDeletion{
+			Name:      string(repo.Name),
+			DeletedAt: repo.DeletedAt,
+		})
+	}
+	respond(w, http.StatusOK, result)
+}
+
+// externalServiceSummary is the per-kind summary returned by the
+// /external-services-summary debug endpoint.
+type externalServiceSummary struct {
+	Total       int `json:"total"`
+	WithError   int `json:"withError"`
+	NeverSynced int `json:"neverSynced"`
+}
+
+// handleExternalServicesSummary is a debug endpoint giving a quick overview of
+// how many external services exist per kind, how many of them are failing
+// their most recent sync and how many have never synced at all.
+func (s *Server) handleExternalServicesSummary(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	kinds, err := s.Store.ExternalServiceStore.DistinctKinds(ctx)
+	if err != nil {
+		respond(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	summary := make(map[string]externalServiceSummary, len(kinds))
+	for _, kind := range kinds {
+		svcs, err := s.Store.ExternalServiceStore.List(ctx, database.ExternalServicesListOptions{Kinds: []string{kind}})
+		if err != nil {
+			respond(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		var stats externalServiceSummary
+		for _, svc := range svcs {
+			stats.Total++
+			if svc.LastSyncAt.IsZero() {
+				stats.NeverSynced++
+				continue
+			}
+			lastError, err := s.Store.ExternalServiceStore.GetLastSyncError(ctx, svc.ID)
+			if err != nil {
+				respond(w, http.StatusInternalServerError, err)
+				return
+			}
+			if lastError != "" {
+				stats.WithError++
+			}
+		}
+		summary[kind] = stats
+	}
+
+	respond(w, http.StatusOK, summary)
+}
+
+// externalServiceSchedule is the response of the /external-service-schedule
+// debug endpoint.
+type externalServiceSchedule struct {
+	ID          int64      `json:"id"`
+	Kind        string     `json:"kind"`
+	DisplayName string     `json:"displayName"`
+	LastSyncAt  *time.Time `json:"lastSyncAt,omitempty"`
+	NextSyncAt  *time.Time `json:"nextSyncAt,omitempty"`
+	LastError   string     `json:"lastError,omitempty"`
+	SyncDue     bool       `json:"syncDue"`
+}
+
+// handleExternalServiceSchedule is a debug endpoint showing when a single
+// external service last synced, when it's next due, its last sync error and
+// whether the syncer considers it due right now, so operators can answer "why
+// isn't this service syncing".
+func (s *Server) handleExternalServiceSchedule(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+	if err != nil {
+		respond(w, http.StatusBadRequest, errors.Wrap(err, "invalid id"))
+		return
+	}
+
+	ctx := r.Context()
+	svc, err := s.Store.ExternalServiceStore.GetByID(ctx, id)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			respond(w, http.StatusNotFound, err)
+			return
+		}
+		respond(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	lastError, err := s.Store.ExternalServiceStore.GetLastSyncError(ctx, id)
+	if err != nil {
+		respond(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	due, err := s.Store.ExternalServiceStore.SyncDue(ctx, []int64{id}, 0)
+	if err != nil {
+		respond(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	schedule := externalServiceSchedule{
+		ID:          svc.ID,
+		Kind:        svc.Kind,
+		DisplayName: svc.DisplayName,
+		LastError:   lastError,
+		SyncDue:     due,
+	}
+	if !svc.LastSyncAt.IsZero() {
+		lastSyncAt := svc.LastSyncAt
+		schedule.LastSyncAt = &lastSyncAt
+	}
+	if !svc.NextSyncAt.IsZero() {
+		nextSyncAt := svc.NextSyncAt
+		schedule.NextSyncAt = &nextSyncAt
+	}
+	respond(w, http.StatusOK, schedule)
+}
+
+// externalServiceRepo is a single repo returned by the /external-service-repos
+// debug endpoint.
+type externalServiceRepo struct {
+	ID   api.RepoID   `json:"id"`
+	Name api.RepoName `json:"name"`
+}
+
+// handleExternalServiceRepos is a debug endpoint paging through the repos added
+// by a single external service, so operators can verify a sync produced the
+// expected repos.
+func (s *Server) handleExternalServiceRepos(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+	if err != nil {
+		respond(w, http.StatusBadRequest, errors.Wrap(err, "invalid id"))
+		return
+	}
+
+	limit := 100
+	if v := r.URL.Query().Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil || limit < 0 {
+			respond(w, http.StatusBadRequest, errors.Newf("invalid limit %q", v))
+			return
+		}
+	}
+	offset := 0
+	if v := r.URL.Query().Get("offset"); v != "" {
+		if offset, err = strconv.Atoi(v); err != nil || offset < 0 {
+			respond(w, http.StatusBadRequest, errors.Newf("invalid offset %q", v))
+			return
+		}
+	}
+
+	rs, err := s.Store.ExternalServiceStore.ListRepos(r.Context(), id, database.LimitOffset{Limit: limit, Offset: offset})
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			respond(w, http.StatusNotFound, err)
+			return
+		}
+		respond(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	result := make([]externalServiceRepo, 0, len(rs))
+	for _, repo := range rs {
+		result = append(result, externalServiceRepo{ID: repo.ID, Name: repo.Name})
+	}
+	respond(w, http.StatusOK, result)
+}
+
 // TODO(tsenart): Reuse this function in all handlers.
 func respond(w http.ResponseWriter, code int, v interface{}) {
 	switch val := v.(type) {
@@ -242,6 +473,10 @@ func (s *Server) handleExternalServiceSync(w http.ResponseWriter, r *http.Reques
 		}
 	}
 
+	if s.ExternalServicesChanged != nil {
+		s.ExternalServicesChanged()
+	}
+
 	log15.Info("server.external-service-sync", "synced", req.ExternalService.Kind)
 	respond(w, http.StatusOK, &protocol.ExternalServiceSyncResult{
 		ExternalService: req.ExternalService,
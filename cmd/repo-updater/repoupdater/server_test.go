@@ -809,6 +809,265 @@ func TestExternalServiceValidate_ValidatesToken(t *testing.T) {
 	}
 }
 
+func TestServer_handleExternalServiceCheckConnection(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		wantError string
+	}{
+		{
+			name: "check passes",
+		},
+		{
+			name:      "check fails",
+			err:       errors.New("connection check failed"),
+			wantError: "connection check failed",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			svc := &types.ExternalService{
+				ID:          1,
+				Kind:        extsvc.KindGitHub,
+				DisplayName: "github.com - test",
+				Config:      `{"url": "https://github.com"}`,
+			}
+
+			externalServices := database.NewMockExternalServiceStore()
+			externalServices.GetByIDFunc.SetDefaultReturn(svc, nil)
+
+			src := repos.NewFakeSource(svc, test.err)
+			s := &Server{
+				Store:  &repos.Store{ExternalServiceStore: externalServices},
+				Syncer: &repos.Syncer{Sourcer: repos.NewFakeSourcer(nil, src)},
+			}
+
+			r := httptest.NewRequest("POST", "/check-external-service-connection", strings.NewReader(`{"ExternalServiceID": 1}`))
+			w := httptest.NewRecorder()
+			s.handleExternalServiceCheckConnection(w, r)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("Code: want %v but got %v", http.StatusOK, w.Code)
+			}
+			var result protocol.ExternalServiceCheckConnectionResult
+			if err := json.NewDecoder(w.Body).Decode(&result); err != nil {
+				t.Fatal(err)
+			}
+			if result.Error != test.wantError {
+				t.Errorf("Error: want %q but got %q", test.wantError, result.Error)
+			}
+		})
+	}
+}
+
+func TestServer_handleExternalServicesSummary(t *testing.T) {
+	githubSynced := &types.ExternalService{ID: 1, Kind: extsvc.KindGitHub, LastSyncAt: time.Now()}
+	githubFailing := &types.ExternalService{ID: 2, Kind: extsvc.KindGitHub, LastSyncAt: time.Now()}
+	gitlabNeverSynced := &types.ExternalService{ID: 3, Kind: extsvc.KindGitLab}
+
+	externalServices := database.NewMockExternalServiceStore()
+	externalServices.DistinctKindsFunc.SetDefaultReturn([]string{extsvc.KindGitHub, extsvc.KindGitLab}, nil)
+	externalServices.ListFunc.SetDefaultHook(func(_ context.Context, opt database.ExternalServicesListOptions) ([]*types.ExternalService, error) {
+		if opt.Kinds[0] == extsvc.KindGitHub {
+			return []*types.ExternalService{githubSynced, githubFailing}, nil
+		}
+		return []*types.ExternalService{gitlabNeverSynced}, nil
+	})
+	externalServices.GetLastSyncErrorFunc.SetDefaultHook(func(_ context.Context, id int64) (string, error) {
+		if id == githubFailing.ID {
+			return "bad credentials", nil
+		}
+		return "", nil
+	})
+
+	s := &Server{Store: &repos.Store{ExternalServiceStore: externalServices}}
+
+	r := httptest.NewRequest("GET", "/external-services-summary", nil)
+	w := httptest.NewRecorder()
+	s.handleExternalServicesSummary(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Code: want %v but got %v", http.StatusOK, w.Code)
+	}
+	var summary map[string]externalServiceSummary
+	if err := json.NewDecoder(w.Body).Decode(&summary); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]externalServiceSummary{
+		extsvc.KindGitHub: {Total: 2, WithError: 1},
+		extsvc.KindGitLab: {Total: 1, NeverSynced: 1},
+	}
+	if diff := cmp.Diff(want, summary); diff != "" {
+		t.Fatalf("Summary mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestServer_handleExternalServiceSchedule(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	svc := &types.ExternalService{
+		ID:          1,
+		Kind:        extsvc.KindGitHub,
+		DisplayName: "github.com - test",
+		LastSyncAt:  now.Add(-time.Hour),
+		NextSyncAt:  now.Add(time.Hour),
+	}
+
+	externalServices := database.NewMockExternalServiceStore()
+	externalServices.GetByIDFunc.SetDefaultHook(func(_ context.Context, id int64) (*types.ExternalService, error) {
+		if id != svc.ID {
+			return nil, notFoundError{errors.Newf("external service not found: %d", id)}
+		}
+		return svc, nil
+	})
+	externalServices.GetLastSyncErrorFunc.SetDefaultReturn("bad credentials", nil)
+	externalServices.SyncDueFunc.SetDefaultReturn(true, nil)
+
+	s := &Server{Store: &repos.Store{ExternalServiceStore: externalServices}}
+
+	t.Run("found", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/external-service-schedule?id=1", nil)
+		w := httptest.NewRecorder()
+		s.handleExternalServiceSchedule(w, r)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Code: want %v but got %v", http.StatusOK, w.Code)
+		}
+		var schedule externalServiceSchedule
+		if err := json.NewDecoder(w.Body).Decode(&schedule); err != nil {
+			t.Fatal(err)
+		}
+		if schedule.ID != svc.ID || schedule.Kind != svc.Kind {
+			t.Errorf("want service %d of kind %s, got %+v", svc.ID, svc.Kind, schedule)
+		}
+		if schedule.LastSyncAt == nil || !schedule.LastSyncAt.Equal(svc.LastSyncAt) {
+			t.Errorf("LastSyncAt: want %v but got %v", svc.LastSyncAt, schedule.LastSyncAt)
+		}
+		if schedule.NextSyncAt == nil || !schedule.NextSyncAt.Equal(svc.NextSyncAt) {
+			t.Errorf("NextSyncAt: want %v but got %v", svc.NextSyncAt, schedule.NextSyncAt)
+		}
+		if schedule.LastError != "bad credentials" {
+			t.Errorf("LastError: want %q but got %q", "bad credentials", schedule.LastError)
+		}
+		if !schedule.SyncDue {
+			t.Error("SyncDue: want true")
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/external-service-schedule?id=99", nil)
+		w := httptest.NewRecorder()
+		s.handleExternalServiceSchedule(w, r)
+
+		if w.Code != http.StatusNotFound {
+			t.Fatalf("Code: want %v but got %v", http.StatusNotFound, w.Code)
+		}
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/external-service-schedule?id=banana", nil)
+		w := httptest.NewRecorder()
+		s.handleExternalServiceSchedule(w, r)
+
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("Code: want %v but got %v", http.StatusBadRequest, w.Code)
+		}
+	})
+}
+
+func TestServer_handleExternalServiceRepos(t *testing.T) {
+	allRepos := []*types.Repo{
+		{ID: 1, Name: "github.com/foo/one"},
+		{ID: 2, Name: "github.com/foo/two"},
+		{ID: 3, Name: "github.com/foo/three"},
+	}
+
+	externalServices := database.NewMockExternalServiceStore()
+	externalServices.ListReposFunc.SetDefaultHook(func(_ context.Context, id int64, opts database.LimitOffset) ([]*types.Repo, error) {
+		if id != 1 {
+			return nil, notFoundError{errors.Newf("external service not found: %d", id)}
+		}
+		rs := allRepos
+		if opts.Offset < len(rs) {
+			rs = rs[opts.Offset:]
+		} else {
+			rs = nil
+		}
+		if opts.Limit < len(rs) {
+			rs = rs[:opts.Limit]
+		}
+		return rs, nil
+	})
+
+	s := &Server{Store: &repos.Store{ExternalServiceStore: externalServices}}
+
+	t.Run("page", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/external-service-repos?id=1&limit=2&offset=1", nil)
+		w := httptest.NewRecorder()
+		s.handleExternalServiceRepos(w, r)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Code: want %v but got %v", http.StatusOK, w.Code)
+		}
+		var page []externalServiceRepo
+		if err := json.NewDecoder(w.Body).Decode(&page); err != nil {
+			t.Fatal(err)
+		}
+		want := []externalServiceRepo{
+			{ID: 2, Name: "github.com/foo/two"},
+			{ID: 3, Name: "github.com/foo/three"},
+		}
+		if diff := cmp.Diff(want, page); diff != "" {
+			t.Fatalf("page mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/external-service-repos?id=99", nil)
+		w := httptest.NewRecorder()
+		s.handleExternalServiceRepos(w, r)
+
+		if w.Code != http.StatusNotFound {
+			t.Fatalf("Code: want %v but got %v", http.StatusNotFound, w.Code)
+		}
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/external-service-repos?id=1&limit=banana", nil)
+		w := httptest.NewRecorder()
+		s.handleExternalServiceRepos(w, r)
+
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("Code: want %v but got %v", http.StatusBadRequest, w.Code)
+		}
+	})
+
+	t.Run("negative limit", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/external-service-repos?id=1&limit=-1", nil)
+		w := httptest.NewRecorder()
+		s.handleExternalServiceRepos(w, r)
+
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("Code: want %v but got %v", http.StatusBadRequest, w.Code)
+		}
+	})
+
+	t.Run("negative offset", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/external-service-repos?id=1&offset=-1", nil)
+		w := httptest.NewRecorder()
+		s.handleExternalServiceRepos(w, r)
+
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("Code: want %v but got %v", http.StatusBadRequest, w.Code)
+		}
+	})
+}
+
+// notFoundError wraps an error so it satisfies errcode.IsNotFound.
+type notFoundError struct{ error }
+
+func (notFoundError) NotFound() bool { return true }
+
 type testSource struct {
 	fn func() error
 }
@@ -832,3 +1091,7 @@ func (t testSource) WithAuthenticator(a auth.Authenticator) (repos.Source, error
 func (t testSource) ValidateAuthenticator(ctx context.Context) error {
 	return t.fn()
 }
+
+func (t testSource) CheckConnection(ctx context.Context) error {
+	return t.fn()
+}
@@ -10,6 +10,9 @@ import (
 	"log"
 	"net"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"golang.org/x/time/rate"
@@ -36,6 +39,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/httpserver"
@@ -53,6 +57,22 @@ import (
 
 const port = "3182"
 
+// rateLimitSyncerTimeout bounds the initial rate limit sync performed during
+// startup. On instances with many external services the sync can take a long
+// time and we don't want it to delay readiness, so on timeout we continue
+// startup and rely on the syncer running again on external service changes.
+var rateLimitSyncerTimeout, _ = time.ParseDuration(env.Get("SRC_RATE_LIMIT_SYNC_TIMEOUT", "1m", "Timeout for the initial rate limit sync performed at startup"))
+
+// skipArchivedUpdates stops the scheduler relay from enqueueing git updates
+// for archived repos synced from code hosts. Archived repos rarely change, so
+// operators with many of them can opt out of keeping their clones fresh.
+var skipArchivedUpdates, _ = strconv.ParseBool(env.Get("SRC_REPOS_SKIP_ARCHIVED_UPDATES", "false", "Don't schedule git updates for archived repositories"))
+
+// additionalPackageHosts lists external service types treated as package hosts
+// in addition to the built-in ones, so lazy-syncing can be enabled for a
+// newly-supported ecosystem without a release.
+var additionalPackageHosts = env.Get("SRC_REPOS_ADDITIONAL_PACKAGE_HOSTS", "", "Comma-separated list of external service types treated as package hosts")
+
 //go:embed state.html.tmpl
 var stateHTMLTemplate string
 
@@ -64,6 +84,8 @@ type LazyDebugserverEndpoint struct {
 	repoUpdaterStateEndpoint   http.HandlerFunc
 	listAuthzProvidersEndpoint http.HandlerFunc
 	gitserverReposStatus       http.HandlerFunc
+	healthCheck                http.HandlerFunc
+	inFlightSyncs              http.HandlerFunc
 }
 
 func Main(enterpriseInit EnterpriseInit) {
@@ -142,11 +164,7 @@ func Main(enterpriseInit EnterpriseInit) {
 	rateLimitSyncer := repos.NewRateLimitSyncer(ratelimit.DefaultRegistry, store.ExternalServiceStore)
 	server.RateLimitSyncer = rateLimitSyncer
 	// Attempt to perform an initial sync with all external services
-	if err := rateLimitSyncer.SyncRateLimiters(ctx); err != nil {
-		// This is not a fatal error since the syncer has been added to the server above
-		// and will still be run whenever an external service is added or updated
-		log15.Error("Performing initial rate limit sync", "err", err)
-	}
+	syncRateLimiters(ctx, rateLimitSyncer, rateLimitSyncerTimeout)
 
 	// All dependencies ready
 	var debugDumpers []debugserver.Dumper
@@ -159,29 +177,37 @@ func Main(enterpriseInit EnterpriseInit) {
 		Store:   store,
 		// We always want to listen on the Synced channel since external service syncing
 		// happens on both Cloud and non Cloud instances.
-		Synced:     make(chan repos.Diff),
-		Logger:     log15.Root(),
-		Now:        clock,
-		Registerer: prometheus.DefaultRegisterer,
+		Synced:                 make(chan repos.Diff),
+		Logger:                 log15.Root(),
+		Now:                    clock,
+		Registerer:             prometheus.DefaultRegisterer,
+		AdditionalPackageHosts: parseAdditionalPackageHosts(additionalPackageHosts),
 	}
 
 	var gps *repos.GitolitePhabricatorMetadataSyncer
 	if !envvar.SourcegraphDotComMode() {
 		gps = repos.NewGitolitePhabricatorMetadataSyncer(store)
 	}
+	gpsGate := &gitolitePhabricatorSyncGate{store: store.ExternalServiceStore}
+	server.ExternalServicesChanged = gpsGate.Invalidate
 
-	go watchSyncer(ctx, syncer, scheduler, gps, server.PermsSyncer)
+	go watchSyncer(ctx, syncer, scheduler, gps, gpsGate, server.PermsSyncer)
 	go func() {
 		log.Fatal(syncer.Run(ctx, store, repos.RunOptions{
 			EnqueueInterval: repos.ConfRepoListUpdateInterval,
 			IsCloud:         envvar.SourcegraphDotComMode(),
 			MinSyncInterval: repos.ConfRepoListUpdateInterval,
+			MaxSyncInterval: repos.ConfRepoListUpdateIntervalMax,
 		}))
 	}()
 	server.Syncer = syncer
 
 	go syncScheduler(ctx, scheduler, store)
 
+	go requeueStuckSyncJobs(ctx, store)
+
+	go reconcileExternalServiceRepos(ctx, store)
+
 	if envvar.SourcegraphDotComMode() {
 		rateLimiter := rate.NewLimiter(.05, 1)
 		go syncer.RunSyncReposWithLastErrorsWorker(ctx, rateLimiter)
@@ -223,6 +249,8 @@ func Main(enterpriseInit EnterpriseInit) {
 	debugserverEndpoints.repoUpdaterStateEndpoint = repoUpdaterStatsHandler(db, scheduler, debugDumpers)
 	debugserverEndpoints.listAuthzProvidersEndpoint = listAuthzProvidersHandler()
 	debugserverEndpoints.gitserverReposStatus = gitserverReposStatusHandler(db)
+	debugserverEndpoints.healthCheck = healthCheckHandler(db, gitserver.DefaultClient)
+	debugserverEndpoints.inFlightSyncs = inFlightSyncsHandler(syncer)
 
 	// We mark the service as ready now AFTER assigning the additional endpoints in
 	// the debugserver constructed at the top of this function. This ensures we don't
@@ -246,6 +274,31 @@ func Main(enterpriseInit EnterpriseInit) {
 	goroutine.MonitorBackgroundRoutines(ctx, httpSrv)
 }
 
+type rateLimiterSyncer interface {
+	SyncRateLimiters(ctx context.Context) error
+}
+
+// syncRateLimiters performs an initial sync of all rate limiters, bounded by
+// the given timeout. Errors and timeouts are not fatal since the syncer will
+// still be run whenever an external service is added or updated, so in both
+// cases we only log and let startup proceed.
+func syncRateLimiters(ctx context.Context, syncer rateLimiterSyncer, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- syncer.SyncRateLimiters(ctx) }()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			log15.Error("Performing initial rate limit sync", "err", err)
+		}
+	case <-ctx.Done():
+		log15.Warn("Initial rate limit sync timed out, continuing with startup", "timeout", timeout)
+	}
+}
+
 func createDebugServerRoutine(ready chan struct{}, debugserverEndpoints *LazyDebugserverEndpoint) goroutine.BackgroundRoutine {
 	return debugserver.NewServerRoutine(
 		ready,
@@ -277,9 +330,87 @@ func createDebugServerRoutine(ready chan struct{}, debugserverEndpoints *LazyDeb
 				debugserverEndpoints.gitserverReposStatus(w, r)
 			}),
 		},
+		debugserver.Endpoint{
+			Name: "Health Check",
+			Path: "/healthz",
+			Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				<-ready
+				debugserverEndpoints.healthCheck(w, r)
+			}),
+		},
+		debugserver.Endpoint{
+			Name: "In-Flight Syncs",
+			Path: "/in-flight-syncs",
+			Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				<-ready
+				debugserverEndpoints.inFlightSyncs(w, r)
+			}),
+		},
 	)
 }
 
+// gitserverPinger captures the part of the gitserver client the health check
+// uses to verify connectivity.
+type gitserverPinger interface {
+	ReposStats(ctx context.Context) (map[string]*protocol.ReposStats, error)
+}
+
+// healthCheckHandler reports whether the dependencies repo-updater needs to do
+// useful work are reachable. It returns 503 with a JSON body listing the
+// failing dependencies, and is cheap enough to be polled frequently.
+func healthCheckHandler(db dbutil.DB, gitserverClient gitserverPinger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+		defer cancel()
+
+		failed := map[string]string{}
+
+		var one int
+		if err := db.QueryRowContext(ctx, "SELECT 1").Scan(&one); err != nil {
+			failed["database"] = err.Error()
+		}
+
+		if _, err := gitserverClient.ReposStats(ctx); err != nil {
+			failed["gitserver"] = err.Error()
+		}
+
+		resp, err := json.MarshalIndent(struct {
+			Healthy bool              `json:"healthy"`
+			Failed  map[string]string `json:"failed,omitempty"`
+		}{Healthy: len(failed) == 0, Failed: failed}, "", "  ")
+		if err != nil {
+			http.Error(w, "failed to marshal health check response: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if len(failed) > 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_, _ = w.Write(resp)
+	}
+}
+
+// inFlightSyncer captures the part of the syncer the in-flight syncs debug
+// endpoint uses.
+type inFlightSyncer interface {
+	InFlightSyncs() []repos.InFlightSync
+}
+
+// inFlightSyncsHandler dumps the external service syncs currently being
+// processed, so operators can see what a seemingly stuck syncer is working on.
+func inFlightSyncsHandler(syncer inFlightSyncer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		resp, err := json.MarshalIndent(syncer.InFlightSyncs(), "", "  ")
+		if err != nil {
+			http.Error(w, "failed to marshal in-flight syncs: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(resp)
+	}
+}
+
 func gitserverReposStatusHandler(db database.DB) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		repo := r.FormValue("repo")
@@ -414,11 +545,63 @@ type permsSyncer interface {
 	ScheduleRepos(ctx context.Context, repoIDs ...api.RepoID)
 }
 
+// externalServiceKindsStore is the subset of the external service store used
+// by gitolitePhabricatorSyncGate.
+type externalServiceKindsStore interface {
+	DistinctKinds(ctx context.Context) ([]string, error)
+}
+
+// gitolitePhabricatorSyncGate reports whether any Gitolite or Phabricator
+// external services exist, so watchSyncer can skip the
+// GitolitePhabricatorMetadataSyncer entirely on instances without them. The
+// answer is cached until Invalidate is called on external-service changes.
+type gitolitePhabricatorSyncGate struct {
+	store externalServiceKindsStore
+
+	mu      sync.Mutex
+	valid   bool
+	enabled bool
+}
+
+// Invalidate discards the cached answer so the next Enabled call re-checks
+// the database.
+func (g *gitolitePhabricatorSyncGate) Invalidate() {
+	g.mu.Lock()
+	g.valid = false
+	g.mu.Unlock()
+}
+
+func (g *gitolitePhabricatorSyncGate) Enabled(ctx context.Context) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.valid {
+		kinds, err := g.store.DistinctKinds(ctx)
+		if err != nil {
+			// Fail open: a wasted metadata sync beats a silently missing one.
+			log15.Warn("listing external service kinds", "error", err)
+			return true
+		}
+
+		g.enabled = false
+		for _, kind := range kinds {
+			if kind == extsvc.KindGitolite || kind == extsvc.KindPhabricator {
+				g.enabled = true
+				break
+			}
+		}
+		g.valid = true
+	}
+
+	return g.enabled
+}
+
 func watchSyncer(
 	ctx context.Context,
 	syncer *repos.Syncer,
 	sched scheduler,
 	gps *repos.GitolitePhabricatorMetadataSyncer,
+	gpsGate *gitolitePhabricatorSyncGate,
 	permsSyncer permsSyncer,
 ) {
 	log15.Debug("started new repo syncer updates scheduler relay thread")
@@ -429,7 +612,7 @@ func watchSyncer(
 			return
 		case diff := <-syncer.Synced:
 			if !conf.Get().DisableAutoGitUpdates {
-				sched.UpdateFromDiff(diff)
+				sched.UpdateFromDiff(filterDiffForScheduling(diff, skipArchivedUpdates))
 			}
 
 			// PermsSyncer is only available in enterprise mode.
@@ -453,7 +636,7 @@ func watchSyncer(
 				permsSyncer.ScheduleRepos(ctx, repoIDs...)
 			}
 
-			if gps == nil {
+			if gps == nil || !gpsGate.Enabled(ctx) {
 				continue
 			}
 
@@ -467,6 +650,42 @@ func watchSyncer(
 	}
 }
 
+// parseAdditionalPackageHosts splits the comma-separated service type list
+// from the environment, dropping empty entries.
+func parseAdditionalPackageHosts(value string) []string {
+	var serviceTypes []string
+	for _, serviceType := range strings.Split(value, ",") {
+		if serviceType = strings.TrimSpace(serviceType); serviceType != "" {
+			serviceTypes = append(serviceTypes, serviceType)
+		}
+	}
+	return serviceTypes
+}
+
+// filterDiffForScheduling removes archived repos from the added, modified and
+// unmodified sets of diff, so the update scheduler doesn't enqueue git updates
+// for them. Deletions are kept so archived repos still drop out of the
+// schedule.
+func filterDiffForScheduling(diff repos.Diff, skipArchived bool) repos.Diff {
+	if !skipArchived {
+		return diff
+	}
+
+	filter := func(rs types.Repos) types.Repos {
+		filtered := rs[:0:0]
+		for _, r := range rs {
+			if !r.Archived {
+				filtered = append(filtered, r)
+			}
+		}
+		return filtered
+	}
+	diff.Added = filter(diff.Added)
+	diff.Modified = filter(diff.Modified)
+	diff.Unmodified = filter(diff.Unmodified)
+	return diff
+}
+
 // syncScheduler will periodically list the cloned repositories on gitserver and
 // update the scheduler with the list. It also ensures that if any of our default
 // repos are missing from the cloned list they will be added for cloning ASAP.
@@ -518,3 +737,45 @@ func syncScheduler(ctx context.Context, sched scheduler, store *repos.Store) {
 		}
 	}
 }
+
+// reconcileExternalServiceRepos periodically removes external_service_repos
+// rows whose repo or service was soft-deleted without the delete triggers
+// cleaning them up.
+func reconcileExternalServiceRepos(ctx context.Context, store *repos.Store) {
+	const interval = time.Hour
+
+	for ctx.Err() == nil {
+		removed, err := store.ExternalServiceStore.ReconcileExternalServiceRepos(ctx)
+		if err != nil {
+			log15.Error("Reconciling external service repos", "error", err)
+		} else if removed > 0 {
+			log15.Warn("Removed orphaned external service repo rows", "count", removed)
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(interval):
+		}
+	}
+}
+
+// requeueStuckSyncJobs periodically resets sync jobs orphaned by a dead worker
+// back to queued so another worker picks them up.
+func requeueStuckSyncJobs(ctx context.Context, store *repos.Store) {
+	const (
+		interval  = 5 * time.Minute
+		olderThan = 30 * time.Minute
+	)
+
+	for ctx.Err() == nil {
+		requeued, err := store.ExternalServiceStore.RequeueStuckSyncJobs(ctx, olderThan)
+		if err != nil {
+			log15.Error("Requeueing stuck external service sync jobs", "error", err)
+		} else if requeued > 0 {
+			log15.Warn("Requeued stuck external service sync jobs", "count", requeued)
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(interval):
+		}
+	}
+}
@@ -0,0 +1,266 @@
+package shared
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/repos"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type blockingRateLimiterSyncer struct {
+	started chan struct{}
+}
+
+func (s *blockingRateLimiterSyncer) SyncRateLimiters(ctx context.Context) error {
+	close(s.started)
+	// Block until well after the timeout under test has expired.
+	time.Sleep(time.Minute)
+	return nil
+}
+
+func TestSyncRateLimiters_Timeout(t *testing.T) {
+	syncer := &blockingRateLimiterSyncer{started: make(chan struct{})}
+
+	done := make(chan struct{})
+	go func() {
+		syncRateLimiters(context.Background(), syncer, 10*time.Millisecond)
+		close(done)
+	}()
+
+	select {
+	case <-syncer.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("syncer never started")
+	}
+
+	// Startup should proceed once the timeout expires, even though the syncer
+	// is still blocked.
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("syncRateLimiters did not return after the timeout expired")
+	}
+}
+
+// failingConnector is a database/sql connector that always fails to connect.
+type failingConnector struct{}
+
+func (failingConnector) Connect(context.Context) (driver.Conn, error) {
+	return nil, errors.New("database down")
+}
+
+func (failingConnector) Driver() driver.Driver { return nil }
+
+// healthyConnector is a database/sql connector whose connections answer every
+// query with a single row, enough for the health check's "SELECT 1".
+type healthyConnector struct{}
+
+func (healthyConnector) Connect(context.Context) (driver.Conn, error) { return healthyConn{}, nil }
+
+func (healthyConnector) Driver() driver.Driver { return nil }
+
+type healthyConn struct{}
+
+func (healthyConn) Prepare(query string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+
+func (healthyConn) Close() error { return nil }
+
+func (healthyConn) Begin() (driver.Tx, error) { return nil, driver.ErrSkip }
+
+func (healthyConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return &singleRow{}, nil
+}
+
+type singleRow struct{ done bool }
+
+func (r *singleRow) Columns() []string { return []string{"one"} }
+
+func (r *singleRow) Close() error { return nil }
+
+func (r *singleRow) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = int64(1)
+	return nil
+}
+
+type fakeGitserverPinger struct {
+	err error
+}
+
+func (p *fakeGitserverPinger) ReposStats(ctx context.Context) (map[string]*protocol.ReposStats, error) {
+	return nil, p.err
+}
+
+func TestHealthCheckHandler(t *testing.T) {
+	healthyDB := sql.OpenDB(healthyConnector{})
+
+	for _, tc := range []struct {
+		name     string
+		db       dbutil.DB
+		pinger   *fakeGitserverPinger
+		wantCode int
+		wantBody string
+	}{
+		{
+			name:     "healthy",
+			db:       healthyDB,
+			pinger:   &fakeGitserverPinger{},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "database down",
+			db:       sql.OpenDB(failingConnector{}),
+			pinger:   &fakeGitserverPinger{},
+			wantCode: http.StatusServiceUnavailable,
+			wantBody: "database",
+		},
+		{
+			name:     "gitserver down",
+			db:       healthyDB,
+			pinger:   &fakeGitserverPinger{err: errors.New("gitserver down")},
+			wantCode: http.StatusServiceUnavailable,
+			wantBody: "gitserver",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/healthz", nil)
+
+			healthCheckHandler(tc.db, tc.pinger)(rec, req)
+
+			if rec.Code != tc.wantCode {
+				t.Errorf("unexpected status code. want=%d have=%d", tc.wantCode, rec.Code)
+			}
+			if tc.wantBody != "" && !strings.Contains(rec.Body.String(), tc.wantBody) {
+				t.Errorf("body does not mention failing dependency %q: %s", tc.wantBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+// fakeKindsStore returns a fixed set of external service kinds and counts how
+// often it's asked.
+type fakeKindsStore struct {
+	kinds []string
+	calls int
+}
+
+func (s *fakeKindsStore) DistinctKinds(context.Context) ([]string, error) {
+	s.calls++
+	return s.kinds, nil
+}
+
+func TestGitolitePhabricatorSyncGate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("no gitolite or phabricator services", func(t *testing.T) {
+		store := &fakeKindsStore{kinds: []string{extsvc.KindGitHub, extsvc.KindGitLab}}
+		gate := &gitolitePhabricatorSyncGate{store: store}
+
+		if gate.Enabled(ctx) {
+			t.Fatal("want gate disabled without Gitolite or Phabricator services")
+		}
+
+		// The answer is cached until invalidated.
+		gate.Enabled(ctx)
+		if store.calls != 1 {
+			t.Fatalf("want 1 store call while cached, got %d", store.calls)
+		}
+
+		store.kinds = append(store.kinds, extsvc.KindGitolite)
+		gate.Invalidate()
+		if !gate.Enabled(ctx) {
+			t.Fatal("want gate enabled after a Gitolite service appeared")
+		}
+		if store.calls != 2 {
+			t.Fatalf("want a fresh store call after Invalidate, got %d", store.calls)
+		}
+	})
+
+	t.Run("phabricator service", func(t *testing.T) {
+		store := &fakeKindsStore{kinds: []string{extsvc.KindPhabricator}}
+		gate := &gitolitePhabricatorSyncGate{store: store}
+
+		if !gate.Enabled(ctx) {
+			t.Fatal("want gate enabled with a Phabricator service")
+		}
+	})
+}
+
+type fakeInFlightSyncer struct {
+	syncs []repos.InFlightSync
+}
+
+func (f *fakeInFlightSyncer) InFlightSyncs() []repos.InFlightSync { return f.syncs }
+
+func TestInFlightSyncsHandler(t *testing.T) {
+	started := time.Date(2022, 2, 1, 10, 0, 0, 0, time.UTC)
+	handler := inFlightSyncsHandler(&fakeInFlightSyncer{syncs: []repos.InFlightSync{
+		{ExternalServiceID: 42, StartedAt: started},
+	}})
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest("GET", "/in-flight-syncs", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("want status 200, got %d", rec.Code)
+	}
+	body := rec.Body.String()
+	if !strings.Contains(body, `"external_service_id": 42`) {
+		t.Fatalf("want service 42 in response, got %s", body)
+	}
+	if !strings.Contains(body, started.Format("2006-01-02")) {
+		t.Fatalf("want start time in response, got %s", body)
+	}
+}
+
+func TestFilterDiffForScheduling(t *testing.T) {
+	active := &types.Repo{ID: 1, Name: "github.com/foo/active"}
+	archived := &types.Repo{ID: 2, Name: "github.com/foo/archived", Archived: true}
+	deletedArchived := &types.Repo{ID: 3, Name: "github.com/foo/deleted", Archived: true}
+
+	diff := repos.Diff{
+		Added:      types.Repos{active, archived},
+		Modified:   types.Repos{archived},
+		Unmodified: types.Repos{archived},
+		Deleted:    types.Repos{deletedArchived},
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		got := filterDiffForScheduling(diff, false)
+		if len(got.Added) != 2 || len(got.Modified) != 1 || len(got.Unmodified) != 1 {
+			t.Fatalf("want diff untouched when disabled, got %+v", got)
+		}
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		got := filterDiffForScheduling(diff, true)
+		if len(got.Added) != 1 || got.Added[0].ID != active.ID {
+			t.Fatalf("want only the active repo to be added, got %+v", got.Added)
+		}
+		if len(got.Modified) != 0 {
+			t.Fatalf("want no archived repos in modified, got %+v", got.Modified)
+		}
+		if len(got.Unmodified) != 0 {
+			t.Fatalf("want no archived repos in unmodified, got %+v", got.Unmodified)
+		}
+		if len(got.Deleted) != 1 {
+			t.Fatalf("want deletions to be kept, got %+v", got.Deleted)
+		}
+	})
+}
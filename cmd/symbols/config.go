@@ -2,11 +2,13 @@ package main
 
 import (
 	"os"
+	"os/exec"
 	"runtime"
 	"strconv"
 	"time"
 
 	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 type Config struct {
@@ -17,12 +19,14 @@ type Config struct {
 	ctagsLogErrors          bool
 	ctagsDebugLogs          bool
 
-	sanityCheck       bool
-	cacheDir          string
-	cacheSizeMB       int
-	numCtagsProcesses int
-	requestBufferSize int
-	processingTimeout time.Duration
+	sanityCheck             bool
+	cacheDir                string
+	cacheSizeMB             int
+	numCtagsProcesses       int
+	requestBufferSize       int
+	rejectWhenBufferFull    bool
+	maxSymbolResultsPerRepo int
+	processingTimeout       time.Duration
 
 	// The maximum sum of lengths of all paths in a single call to git archive. Without this limit, we
 	// could hit the error "argument list too long" by exceeding the limit on the number of arguments to
@@ -34,6 +38,10 @@ type Config struct {
 	// We want to remain well under that limit, so defaulting to 100,000 seems safe (see the
 	// MAX_TOTAL_PATHS_LENGTH environment variable below).
 	maxTotalPathsLength int
+
+	// The maximum size in bytes of a single file we attempt to parse. Larger files (e.g. enormous
+	// generated files) are skipped during fetch rather than fed to ctags.
+	maxFileSizeBytes int
 }
 
 var config = &Config{}
@@ -41,6 +49,12 @@ var config = &Config{}
 // Load reads from the environment and stores the transformed data on the config object for later retrieval.
 func (c *Config) Load() {
 	c.ctagsCommand = c.Get("CTAGS_COMMAND", "universal-ctags", "ctags command (should point to universal-ctags executable compiled with JSON and seccomp support)")
+	// Fail fast at startup on a missing or non-executable ctags binary (e.g.
+	// when pointing a single instance at an alternate build), rather than
+	// discovering it on the first parse request.
+	if _, err := exec.LookPath(c.ctagsCommand); err != nil {
+		c.AddError(errors.Wrap(err, "invalid CTAGS_COMMAND"))
+	}
 	c.ctagsPatternLengthLimit = c.GetInt("CTAGS_PATTERN_LENGTH_LIMIT", "250", "the maximum length of the patterns output by ctags")
 	logCtagsErrorsDefault := "false"
 	if os.Getenv("DEPLOY_TYPE") == "dev" {
@@ -54,6 +68,9 @@ func (c *Config) Load() {
 	c.cacheSizeMB = c.GetInt("SYMBOLS_CACHE_SIZE_MB", "100000", "maximum size of the disk cache (in megabytes)")
 	c.numCtagsProcesses = c.GetInt("CTAGS_PROCESSES", strconv.Itoa(runtime.GOMAXPROCS(0)), "number of concurrent parser processes to run")
 	c.requestBufferSize = c.GetInt("REQUEST_BUFFER_SIZE", "8192", "maximum size of buffered parser request channel")
+	c.rejectWhenBufferFull = c.GetBool("REJECT_WHEN_BUFFER_FULL", "false", "reject search requests with a 503 instead of blocking when the request buffer is full")
+	c.maxSymbolResultsPerRepo = c.GetInt("MAX_SYMBOL_RESULTS_PER_REPO", "0", "maximum number of symbols a single repository contributes to a response; 0 means no per-repo cap")
 	c.processingTimeout = c.GetInterval("PROCESSING_TIMEOUT", "2h", "maximum time to spend processing a repository")
 	c.maxTotalPathsLength = c.GetInt("MAX_TOTAL_PATHS_LENGTH", "100000", "maximum sum of lengths of all paths in a single call to git archive")
+	c.maxFileSizeBytes = c.GetInt("MAX_FILE_SIZE_BYTES", "524288", "maximum size in bytes of a single file to parse for symbols; larger files are skipped")
 }
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfigCtagsCommand(t *testing.T) {
+	// An executable path validates cleanly.
+	ctags := filepath.Join(t.TempDir(), "ctags")
+	if err := os.WriteFile(ctags, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	config := Config{}
+	config.SetMockGetter(mapGetter(map[string]string{"CTAGS_COMMAND": ctags}))
+	config.Load()
+	if err := config.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %s", err)
+	}
+	if config.ctagsCommand != ctags {
+		t.Errorf("unexpected value for ctagsCommand. want=%s have=%s", ctags, config.ctagsCommand)
+	}
+
+	// A command that doesn't exist fails at startup, not on first parse.
+	config = Config{}
+	config.SetMockGetter(mapGetter(map[string]string{"CTAGS_COMMAND": "/no/such/ctags"}))
+	config.Load()
+	if err := config.Validate(); err == nil {
+		t.Fatal("expected validation error for non-existent ctags command")
+	}
+
+	// A file without the executable bit is also rejected.
+	nonExecutable := filepath.Join(t.TempDir(), "ctags")
+	if err := os.WriteFile(nonExecutable, []byte("#!/bin/sh\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	config = Config{}
+	config.SetMockGetter(mapGetter(map[string]string{"CTAGS_COMMAND": nonExecutable}))
+	config.Load()
+	if err := config.Validate(); err == nil {
+		t.Fatal("expected validation error for non-executable ctags command")
+	}
+}
+
+func mapGetter(env map[string]string) func(name, defaultValue, description string) string {
+	return func(name, defaultValue, description string) string {
+		if v, ok := env[name]; ok {
+			return v
+		}
+
+		return defaultValue
+	}
+}
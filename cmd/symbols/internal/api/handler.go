@@ -3,7 +3,9 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
+	"regexp"
 
 	"github.com/inconshreveable/log15"
 
@@ -19,14 +21,30 @@ type apiHandler struct {
 	cachedDatabaseWriter writer.CachedDatabaseWriter
 	ctagsBinary          string
 	operations           *operations
+
+	// inFlight bounds the number of concurrently handled search requests to
+	// the parser request buffer size. If rejectWhenBufferFull is set, search
+	// requests arriving while the buffer is full are rejected with a 503
+	// instead of blocking.
+	inFlight             chan struct{}
+	rejectWhenBufferFull bool
+
+	// maxSymbolResultsPerRepo caps how many symbols a single repository
+	// contributes to a response, so one huge repo can't dominate a multi-repo
+	// stream. Zero means no per-repo cap (the global result limit still
+	// applies).
+	maxSymbolResultsPerRepo int
 }
 
 func NewHandler(
 	cachedDatabaseWriter writer.CachedDatabaseWriter,
 	ctagsBinary string,
+	requestBufferSize int,
+	rejectWhenBufferFull bool,
+	maxSymbolResultsPerRepo int,
 	observationContext *observation.Context,
 ) http.Handler {
-	h := newAPIHandler(cachedDatabaseWriter, ctagsBinary, observationContext)
+	h := newAPIHandler(cachedDatabaseWriter, ctagsBinary, requestBufferSize, rejectWhenBufferFull, maxSymbolResultsPerRepo, observationContext)
 
 	mux := http.NewServeMux()
 	mux.HandleFunc("/search", h.handleSearch)
@@ -38,25 +56,59 @@ func NewHandler(
 func newAPIHandler(
 	cachedDatabaseWriter writer.CachedDatabaseWriter,
 	ctagsBinary string,
+	requestBufferSize int,
+	rejectWhenBufferFull bool,
+	maxSymbolResultsPerRepo int,
 	observationContext *observation.Context,
 ) *apiHandler {
 	return &apiHandler{
-		cachedDatabaseWriter: cachedDatabaseWriter,
-		ctagsBinary:          ctagsBinary,
-		operations:           newOperations(observationContext),
+		cachedDatabaseWriter:    cachedDatabaseWriter,
+		ctagsBinary:             ctagsBinary,
+		operations:              newOperations(observationContext),
+		inFlight:                make(chan struct{}, requestBufferSize),
+		rejectWhenBufferFull:    rejectWhenBufferFull,
+		maxSymbolResultsPerRepo: maxSymbolResultsPerRepo,
 	}
 }
 
 const maxNumSymbolResults = 500
 
+// retryAfterSeconds is the Retry-After value returned with rejected requests
+// when the request buffer is full.
+const retryAfterSeconds = "30"
+
+// truncatedHeader is set on responses whose results were cut off by the
+// per-repo cap, so aggregating callers can surface the truncation.
+const truncatedHeader = "X-Symbols-Truncated"
+
 func (h *apiHandler) handleSearch(w http.ResponseWriter, r *http.Request) {
+	if h.rejectWhenBufferFull {
+		select {
+		case h.inFlight <- struct{}{}:
+		default:
+			w.Header().Set("Retry-After", retryAfterSeconds)
+			http.Error(w, "request buffer full", http.StatusServiceUnavailable)
+			return
+		}
+	} else {
+		h.inFlight <- struct{}{}
+	}
+	defer func() { <-h.inFlight }()
+
 	var args types.SearchArgs
-	if err := json.NewDecoder(r.Body).Decode(&args); err != nil {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&args); err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	if args.First < 0 || args.First > maxNumSymbolResults {
+	if err := validateSearchArgs(args); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if args.First > maxNumSymbolResults {
 		args.First = maxNumSymbolResults
 	}
 
@@ -68,15 +120,92 @@ func (h *apiHandler) handleSearch(w http.ResponseWriter, r *http.Request) {
 		}
 
 		log15.Error("Symbol search failed", "args", args, "error", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeSearchError(w, err)
 		return
 	}
 
-	if err := json.NewEncoder(w).Encode(result); err != nil {
+	// The per-repo cap applies on top of args.First: this repo contributes at
+	// most maxSymbolResultsPerRepo symbols, and the header distinguishes that
+	// truncation from an exhausted result set.
+	if h.maxSymbolResultsPerRepo > 0 && result != nil && len(*result) > h.maxSymbolResultsPerRepo {
+		capped := (*result)[:h.maxSymbolResultsPerRepo]
+		result = &capped
+		w.Header().Set(truncatedHeader, "true")
+	}
+
+	// Stop encoding as soon as the client disconnects so large results don't
+	// keep burning CPU after the request context is cancelled.
+	if err := json.NewEncoder(&contextAwareWriter{ctx: r.Context(), w: w}).Encode(result); err != nil {
+		if r.Context().Err() == context.Canceled && errors.Is(err, context.Canceled) {
+			return
+		}
+
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
 }
 
+// validateSearchArgs rejects malformed search arguments before any work is
+// done, so client bugs surface as a 400 instead of an empty result or a
+// failure deep in the search.
+func validateSearchArgs(args types.SearchArgs) error {
+	if args.IsRegExp {
+		if _, err := regexp.Compile(args.Query); err != nil {
+			return errors.Wrap(err, "invalid query")
+		}
+	}
+	for _, pattern := range args.IncludePatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return errors.Wrap(err, "invalid include pattern")
+		}
+	}
+	if args.ExcludePattern != "" {
+		if _, err := regexp.Compile(args.ExcludePattern); err != nil {
+			return errors.Wrap(err, "invalid exclude pattern")
+		}
+	}
+	if args.First < 0 {
+		return errors.Newf("first must be non-negative, got %d", args.First)
+	}
+	return nil
+}
+
+// searchErrorPayload is the JSON body returned for failed search requests.
+type searchErrorPayload struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// writeSearchError maps typed search failures onto HTTP statuses and writes a
+// {code, message} JSON body. Unclassified failures remain a 500.
+func writeSearchError(w http.ResponseWriter, err error) {
+	status, code := http.StatusInternalServerError, "Unknown"
+	switch {
+	case errors.HasType(err, types.NotFoundError{}):
+		status, code = http.StatusNotFound, "NotFound"
+	case errors.HasType(err, types.CtagsFailureError{}):
+		status, code = http.StatusInternalServerError, "CtagsFailure"
+	case errors.HasType(err, types.TimeoutError{}):
+		status, code = http.StatusGatewayTimeout, "Timeout"
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(searchErrorPayload{Code: code, Message: err.Error()})
+}
+
+// contextAwareWriter fails writes once its context is cancelled.
+type contextAwareWriter struct {
+	ctx context.Context
+	w   io.Writer
+}
+
+func (w *contextAwareWriter) Write(p []byte) (int, error) {
+	if err := w.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return w.w.Write(p)
+}
+
 func (h *apiHandler) handleListLanguages(w http.ResponseWriter, r *http.Request) {
 	mapping, err := ctags.ListLanguageMappings(r.Context(), h.ctagsBinary)
 	if err != nil {
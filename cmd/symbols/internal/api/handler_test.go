@@ -2,9 +2,12 @@ package api
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
 	"net/http/httptest"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,12 +18,14 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/symbols/internal/fetcher"
 	"github.com/sourcegraph/sourcegraph/cmd/symbols/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/cmd/symbols/internal/parser"
+	"github.com/sourcegraph/sourcegraph/cmd/symbols/internal/types"
 	"github.com/sourcegraph/sourcegraph/internal/diskcache"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	symbolsclient "github.com/sourcegraph/sourcegraph/internal/symbols"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func init() {
@@ -50,10 +55,10 @@ func TestHandler(t *testing.T) {
 	gitserverClient := NewMockGitserverClient()
 	gitserverClient.FetchTarFunc.SetDefaultHook(gitserver.CreateTestFetchTarFunc(files))
 
-	parser := parser.NewParser(parserPool, fetcher.NewRepositoryFetcher(gitserverClient, 15, 1000, &observation.TestContext), 0, 10, &observation.TestContext)
+	parser := parser.NewParser(parserPool, fetcher.NewRepositoryFetcher(gitserverClient, 15, 1000, 1<<19, &observation.TestContext), 0, 10, &observation.TestContext)
 	databaseWriter := writer.NewDatabaseWriter(tmpDir, gitserverClient, parser)
 	cachedDatabaseWriter := writer.NewCachedDatabaseWriter(databaseWriter, cache)
-	handler := NewHandler(cachedDatabaseWriter, "", &observation.TestContext)
+	handler := NewHandler(cachedDatabaseWriter, "", 10, false, 0, &observation.TestContext)
 
 	server := httptest.NewServer(handler)
 	defer server.Close()
@@ -110,6 +115,18 @@ func TestHandler(t *testing.T) {
 			args:     search.SymbolsParameters{ExcludePattern: "a.js", IsCaseSensitive: true, First: 10},
 			expected: nil,
 		},
+		"includepathglob": {
+			args:     search.SymbolsParameters{IncludePaths: []string{"*.js"}, First: 10},
+			expected: []result.Symbol{x, y},
+		},
+		"includepathglobnomatch": {
+			args:     search.SymbolsParameters{IncludePaths: []string{"src/*"}, First: 10},
+			expected: nil,
+		},
+		"excludepathglob": {
+			args:     search.SymbolsParameters{ExcludePaths: []string{"*.js"}, First: 10},
+			expected: nil,
+		},
 	}
 
 	for label, testCase := range testCases {
@@ -130,6 +147,58 @@ func TestHandler(t *testing.T) {
 	}
 }
 
+func TestHandlerPerRepoCap(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cache := diskcache.NewStore(tmpDir, "symbols", diskcache.WithBackgroundTimeout(20*time.Minute))
+
+	parserFactory := func() (ctags.Parser, error) {
+		return newMockParser("x", "y"), nil
+	}
+	parserPool, err := parser.NewParserPool(parserFactory, 15)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"a.js": "var x = 1",
+	}
+	gitserverClient := NewMockGitserverClient()
+	gitserverClient.FetchTarFunc.SetDefaultHook(gitserver.CreateTestFetchTarFunc(files))
+
+	parser := parser.NewParser(parserPool, fetcher.NewRepositoryFetcher(gitserverClient, 15, 1000, 1<<19, &observation.TestContext), 0, 10, &observation.TestContext)
+	databaseWriter := writer.NewDatabaseWriter(tmpDir, gitserverClient, parser)
+	cachedDatabaseWriter := writer.NewCachedDatabaseWriter(databaseWriter, cache)
+
+	// A per-repo cap of 1 with a global limit of 10: the cap must kick in
+	// independently of args.First.
+	handler := NewHandler(cachedDatabaseWriter, "", 10, false, 1, &observation.TestContext)
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	resp, err := http.Post(server.URL+"/search", "application/json", strings.NewReader(`{"first": 10}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("want status %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+	if truncated := resp.Header.Get(truncatedHeader); truncated != "true" {
+		t.Fatalf("want %s header %q, got %q", truncatedHeader, "true", truncated)
+	}
+
+	var symbols result.Symbols
+	if err := json.NewDecoder(resp.Body).Decode(&symbols); err != nil {
+		t.Fatal(err)
+	}
+	if len(symbols) != 1 {
+		t.Fatalf("want 1 symbol after per-repo truncation, got %d", len(symbols))
+	}
+}
+
 type mockParser struct {
 	names []string
 }
@@ -148,3 +217,129 @@ func (m *mockParser) Parse(name string, content []byte) ([]*ctags.Entry, error)
 }
 
 func (m *mockParser) Close() {}
+
+func TestContextAwareWriterStopsEncodingWhenCancelled(t *testing.T) {
+	// Cancel the request context before the response is encoded, as a client
+	// disconnecting mid-request would.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	sink := &countingWriter{}
+	w := &contextAwareWriter{ctx: ctx, w: sink}
+
+	symbols := make(result.Symbols, 1000)
+	for i := range symbols {
+		symbols[i] = result.Symbol{Name: "x", Path: "a.js"}
+	}
+
+	err := json.NewEncoder(w).Encode(symbols)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("want context.Canceled, got %v", err)
+	}
+	if sink.writes != 0 {
+		t.Fatalf("want no writes to reach the client, got %d", sink.writes)
+	}
+}
+
+func TestHandleSearchRejectsWhenBufferFull(t *testing.T) {
+	h := newAPIHandler(nil, "", 1, true, 0, &observation.TestContext)
+
+	// Occupy the only request buffer slot as an in-flight search would.
+	h.inFlight <- struct{}{}
+
+	req := httptest.NewRequest("POST", "/search", strings.NewReader("{}"))
+	w := httptest.NewRecorder()
+	h.handleSearch(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("want status %d, got %d", http.StatusServiceUnavailable, w.Code)
+	}
+	if retryAfter := w.Header().Get("Retry-After"); retryAfter == "" {
+		t.Fatal("want a Retry-After header on rejected requests")
+	}
+}
+
+func TestHandleSearchRejectsInvalidArgs(t *testing.T) {
+	testCases := map[string]string{
+		"unknown field":  `{"repo": "foo", "bogus": true}`,
+		"invalid regex":  `{"repo": "foo", "query": "(", "isRegExp": true}`,
+		"negative first": `{"repo": "foo", "first": -1}`,
+	}
+
+	for label, body := range testCases {
+		t.Run(label, func(t *testing.T) {
+			h := newAPIHandler(nil, "", 10, false, 0, &observation.TestContext)
+
+			req := httptest.NewRequest("POST", "/search", strings.NewReader(body))
+			w := httptest.NewRecorder()
+			h.handleSearch(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("want status %d, got %d (%s)", http.StatusBadRequest, w.Code, w.Body.String())
+			}
+			if w.Body.Len() == 0 {
+				t.Fatal("want a descriptive error message")
+			}
+		})
+	}
+}
+
+func TestWriteSearchError(t *testing.T) {
+	testCases := map[string]struct {
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		"not found": {
+			err:        types.NotFoundError{Err: errors.New("repo does not exist")},
+			wantStatus: http.StatusNotFound,
+			wantCode:   "NotFound",
+		},
+		"ctags failure": {
+			err:        types.CtagsFailureError{Err: errors.New("parser.Parse: boom")},
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   "CtagsFailure",
+		},
+		"timeout": {
+			err:        types.TimeoutError{Err: context.DeadlineExceeded},
+			wantStatus: http.StatusGatewayTimeout,
+			wantCode:   "Timeout",
+		},
+		"unclassified": {
+			err:        errors.New("boom"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   "Unknown",
+		},
+	}
+
+	for label, testCase := range testCases {
+		t.Run(label, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			writeSearchError(w, errors.Wrap(testCase.err, "handleSearchInternal"))
+
+			if w.Code != testCase.wantStatus {
+				t.Fatalf("want status %d, got %d", testCase.wantStatus, w.Code)
+			}
+
+			var payload searchErrorPayload
+			if err := json.NewDecoder(w.Body).Decode(&payload); err != nil {
+				t.Fatal(err)
+			}
+			if payload.Code != testCase.wantCode {
+				t.Fatalf("want code %q, got %q", testCase.wantCode, payload.Code)
+			}
+			if payload.Message == "" {
+				t.Fatal("want a non-empty error message")
+			}
+		})
+	}
+}
+
+type countingWriter struct {
+	writes int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return len(p), nil
+}
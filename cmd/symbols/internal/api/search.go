@@ -10,6 +10,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/symbols/internal/api/observability"
 	"github.com/sourcegraph/sourcegraph/cmd/symbols/internal/database/store"
 	"github.com/sourcegraph/sourcegraph/cmd/symbols/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -42,7 +43,7 @@ func (h *apiHandler) handleSearchInternal(ctx context.Context, args types.Search
 
 	dbFile, err := h.cachedDatabaseWriter.GetOrCreateDatabaseFile(ctx, args)
 	if err != nil {
-		return nil, errors.Wrap(err, "databaseWriter.GetOrCreateDatabaseFile")
+		return nil, classifySearchError(errors.Wrap(err, "databaseWriter.GetOrCreateDatabaseFile"))
 	}
 	trace.Log(log.String("dbFile", dbFile))
 
@@ -55,5 +56,20 @@ func (h *apiHandler) handleSearchInternal(ctx context.Context, args types.Search
 		return nil
 	})
 
-	return &results, err
+	return &results, classifySearchError(err)
+}
+
+// classifySearchError attributes a search failure to a missing repository or
+// commit, or to the search deadline. Ctags failures are typed at their source
+// in the parser; anything else passes through unchanged.
+func classifySearchError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.HasType(err, &gitdomain.RepoNotExistError{}) || errors.HasType(err, &gitdomain.RevisionNotFoundError{}):
+		return types.NotFoundError{Err: err}
+	case errors.Is(err, context.DeadlineExceeded):
+		return types.TimeoutError{Err: err}
+	}
+	return err
 }
@@ -68,13 +68,22 @@ func (s *store) Search(ctx context.Context, args types.SearchArgs) ([]result.Sym
 }
 
 func makeSearchConditions(args types.SearchArgs) []*sqlf.Query {
-	conditions := make([]*sqlf.Query, 0, 2+len(args.IncludePatterns))
+	conditions := make([]*sqlf.Query, 0, 2+len(args.IncludePatterns)+len(args.IncludePaths)+len(args.ExcludePaths))
 	conditions = append(conditions, makeSearchCondition("name", args.Query, args.IsCaseSensitive))
 	conditions = append(conditions, negate(makeSearchCondition("path", args.ExcludePattern, args.IsCaseSensitive)))
 	for _, includePattern := range args.IncludePatterns {
 		conditions = append(conditions, makeSearchCondition("path", includePattern, args.IsCaseSensitive))
 	}
 
+	// Path globs are part of the WHERE clause, so like the patterns above they
+	// restrict the result set before the LIMIT derived from args.First.
+	for _, includePath := range args.IncludePaths {
+		conditions = append(conditions, makeGlobCondition("path", includePath))
+	}
+	for _, excludePath := range args.ExcludePaths {
+		conditions = append(conditions, negate(makeGlobCondition("path", excludePath)))
+	}
+
 	filtered := conditions[:0]
 	for _, condition := range conditions {
 		if condition != nil {
@@ -120,6 +129,16 @@ func makeSearchCondition(column string, regex string, isCaseSensitive bool) *sql
 	return sqlf.Sprintf(column+" REGEXP %s", regex)
 }
 
+// makeGlobCondition returns a condition matching the column against a glob in
+// SQLite GLOB syntax ('*', '?' and '[...]' wildcards; case-sensitive).
+func makeGlobCondition(column string, glob string) *sqlf.Query {
+	if glob == "" {
+		return nil
+	}
+
+	return sqlf.Sprintf(column+" GLOB %s", glob)
+}
+
 // isLiteralEquality returns true if the given regex matches literal strings exactly.
 // If so, this function returns true along with the literal search query. If not, this
 // function returns false.
@@ -0,0 +1,42 @@
+package writer
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/symbols/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// SymbolCache abstracts the per-path symbol entries of an on-disk symbol
+// database, so invalidation can be exercised without a real SQLite file.
+type SymbolCache interface {
+	// InvalidatePaths removes the symbol entries for the given paths.
+	InvalidatePaths(ctx context.Context, paths []string) error
+}
+
+// InvalidateChangedSymbols diffs the two commits and invalidates only the
+// entries of changed paths in the symbol cache, rather than discarding the
+// whole database. Renames currently surface in the diff as an add plus a
+// delete; if rename parsing lands in parseGitDiffOutput, both sides of a
+// rename will flow through here unchanged.
+func InvalidateChangedSymbols(ctx context.Context, gitserverClient gitserver.GitserverClient, cache SymbolCache, repo api.RepoName, oldCommit, newCommit api.CommitID) error {
+	changes, err := gitserverClient.GitDiff(ctx, repo, oldCommit, newCommit)
+	if err != nil {
+		return errors.Wrap(err, "gitserverClient.GitDiff")
+	}
+
+	paths := make([]string, 0, len(changes.Added)+len(changes.Modified)+len(changes.Deleted))
+	paths = append(paths, changes.Added...)
+	paths = append(paths, changes.Modified...)
+	paths = append(paths, changes.Deleted...)
+	if len(paths) == 0 {
+		return nil
+	}
+
+	if err := cache.InvalidatePaths(ctx, paths); err != nil {
+		return errors.Wrap(err, "cache.InvalidatePaths")
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package writer
+
+import (
+	"context"
+	"io"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/cmd/symbols/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+// diffGitserverClient is a fake GitserverClient returning fixed changes.
+type diffGitserverClient struct {
+	changes gitserver.Changes
+}
+
+func (c *diffGitserverClient) FetchTar(context.Context, api.RepoName, api.CommitID, []string) (io.ReadCloser, error) {
+	return nil, nil
+}
+
+func (c *diffGitserverClient) GitDiff(context.Context, api.RepoName, api.CommitID, api.CommitID) (gitserver.Changes, error) {
+	return c.changes, nil
+}
+
+func (c *diffGitserverClient) GitDiffRange(context.Context, api.RepoName, []api.CommitID) ([]gitserver.Changes, error) {
+	return []gitserver.Changes{c.changes}, nil
+}
+
+func (c *diffGitserverClient) GitDiffWith(context.Context, api.RepoName, api.CommitID, api.CommitID, int) (gitserver.Changes, error) {
+	return c.changes, nil
+}
+
+// fakeSymbolCache records the paths it was asked to invalidate.
+type fakeSymbolCache struct {
+	invalidated []string
+	calls       int
+}
+
+func (c *fakeSymbolCache) InvalidatePaths(ctx context.Context, paths []string) error {
+	c.invalidated = append(c.invalidated, paths...)
+	c.calls++
+	return nil
+}
+
+func TestInvalidateChangedSymbols(t *testing.T) {
+	gitserverClient := &diffGitserverClient{changes: gitserver.Changes{
+		Added:    []string{"added.go"},
+		Modified: []string{"modified.go"},
+		Deleted:  []string{"deleted.go"},
+		// Unchanged content; must not be invalidated.
+		TypeChanged: []string{"typechanged.go"},
+	}}
+	cache := &fakeSymbolCache{}
+
+	err := InvalidateChangedSymbols(context.Background(), gitserverClient, cache, "foo", "deadbeef", "cafebabe")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sort.Strings(cache.invalidated)
+	want := []string{"added.go", "deleted.go", "modified.go"}
+	if diff := cmp.Diff(want, cache.invalidated); diff != "" {
+		t.Errorf("unexpected invalidated paths (-want +got):\n%s", diff)
+	}
+}
+
+func TestInvalidateChangedSymbolsNoChanges(t *testing.T) {
+	cache := &fakeSymbolCache{}
+
+	err := InvalidateChangedSymbols(context.Background(), &diffGitserverClient{}, cache, "foo", "deadbeef", "cafebabe")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cache.calls != 0 {
+		t.Errorf("want no invalidation calls for an empty diff, got %d", cache.calls)
+	}
+}
@@ -22,6 +22,12 @@ type MockGitserverClient struct {
 	// GitDiffFunc is an instance of a mock function object controlling the
 	// behavior of the method GitDiff.
 	GitDiffFunc *GitserverClientGitDiffFunc
+	// GitDiffRangeFunc is an instance of a mock function object controlling
+	// the behavior of the method GitDiffRange.
+	GitDiffRangeFunc *GitserverClientGitDiffRangeFunc
+	// GitDiffWithFunc is an instance of a mock function object controlling
+	// the behavior of the method GitDiffWith.
+	GitDiffWithFunc *GitserverClientGitDiffWithFunc
 }
 
 // NewMockGitserverClient creates a new mock of the GitserverClient
@@ -39,6 +45,16 @@ func NewMockGitserverClient() *MockGitserverClient {
 				return gitserver.Changes{}, nil
 			},
 		},
+		GitDiffRangeFunc: &GitserverClientGitDiffRangeFunc{
+			defaultHook: func(context.Context, api.RepoName, []api.CommitID) ([]gitserver.Changes, error) {
+				return nil, nil
+			},
+		},
+		GitDiffWithFunc: &GitserverClientGitDiffWithFunc{
+			defaultHook: func(context.Context, api.RepoName, api.CommitID, api.CommitID, int) (gitserver.Changes, error) {
+				return gitserver.Changes{}, nil
+			},
+		},
 	}
 }
 
@@ -56,6 +72,16 @@ func NewStrictMockGitserverClient() *MockGitserverClient {
 				panic("unexpected invocation of MockGitserverClient.GitDiff")
 			},
 		},
+		GitDiffRangeFunc: &GitserverClientGitDiffRangeFunc{
+			defaultHook: func(context.Context, api.RepoName, []api.CommitID) ([]gitserver.Changes, error) {
+				panic("unexpected invocation of MockGitserverClient.GitDiffRange")
+			},
+		},
+		GitDiffWithFunc: &GitserverClientGitDiffWithFunc{
+			defaultHook: func(context.Context, api.RepoName, api.CommitID, api.CommitID, int) (gitserver.Changes, error) {
+				panic("unexpected invocation of MockGitserverClient.GitDiffWith")
+			},
+		},
 	}
 }
 
@@ -70,6 +96,12 @@ func NewMockGitserverClientFrom(i gitserver.GitserverClient) *MockGitserverClien
 		GitDiffFunc: &GitserverClientGitDiffFunc{
 			defaultHook: i.GitDiff,
 		},
+		GitDiffRangeFunc: &GitserverClientGitDiffRangeFunc{
+			defaultHook: i.GitDiffRange,
+		},
+		GitDiffWithFunc: &GitserverClientGitDiffWithFunc{
+			defaultHook: i.GitDiffWith,
+		},
 	}
 }
 
@@ -300,3 +332,232 @@ func (c GitserverClientGitDiffFuncCall) Args() []interface{} {
 func (c GitserverClientGitDiffFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
+
+// GitserverClientGitDiffRangeFunc describes the behavior when the
+// GitDiffRange method of the parent MockGitserverClient instance is
+// invoked.
+type GitserverClientGitDiffRangeFunc struct {
+	defaultHook func(context.Context, api.RepoName, []api.CommitID) ([]gitserver.Changes, error)
+	hooks       []func(context.Context, api.RepoName, []api.CommitID) ([]gitserver.Changes, error)
+	history     []GitserverClientGitDiffRangeFuncCall
+	mutex       sync.Mutex
+}
+
+// GitDiffRange delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockGitserverClient) GitDiffRange(v0 context.Context, v1 api.RepoName, v2 []api.CommitID) ([]gitserver.Changes, error) {
+	r0, r1 := m.GitDiffRangeFunc.nextHook()(v0, v1, v2)
+	m.GitDiffRangeFunc.appendCall(GitserverClientGitDiffRangeFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GitDiffRange method
+// of the parent MockGitserverClient instance is invoked and the hook queue
+// is empty.
+func (f *GitserverClientGitDiffRangeFunc) SetDefaultHook(hook func(context.Context, api.RepoName, []api.CommitID) ([]gitserver.Changes, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GitDiffRange method of the parent MockGitserverClient instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *GitserverClientGitDiffRangeFunc) PushHook(hook func(context.Context, api.RepoName, []api.CommitID) ([]gitserver.Changes, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GitserverClientGitDiffRangeFunc) SetDefaultReturn(r0 []gitserver.Changes, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName, []api.CommitID) ([]gitserver.Changes, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GitserverClientGitDiffRangeFunc) PushReturn(r0 []gitserver.Changes, r1 error) {
+	f.PushHook(func(context.Context, api.RepoName, []api.CommitID) ([]gitserver.Changes, error) {
+		return r0, r1
+	})
+}
+
+func (f *GitserverClientGitDiffRangeFunc) nextHook() func(context.Context, api.RepoName, []api.CommitID) ([]gitserver.Changes, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitserverClientGitDiffRangeFunc) appendCall(r0 GitserverClientGitDiffRangeFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GitserverClientGitDiffRangeFuncCall objects
+// describing the invocations of this function.
+func (f *GitserverClientGitDiffRangeFunc) History() []GitserverClientGitDiffRangeFuncCall {
+	f.mutex.Lock()
+	history := make([]GitserverClientGitDiffRangeFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitserverClientGitDiffRangeFuncCall is an object that describes an
+// invocation of method GitDiffRange on an instance of MockGitserverClient.
+type GitserverClientGitDiffRangeFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoName
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []api.CommitID
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []gitserver.Changes
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitserverClientGitDiffRangeFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitserverClientGitDiffRangeFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// GitserverClientGitDiffWithFunc describes the behavior when the
+// GitDiffWith method of the parent MockGitserverClient instance is invoked.
+type GitserverClientGitDiffWithFunc struct {
+	defaultHook func(context.Context, api.RepoName, api.CommitID, api.CommitID, int) (gitserver.Changes, error)
+	hooks       []func(context.Context, api.RepoName, api.CommitID, api.CommitID, int) (gitserver.Changes, error)
+	history     []GitserverClientGitDiffWithFuncCall
+	mutex       sync.Mutex
+}
+
+// GitDiffWith delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockGitserverClient) GitDiffWith(v0 context.Context, v1 api.RepoName, v2 api.CommitID, v3 api.CommitID, v4 int) (gitserver.Changes, error) {
+	r0, r1 := m.GitDiffWithFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.GitDiffWithFunc.appendCall(GitserverClientGitDiffWithFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GitDiffWith method
+// of the parent MockGitserverClient instance is invoked and the hook queue
+// is empty.
+func (f *GitserverClientGitDiffWithFunc) SetDefaultHook(hook func(context.Context, api.RepoName, api.CommitID, api.CommitID, int) (gitserver.Changes, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GitDiffWith method of the parent MockGitserverClient instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *GitserverClientGitDiffWithFunc) PushHook(hook func(context.Context, api.RepoName, api.CommitID, api.CommitID, int) (gitserver.Changes, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GitserverClientGitDiffWithFunc) SetDefaultReturn(r0 gitserver.Changes, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName, api.CommitID, api.CommitID, int) (gitserver.Changes, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GitserverClientGitDiffWithFunc) PushReturn(r0 gitserver.Changes, r1 error) {
+	f.PushHook(func(context.Context, api.RepoName, api.CommitID, api.CommitID, int) (gitserver.Changes, error) {
+		return r0, r1
+	})
+}
+
+func (f *GitserverClientGitDiffWithFunc) nextHook() func(context.Context, api.RepoName, api.CommitID, api.CommitID, int) (gitserver.Changes, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitserverClientGitDiffWithFunc) appendCall(r0 GitserverClientGitDiffWithFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GitserverClientGitDiffWithFuncCall objects
+// describing the invocations of this function.
+func (f *GitserverClientGitDiffWithFunc) History() []GitserverClientGitDiffWithFuncCall {
+	f.mutex.Lock()
+	history := make([]GitserverClientGitDiffWithFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitserverClientGitDiffWithFuncCall is an object that describes an
+// invocation of method GitDiffWith on an instance of MockGitserverClient.
+type GitserverClientGitDiffWithFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoName
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 api.CommitID
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 api.CommitID
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 gitserver.Changes
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitserverClientGitDiffWithFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitserverClientGitDiffWithFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
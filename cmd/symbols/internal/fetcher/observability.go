@@ -12,6 +12,7 @@ import (
 type operations struct {
 	fetching               prometheus.Gauge
 	fetchQueueSize         prometheus.Gauge
+	filesSkipped           prometheus.Counter
 	fetchRepositoryArchive *observation.Operation
 }
 
@@ -30,6 +31,13 @@ func newOperations(observationContext *observation.Context) *operations {
 	})
 	observationContext.Registerer.MustRegister(fetchQueueSize)
 
+	filesSkipped := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "src",
+		Name:      "codeintel_symbols_fetch_files_skipped_total",
+		Help:      "The number of files skipped during fetch because they exceed the maximum file size.",
+	})
+	observationContext.Registerer.MustRegister(filesSkipped)
+
 	operationMetrics := metrics.NewREDMetrics(
 		observationContext.Registerer,
 		"codeintel_symbols_repository_fetcher",
@@ -48,6 +56,7 @@ func newOperations(observationContext *observation.Context) *operations {
 	return &operations{
 		fetching:               fetching,
 		fetchQueueSize:         fetchQueueSize,
+		filesSkipped:           filesSkipped,
 		fetchRepositoryArchive: op("FetchRepositoryArchive"),
 	}
 }
@@ -25,6 +25,7 @@ type repositoryFetcher struct {
 	fetchSem            chan int
 	operations          *operations
 	maxTotalPathsLength int
+	maxFileSizeBytes    int64
 }
 
 type ParseRequest struct {
@@ -37,12 +38,13 @@ type parseRequestOrError struct {
 	Err          error
 }
 
-func NewRepositoryFetcher(gitserverClient gitserver.GitserverClient, maximumConcurrentFetches int, maxTotalPathsLength int, observationContext *observation.Context) RepositoryFetcher {
+func NewRepositoryFetcher(gitserverClient gitserver.GitserverClient, maximumConcurrentFetches int, maxTotalPathsLength int, maxFileSizeBytes int64, observationContext *observation.Context) RepositoryFetcher {
 	return &repositoryFetcher{
 		gitserverClient:     gitserverClient,
 		fetchSem:            make(chan int, maximumConcurrentFetches),
 		operations:          newOperations(observationContext),
 		maxTotalPathsLength: maxTotalPathsLength,
+		maxFileSizeBytes:    maxFileSizeBytes,
 	}
 }
 
@@ -88,7 +90,7 @@ func (f *repositoryFetcher) fetchRepositoryArchive(ctx context.Context, args typ
 		}
 		defer rc.Close()
 
-		err = readTar(ctx, tar.NewReader(rc), callback, trace)
+		err = f.readTar(ctx, tar.NewReader(rc), callback, trace)
 		if err != nil {
 			return errors.Wrap(err, "readTar")
 		}
@@ -149,7 +151,7 @@ func (f *repositoryFetcher) limitConcurrentFetches(ctx context.Context) (func(),
 	}
 }
 
-func readTar(ctx context.Context, tarReader *tar.Reader, callback func(request ParseRequest), traceLog observation.TraceLogger) error {
+func (f *repositoryFetcher) readTar(ctx context.Context, tarReader *tar.Reader, callback func(request ParseRequest), traceLog observation.TraceLogger) error {
 	for {
 		if ctx.Err() != nil {
 			return ctx.Err()
@@ -164,12 +166,12 @@ func readTar(ctx context.Context, tarReader *tar.Reader, callback func(request P
 			return err
 		}
 
-		readTarHeader(tarReader, tarHeader, callback, traceLog)
+		f.readTarHeader(tarReader, tarHeader, callback, traceLog)
 	}
 }
 
-func readTarHeader(tarReader *tar.Reader, tarHeader *tar.Header, callback func(request ParseRequest), trace observation.TraceLogger) error {
-	if !shouldParse(tarHeader) {
+func (f *repositoryFetcher) readTarHeader(tarReader *tar.Reader, tarHeader *tar.Header, callback func(request ParseRequest), trace observation.TraceLogger) error {
+	if !f.shouldParse(tarHeader) {
 		return nil
 	}
 
@@ -221,12 +223,11 @@ func readTarHeader(tarReader *tar.Reader, tarHeader *tar.Header, callback func(r
 	return nil
 }
 
-// maxFileSize (512KB) is the maximum size of files we attempt to parse.
-const maxFileSize = 1 << 19
-
-func shouldParse(tarHeader *tar.Header) bool {
-	// We do not search large files
-	if tarHeader.Size > maxFileSize {
+func (f *repositoryFetcher) shouldParse(tarHeader *tar.Header) bool {
+	// We do not search large files. Record them so skips due to a too-small
+	// MAX_FILE_SIZE_BYTES are visible.
+	if tarHeader.Size > f.maxFileSizeBytes {
+		f.operations.filesSkipped.Inc()
 		return false
 	}
 
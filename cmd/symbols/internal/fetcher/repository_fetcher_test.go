@@ -33,12 +33,13 @@ func TestRepositoryFetcher(t *testing.T) {
 	tarContents["ignored.json"] = "{}"
 
 	// Large files are ignored
+	maxFileSize := 1 << 19
 	tarContents["payloads.txt"] = strings.Repeat("oversized load", maxFileSize)
 
 	gitserverClient := NewMockGitserverClient()
 	gitserverClient.FetchTarFunc.SetDefaultHook(gitserver.CreateTestFetchTarFunc(tarContents))
 
-	repositoryFetcher := NewRepositoryFetcher(gitserverClient, 15, 1000, &observation.TestContext)
+	repositoryFetcher := NewRepositoryFetcher(gitserverClient, 15, 1000, int64(maxFileSize), &observation.TestContext)
 	args := types.SearchArgs{Repo: api.RepoName("foo"), CommitID: api.CommitID("deadbeef")}
 
 	t.Run("all paths", func(t *testing.T) {
@@ -68,6 +69,27 @@ func TestRepositoryFetcher(t *testing.T) {
 	})
 }
 
+func TestRepositoryFetcherMaxFileSize(t *testing.T) {
+	tarContents := map[string]string{
+		"small.txt": "fits",
+		"big.txt":   strings.Repeat("does not fit", 100),
+	}
+
+	gitserverClient := NewMockGitserverClient()
+	gitserverClient.FetchTarFunc.SetDefaultHook(gitserver.CreateTestFetchTarFunc(tarContents))
+
+	repositoryFetcher := NewRepositoryFetcher(gitserverClient, 15, 1000, 16, &observation.TestContext)
+	args := types.SearchArgs{Repo: api.RepoName("foo"), CommitID: api.CommitID("deadbeef")}
+
+	ch := repositoryFetcher.FetchRepositoryArchive(context.Background(), args, nil)
+	parseRequests := consumeParseRequests(t, ch)
+
+	expectedParseRequests := map[string]string{"small.txt": "fits"}
+	if diff := cmp.Diff(expectedParseRequests, parseRequests); diff != "" {
+		t.Errorf("unexpected parse requests (-want +got):\n%s", diff)
+	}
+}
+
 func consumeParseRequests(t *testing.T, ch <-chan parseRequestOrError) map[string]string {
 	parseRequests := map[string]string{}
 	for v := range ch {
@@ -23,6 +23,15 @@ type GitserverClient interface {
 
 	// GitDiff returns the paths that have changed between two commits.
 	GitDiff(context.Context, api.RepoName, api.CommitID, api.CommitID) (Changes, error)
+
+	// GitDiffRange returns the paths that have changed between each pair of
+	// consecutive commits, in order. A range of n commits yields n-1 Changes.
+	GitDiffRange(context.Context, api.RepoName, []api.CommitID) ([]Changes, error)
+
+	// GitDiffWith is like GitDiff but enables rename detection with the given
+	// similarity threshold percentage (git's -M<N>% flag). A threshold of zero
+	// uses git's default. Thresholds outside [0, 100] are rejected.
+	GitDiffWith(context.Context, api.RepoName, api.CommitID, api.CommitID, int) (Changes, error)
 }
 
 // Changes are added, deleted, and modified paths.
@@ -30,6 +39,11 @@ type Changes struct {
 	Added    []string
 	Modified []string
 	Deleted  []string
+
+	// TypeChanged are paths whose file type changed (git status T), e.g. a
+	// regular file replaced by a symlink. These are kept out of Modified so
+	// callers don't re-parse files whose content is unchanged.
+	TypeChanged []string
 }
 
 type gitserverClient struct {
@@ -68,7 +82,77 @@ func (c *gitserverClient) GitDiff(ctx context.Context, repo api.RepoName, commit
 	}})
 	defer endObservation(1, observation.Args{})
 
-	output, err := git.DiffSymbols(ctx, repo, commitA, commitB)
+	output, err := diffSymbols(ctx, repo, commitA, commitB)
+
+	changes, err := parseGitDiffOutput(output)
+	if err != nil {
+		return Changes{}, errors.Wrap(err, "failed to parse git diff output")
+	}
+
+	return changes, nil
+}
+
+// diffSymbols invokes git.DiffSymbols. It's a variable so tests can supply a
+// fake gitserver.
+var diffSymbols = git.DiffSymbols
+
+func (c *gitserverClient) GitDiffRange(ctx context.Context, repo api.RepoName, commits []api.CommitID) (_ []Changes, err error) {
+	ctx, endObservation := c.operations.gitDiffRange.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("repo", string(repo)),
+		log.Int("commits", len(commits)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if len(commits) < 2 {
+		return nil, errors.Newf("expected at least two commits, got %d", len(commits))
+	}
+
+	allChanges := make([]Changes, 0, len(commits)-1)
+	for i := 0; i < len(commits)-1; i++ {
+		output, err := diffSymbols(ctx, repo, commits[i], commits[i+1])
+		if err != nil {
+			return nil, err
+		}
+
+		changes, err := parseGitDiffOutput(output)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse git diff output")
+		}
+
+		allChanges = append(allChanges, changes)
+	}
+
+	return allChanges, nil
+}
+
+// gitDefaultRenameSimilarity is the similarity threshold git uses for rename
+// detection when -M is given without a value.
+const gitDefaultRenameSimilarity = 50
+
+// diffSymbolsWithSimilarity invokes git.DiffSymbolsWithSimilarity. It's a
+// variable so tests can assert on the threshold without a gitserver.
+var diffSymbolsWithSimilarity = git.DiffSymbolsWithSimilarity
+
+func (c *gitserverClient) GitDiffWith(ctx context.Context, repo api.RepoName, commitA, commitB api.CommitID, similarity int) (_ Changes, err error) {
+	ctx, endObservation := c.operations.gitDiffWith.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("repo", string(repo)),
+		log.String("commitA", string(commitA)),
+		log.String("commitB", string(commitB)),
+		log.Int("similarity", similarity),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if similarity < 0 || similarity > 100 {
+		return Changes{}, errors.Newf("similarity threshold must be between 0 and 100, got %d", similarity)
+	}
+	if similarity == 0 {
+		similarity = gitDefaultRenameSimilarity
+	}
+
+	output, err := diffSymbolsWithSimilarity(ctx, repo, commitA, commitB, similarity)
+	if err != nil {
+		return Changes{}, err
+	}
 
 	changes, err := parseGitDiffOutput(output)
 	if err != nil {
@@ -100,6 +184,8 @@ func parseGitDiffOutput(output []byte) (changes Changes, _ error) {
 			changes.Modified = append(changes.Modified, string(slices[i+1]))
 		case 'D':
 			changes.Deleted = append(changes.Deleted, string(slices[i+1]))
+		case 'T':
+			changes.TypeChanged = append(changes.TypeChanged, string(slices[i+1]))
 		}
 	}
 
@@ -1,9 +1,14 @@
 package gitserver
 
 import (
+	"context"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func TestParseGitDiffOutput(t *testing.T) {
@@ -30,6 +35,16 @@ func TestParseGitDiffOutput(t *testing.T) {
 				Deleted:  []string{"deleted1.json", "deleted2.json", "deleted3.json"},
 			},
 		},
+		{
+			output: combineBytes(
+				[]byte("M"), NUL, []byte("modified1.json"), NUL,
+				[]byte("T"), NUL, []byte("typechanged1.json"), NUL,
+			),
+			expectedChanges: Changes{
+				Modified:    []string{"modified1.json"},
+				TypeChanged: []string{"typechanged1.json"},
+			},
+		},
 		{
 			output: combineBytes(
 				[]byte("A"), NUL, []byte("added1.json"), NUL,
@@ -66,3 +81,72 @@ func combineBytes(bss ...[]byte) (combined []byte) {
 
 	return combined
 }
+
+func TestGitDiffRange(t *testing.T) {
+	orig := diffSymbols
+	t.Cleanup(func() { diffSymbols = orig })
+
+	// Fake gitserver serving one changed path per commit pair.
+	diffSymbols = func(ctx context.Context, repo api.RepoName, commitA, commitB api.CommitID) ([]byte, error) {
+		switch {
+		case commitA == "deadbeef1" && commitB == "deadbeef2":
+			return combineBytes([]byte("A"), NUL, []byte("added1.json"), NUL), nil
+		case commitA == "deadbeef2" && commitB == "deadbeef3":
+			return combineBytes([]byte("M"), NUL, []byte("modified1.json"), NUL), nil
+		}
+		return nil, errors.Newf("unexpected commit pair %s..%s", commitA, commitB)
+	}
+
+	client := NewClient(&observation.TestContext)
+
+	changes, err := client.GitDiffRange(context.Background(), "repo", []api.CommitID{"deadbeef1", "deadbeef2", "deadbeef3"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expectedChanges := []Changes{
+		{Added: []string{"added1.json"}},
+		{Modified: []string{"modified1.json"}},
+	}
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("unexpected changes (-want +got):\n%s", diff)
+	}
+
+	if _, err := client.GitDiffRange(context.Background(), "repo", []api.CommitID{"deadbeef1"}); err == nil {
+		t.Errorf("expected error for a single-commit range, got none")
+	}
+}
+
+func TestGitDiffWith(t *testing.T) {
+	orig := diffSymbolsWithSimilarity
+	t.Cleanup(func() { diffSymbolsWithSimilarity = orig })
+
+	var gotSimilarity int
+	diffSymbolsWithSimilarity = func(ctx context.Context, repo api.RepoName, commitA, commitB api.CommitID, similarity int) ([]byte, error) {
+		gotSimilarity = similarity
+		return nil, nil
+	}
+
+	client := NewClient(&observation.TestContext)
+
+	if _, err := client.GitDiffWith(context.Background(), "repo", "deadbeef1", "deadbeef2", 70); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if gotSimilarity != 70 {
+		t.Errorf("unexpected similarity threshold. want=%d have=%d", 70, gotSimilarity)
+	}
+
+	// A zero threshold falls back to git's default.
+	if _, err := client.GitDiffWith(context.Background(), "repo", "deadbeef1", "deadbeef2", 0); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if gotSimilarity != gitDefaultRenameSimilarity {
+		t.Errorf("unexpected similarity threshold. want=%d have=%d", gitDefaultRenameSimilarity, gotSimilarity)
+	}
+
+	for _, similarity := range []int{-1, 101} {
+		if _, err := client.GitDiffWith(context.Background(), "repo", "deadbeef1", "deadbeef2", similarity); err == nil {
+			t.Errorf("expected error for out-of-range similarity %d, got none", similarity)
+		}
+	}
+}
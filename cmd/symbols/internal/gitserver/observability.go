@@ -8,8 +8,10 @@ import (
 )
 
 type operations struct {
-	fetchTar *observation.Operation
-	gitDiff  *observation.Operation
+	fetchTar     *observation.Operation
+	gitDiff      *observation.Operation
+	gitDiffRange *observation.Operation
+	gitDiffWith  *observation.Operation
 }
 
 func newOperations(observationContext *observation.Context) *operations {
@@ -29,7 +31,9 @@ func newOperations(observationContext *observation.Context) *operations {
 	}
 
 	return &operations{
-		fetchTar: op("FetchTar"),
-		gitDiff:  op("GitDiff"),
+		fetchTar:     op("FetchTar"),
+		gitDiff:      op("GitDiff"),
+		gitDiffRange: op("GitDiffRange"),
+		gitDiffWith:  op("GitDiffWith"),
 	}
 }
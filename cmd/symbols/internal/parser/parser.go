@@ -159,7 +159,7 @@ func (p *parser) handleParseRequest(ctx context.Context, symbolOrErrors chan<- S
 
 	entries, err := parser.Parse(parseRequest.Path, parseRequest.Data)
 	if err != nil {
-		return errors.Wrap(err, "parser.Parse")
+		return types.CtagsFailureError{Err: errors.Wrap(err, "parser.Parse")}
 	}
 	trace.Log(log.Int("numEntries", len(entries)))
 
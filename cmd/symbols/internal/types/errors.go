@@ -0,0 +1,22 @@
+package types
+
+// The typed errors below classify search failures so the HTTP handler can map
+// them onto precise statuses instead of reporting every failure as a 500.
+
+// NotFoundError reports that the repository or commit to search doesn't exist.
+type NotFoundError struct{ Err error }
+
+func (e NotFoundError) Error() string { return e.Err.Error() }
+func (e NotFoundError) Unwrap() error { return e.Err }
+
+// CtagsFailureError reports that extracting symbols with ctags failed.
+type CtagsFailureError struct{ Err error }
+
+func (e CtagsFailureError) Error() string { return e.Err.Error() }
+func (e CtagsFailureError) Unwrap() error { return e.Err }
+
+// TimeoutError reports that a search exceeded its deadline.
+type TimeoutError struct{ Err error }
+
+func (e TimeoutError) Error() string { return e.Err.Error() }
+func (e TimeoutError) Unwrap() error { return e.Err }
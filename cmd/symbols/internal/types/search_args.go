@@ -35,6 +35,19 @@ type SearchArgs struct {
 	// need to match to get included in the result
 	ExcludePattern string
 
+	// IncludePaths is an optional list of globs that a symbol's file path
+	// must match (in addition to IncludePatterns) to get included in the
+	// result. Globs use SQLite GLOB syntax: '*' matches any sequence of
+	// characters (including path separators), '?' matches a single character
+	// and '[...]' matches one character in the class. Matching is always
+	// case-sensitive. The globs are ANDed together.
+	IncludePaths []string
+
+	// ExcludePaths is an optional list of globs; symbols whose file path
+	// matches any of them are excluded from the result. Same syntax as
+	// IncludePaths.
+	ExcludePaths []string
+
 	// First indicates that only the first n symbols should be returned.
 	First int
 }
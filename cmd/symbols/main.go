@@ -108,11 +108,11 @@ func main() {
 	}
 
 	gitserverClient := gitserver.NewClient(observationContext)
-	repositoryFetcher := fetcher.NewRepositoryFetcher(gitserverClient, 15, config.maxTotalPathsLength, observationContext)
+	repositoryFetcher := fetcher.NewRepositoryFetcher(gitserverClient, 15, config.maxTotalPathsLength, int64(config.maxFileSizeBytes), observationContext)
 	parser := parser.NewParser(parserPool, repositoryFetcher, config.requestBufferSize, config.numCtagsProcesses, observationContext)
 	databaseWriter := writer.NewDatabaseWriter(config.cacheDir, gitserverClient, parser)
 	cachedDatabaseWriter := writer.NewCachedDatabaseWriter(databaseWriter, cache)
-	apiHandler := api.NewHandler(cachedDatabaseWriter, config.ctagsCommand, observationContext)
+	apiHandler := api.NewHandler(cachedDatabaseWriter, config.ctagsCommand, config.requestBufferSize, config.rejectWhenBufferFull, config.maxSymbolResultsPerRepo, observationContext)
 
 	server := httpserver.NewFromAddr(addr, &http.Server{
 		ReadTimeout:  75 * time.Second,
@@ -0,0 +1,396 @@
+// Package bitmask implements an experimental bloom-filter index over the
+// blobs of a git repository. The index is used to prune the set of files a
+// grep-style search has to scan: a blob whose filter rejects an ngram of the
+// query cannot contain the query.
+package bitmask
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// estimate is the default target false positive rate the per-blob filters
+	// are sized for.
+	estimate = 0.01
+
+	// bloomSizePadding is the default padding added to the ngram count of a
+	// blob before sizing its filter, so very small files don't get degenerate
+	// filters.
+	bloomSizePadding = 10
+
+	// maxFileSize bounds the blobs we index; larger files are skipped.
+	maxFileSize = 1 << 20
+
+	// ngramSize is the length of the substrings inserted into the filters.
+	ngramSize = 3
+)
+
+// RepoIndex is a bloom-filter index over the blobs of a git repository.
+type RepoIndex struct {
+	// Dir is the repository root the index was built from.
+	Dir string
+
+	// Branch is the branch, tag or commit that was indexed.
+	Branch string
+
+	// Commit is the commit the indexed ref resolved to at indexing time.
+	Commit string
+
+	// Options holds the parameters the per-blob filters were built with.
+	Options IndexOptions
+
+	// Blobs holds one entry per indexed file.
+	Blobs []BlobIndex
+}
+
+// IndexOptions control how the per-blob filters of a RepoIndex are sized.
+// The zero value selects the defaults.
+type IndexOptions struct {
+	// FalsePositiveRate is the target false positive rate the filters are
+	// sized for. It must be in (0, 1); zero selects the default of 1%.
+	FalsePositiveRate float64
+
+	// SizePadding is added to the ngram count of a blob before sizing its
+	// filter, so very small files don't get degenerate filters. It must not
+	// be negative; zero selects the default of 10.
+	SizePadding int
+
+	// Ref is the branch, tag or commit to index. Empty selects the branch
+	// currently checked out.
+	Ref string
+
+	// ExtensionAllowlist restricts indexing to files with one of these
+	// extensions (as reported by filepath.Ext). Empty allows any extension
+	// the denylist doesn't exclude.
+	ExtensionAllowlist []string
+
+	// ExtensionDenylist excludes files with one of these extensions. Nil
+	// selects defaultExtensionDenylist; an empty, non-nil slice excludes
+	// nothing.
+	ExtensionDenylist []string
+}
+
+// defaultExtensionDenylist skips common data and asset extensions that add
+// index size without helping code search.
+var defaultExtensionDenylist = []string{
+	".csv", ".gif", ".ico", ".jpg", ".jpeg", ".json", ".lock", ".png", ".svg", ".tsv",
+}
+
+func (o IndexOptions) withDefaults() (IndexOptions, error) {
+	if o.FalsePositiveRate == 0 {
+		o.FalsePositiveRate = estimate
+	}
+	if o.FalsePositiveRate <= 0 || o.FalsePositiveRate >= 1 {
+		return o, errors.Errorf("false positive rate outside (0, 1): %f", o.FalsePositiveRate)
+	}
+	if o.SizePadding == 0 {
+		o.SizePadding = bloomSizePadding
+	}
+	if o.SizePadding < 0 {
+		return o, errors.Errorf("negative size padding: %d", o.SizePadding)
+	}
+	if o.ExtensionDenylist == nil {
+		o.ExtensionDenylist = defaultExtensionDenylist
+	}
+	return o, nil
+}
+
+// indexableExtension reports whether files with the given extension should be
+// indexed under the allow- and denylists of o.
+func (o IndexOptions) indexableExtension(ext string) bool {
+	for _, deny := range o.ExtensionDenylist {
+		if ext == deny {
+			return false
+		}
+	}
+	if len(o.ExtensionAllowlist) == 0 {
+		return true
+	}
+	for _, allow := range o.ExtensionAllowlist {
+		if ext == allow {
+			return true
+		}
+	}
+	return false
+}
+
+// BlobIndex associates a file path with the bloom filter over its contents.
+type BlobIndex struct {
+	Path   string
+	Filter *Filter
+}
+
+// NewRepoIndex indexes the files of the git repository at dir at the commit
+// the indexed ref resolves to. Paths and blob contents both come from the
+// ref's tree, so the ref may differ from the current checkout. Binary files,
+// files larger than maxFileSize, and files excluded by the extension allow-
+// and denylists are skipped.
+func NewRepoIndex(dir string, opts IndexOptions) (*RepoIndex, error) {
+	opts, err := opts.withDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	ref := opts.Ref
+	if ref == "" {
+		ref, err = runGit(dir, "rev-parse", "--abbrev-ref", "HEAD")
+		if err != nil {
+			return nil, err
+		}
+		// On a detached checkout --abbrev-ref returns the literal string
+		// "HEAD" rather than a branch name, which isn't a useful ref to
+		// record. Fall back to the commit HEAD points at.
+		if ref == "HEAD" {
+			ref, err = runGit(dir, "rev-parse", "HEAD")
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	commit, err := runGit(dir, "rev-parse", ref+"^{commit}")
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := runGit(dir, "ls-tree", "-r", "-z", "--name-only", commit)
+	if err != nil {
+		return nil, err
+	}
+
+	var blobs []BlobIndex
+	for _, path := range strings.Split(strings.TrimRight(out, "\x00"), "\x00") {
+		if path == "" {
+			continue
+		}
+		if !opts.indexableExtension(filepath.Ext(path)) {
+			continue
+		}
+
+		data, err := readBlob(dir, commit, path)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > maxFileSize || bytes.IndexByte(data, 0) >= 0 {
+			continue
+		}
+
+		grams := ngrams(string(data))
+		filter := newFilter(len(grams)+opts.SizePadding, opts.FalsePositiveRate)
+		for g := range grams {
+			filter.add(g)
+		}
+		blobs = append(blobs, BlobIndex{Path: path, Filter: filter})
+	}
+
+	return &RepoIndex{Dir: dir, Branch: ref, Commit: commit, Options: opts, Blobs: blobs}, nil
+}
+
+// ngrams returns the set of substrings of length ngramSize in text.
+func ngrams(text string) map[string]struct{} {
+	grams := make(map[string]struct{})
+	for i := 0; i+ngramSize <= len(text); i++ {
+		grams[text[i:i+ngramSize]] = struct{}{}
+	}
+	return grams
+}
+
+// PathsMatchingQuery returns the paths of blobs that possibly contain the
+// literal query. Queries shorter than ngramSize can't be pruned and match
+// every indexed path.
+func (r *RepoIndex) PathsMatchingQuery(query string) []string {
+	grams := ngrams(query)
+
+	var paths []string
+	for _, b := range r.Blobs {
+		if b.Filter.testAll(grams) {
+			paths = append(paths, b.Path)
+		}
+	}
+	return paths
+}
+
+// LineMatch is a line containing a grep query.
+type LineMatch struct {
+	Path       string
+	LineNumber int
+	Line       string
+}
+
+// GrepOptions control how Grep scans the blobs matching a query.
+// The zero value imposes no deadlines.
+type GrepOptions struct {
+	// PerFileDeadline bounds the time spent scanning a single file, so one
+	// pathological file can't stall the whole search. Files exceeding it are
+	// skipped and recorded as warnings in the returned stats. Zero means no
+	// per-file deadline.
+	PerFileDeadline time.Duration
+}
+
+// GrepStats reports how a Grep call spent its time.
+type GrepStats struct {
+	// FilesScanned is the number of files that were fully line-scanned.
+	FilesScanned int
+
+	// FilesSkipped is the number of files skipped because their scan exceeded
+	// the per-file deadline.
+	FilesSkipped int
+
+	// Warnings holds one message per skipped file.
+	Warnings []string
+}
+
+// now is a test seam for the per-file deadline checks.
+var now = time.Now
+
+// Grep returns the lines containing the literal query, scanning only the
+// blobs whose filters match the query's ngrams.
+func (r *RepoIndex) Grep(query string) ([]LineMatch, error) {
+	matches, _, err := r.GrepWithOptions(context.Background(), query, GrepOptions{})
+	return matches, err
+}
+
+// GrepWithOptions is Grep with a context covering the whole search and
+// per-call options.
+func (r *RepoIndex) GrepWithOptions(ctx context.Context, query string, opts GrepOptions) ([]LineMatch, GrepStats, error) {
+	grams := ngrams(query)
+
+	var stats GrepStats
+	var matches []LineMatch
+	for _, b := range r.Blobs {
+		if err := ctx.Err(); err != nil {
+			return nil, stats, err
+		}
+		if !b.Filter.testAll(grams) {
+			continue
+		}
+
+		data, err := r.readBlob(b.Path)
+		if err != nil {
+			return nil, stats, err
+		}
+
+		var deadline time.Time
+		if opts.PerFileDeadline > 0 {
+			deadline = now().Add(opts.PerFileDeadline)
+		}
+
+		skipped := false
+		var fileMatches []LineMatch
+		for i, line := range strings.Split(string(data), "\n") {
+			if !deadline.IsZero() && now().After(deadline) {
+				skipped = true
+				break
+			}
+			if strings.Contains(line, query) {
+				fileMatches = append(fileMatches, LineMatch{Path: b.Path, LineNumber: i + 1, Line: line})
+			}
+		}
+		if skipped {
+			stats.FilesSkipped++
+			stats.Warnings = append(stats.Warnings, fmt.Sprintf("%s: scan exceeded per-file deadline of %s, file skipped", b.Path, opts.PerFileDeadline))
+			continue
+		}
+
+		stats.FilesScanned++
+		matches = append(matches, fileMatches...)
+	}
+	return matches, stats, nil
+}
+
+// EstimateFalsePositiveRate measures index quality against a sample query
+// set. Every path reported by PathsMatchingQuery is confirmed against the
+// actual file contents (as Grep does), and the fraction of reported paths
+// that don't contain their query is returned. It's a tool for tuning
+// estimate and bloomSizePadding.
+func (r *RepoIndex) EstimateFalsePositiveRate(sampleQueries []string) float64 {
+	var reported, falsePositives int
+	for _, query := range sampleQueries {
+		for _, path := range r.PathsMatchingQuery(query) {
+			reported++
+			data, err := r.readBlob(path)
+			if err != nil || !strings.Contains(string(data), query) {
+				falsePositives++
+			}
+		}
+	}
+
+	if reported == 0 {
+		return 0
+	}
+	return float64(falsePositives) / float64(reported)
+}
+
+// Warm touches every blob filter's bit array so a freshly deserialized index
+// doesn't pay for faulting the filters into memory (and cache) on its first
+// query. It's optional; queries are correct without it, just slower the first
+// time.
+func (r *RepoIndex) Warm() {
+	for i := range r.Blobs {
+		// Count reads every word of the bit array.
+		_ = r.Blobs[i].Filter.Bits.Count()
+	}
+}
+
+// SerializeRepoIndex writes the index to w as gzipped JSON.
+func SerializeRepoIndex(w io.Writer, r *RepoIndex) error {
+	gz := gzip.NewWriter(w)
+	if err := json.NewEncoder(gz).Encode(r); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// DeserializeRepoIndex reads an index produced by SerializeRepoIndex.
+func DeserializeRepoIndex(rdr io.Reader) (*RepoIndex, error) {
+	gz, err := gzip.NewReader(rdr)
+	if err != nil {
+		return nil, err
+	}
+
+	var index RepoIndex
+	if err := json.NewDecoder(gz).Decode(&index); err != nil {
+		return nil, err
+	}
+	return &index, gz.Close()
+}
+
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return "", errors.Wrapf(err, "running git %s", strings.Join(args, " "))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// readBlob returns the content of path at the commit the index was built
+// from, so queries see the indexed content even when the working tree has
+// diverged from the indexed ref.
+func (r *RepoIndex) readBlob(path string) ([]byte, error) {
+	return readBlob(r.Dir, r.Commit, path)
+}
+
+// readBlob returns the content of path at commit. Unlike runGit it returns
+// the output verbatim, since trimming whitespace would corrupt blob content.
+func readBlob(dir, commit, path string) ([]byte, error) {
+	cmd := exec.Command("git", "cat-file", "blob", commit+":"+path)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading blob %s:%s", commit, path)
+	}
+	return out, nil
+}
@@ -0,0 +1,373 @@
+package bitmask
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// initRepo creates a git repository in a temp directory containing the given
+// files, all committed on the default branch.
+func initRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, path)), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, path), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, args := range [][]string{
+		{"init"},
+		{"add", "."},
+		{"-c", "user.name=a", "-c", "user.email=a@example.com", "commit", "-m", "initial"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %s\n%s", args, err, out)
+		}
+	}
+
+	return dir
+}
+
+func TestGrep(t *testing.T) {
+	dir := initRepo(t, map[string]string{
+		"a.txt":     "the quick brown fox\njumps over the lazy dog\n",
+		"sub/b.txt": "lorem ipsum dolor sit amet\n",
+	})
+
+	index, err := NewRepoIndex(dir, IndexOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(index.Blobs) != 2 {
+		t.Fatalf("want 2 indexed blobs, got %d", len(index.Blobs))
+	}
+
+	matches, err := index.Grep("quick brown")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 || matches[0].Path != "a.txt" || matches[0].LineNumber != 1 {
+		t.Fatalf("unexpected matches: %+v", matches)
+	}
+
+	matches, err = index.Grep("zzz_not_present")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 0 {
+		t.Fatalf("unexpected matches: %+v", matches)
+	}
+}
+
+func TestNewRepoIndexRef(t *testing.T) {
+	dir := initRepo(t, map[string]string{
+		"a.txt": "the quick brown fox\n",
+	})
+
+	cmd := exec.Command("git", "tag", "v1.0")
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git tag: %s\n%s", err, out)
+	}
+
+	wantCommit, err := runGit(dir, "rev-parse", "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Diverge the working tree from the tag: rewrite a.txt and commit a new
+	// file. Indexing the tag must see the tag's paths and blob contents, not
+	// the checkout's.
+	if err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte("rewritten after the tag\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "b.txt"), []byte("a file the tag doesn't have\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"add", "."},
+		{"-c", "user.name=a", "-c", "user.email=a@example.com", "commit", "-m", "diverge"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %s\n%s", args, err, out)
+		}
+	}
+
+	index, err := NewRepoIndex(dir, IndexOptions{Ref: "v1.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if index.Branch != "v1.0" {
+		t.Fatalf("want branch v1.0, got %q", index.Branch)
+	}
+	if index.Commit != wantCommit {
+		t.Fatalf("want commit %q, got %q", wantCommit, index.Commit)
+	}
+	if len(index.Blobs) != 1 {
+		t.Fatalf("want 1 indexed blob, got %d", len(index.Blobs))
+	}
+
+	matches, err := index.Grep("quick")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 || matches[0].Line != "the quick brown fox" {
+		t.Fatalf("want the tag's blob content to match, got %+v", matches)
+	}
+	matches, err = index.Grep("rewritten")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 0 {
+		t.Fatalf("want no matches for working tree content, got %+v", matches)
+	}
+}
+
+func TestNewRepoIndexDetachedHead(t *testing.T) {
+	dir := initRepo(t, map[string]string{
+		"a.txt": "the quick brown fox\n",
+	})
+
+	commit, err := runGit(dir, "rev-parse", "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command("git", "checkout", "--detach", commit)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git checkout --detach: %s\n%s", err, out)
+	}
+
+	index, err := NewRepoIndex(dir, IndexOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if index.Branch != commit {
+		t.Fatalf("want the detached commit %q as the ref, got %q", commit, index.Branch)
+	}
+	if index.Commit != commit {
+		t.Fatalf("want commit %q, got %q", commit, index.Commit)
+	}
+	if len(index.Blobs) != 1 {
+		t.Fatalf("want 1 indexed blob, got %d", len(index.Blobs))
+	}
+}
+
+func TestGrepPerFileDeadline(t *testing.T) {
+	big := strings.Repeat("filler line without the needle\n", 5000) + "the needle line\n"
+	dir := initRepo(t, map[string]string{
+		"big.txt":   big,
+		"small.txt": "the needle line\n",
+	})
+
+	index, err := NewRepoIndex(dir, IndexOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Advance the fake clock by a millisecond per observation so any file
+	// with more than a handful of lines blows a tight deadline.
+	fake := time.Unix(0, 0)
+	now = func() time.Time {
+		fake = fake.Add(time.Millisecond)
+		return fake
+	}
+	t.Cleanup(func() { now = time.Now })
+
+	matches, stats, err := index.GrepWithOptions(context.Background(), "needle", GrepOptions{
+		PerFileDeadline: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The big file is skipped and reported, the small one still matches.
+	if len(matches) != 1 || matches[0].Path != "small.txt" {
+		t.Fatalf("unexpected matches: %+v", matches)
+	}
+	if stats.FilesSkipped != 1 || stats.FilesScanned != 1 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+	if len(stats.Warnings) != 1 || !strings.Contains(stats.Warnings[0], "big.txt") {
+		t.Fatalf("unexpected warnings: %+v", stats.Warnings)
+	}
+
+	// A cancelled context stops the search entirely.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := index.GrepWithOptions(ctx, "needle", GrepOptions{}); err == nil {
+		t.Fatal("want error from cancelled context")
+	}
+}
+
+func TestIndexOptionsExtensions(t *testing.T) {
+	dir := initRepo(t, map[string]string{
+		"a.go":      "package main\n",
+		"b.txt":     "the quick brown fox\n",
+		"data.json": `{"key": "value"}` + "\n",
+	})
+
+	indexedPaths := func(t *testing.T, opts IndexOptions) map[string]bool {
+		t.Helper()
+		index, err := NewRepoIndex(dir, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		paths := make(map[string]bool)
+		for _, b := range index.Blobs {
+			paths[b.Path] = true
+		}
+		return paths
+	}
+
+	// The default denylist excludes data files like JSON.
+	paths := indexedPaths(t, IndexOptions{})
+	if paths["data.json"] {
+		t.Fatalf("want data.json excluded by the default denylist, indexed %v", paths)
+	}
+	if !paths["a.go"] || !paths["b.txt"] {
+		t.Fatalf("want a.go and b.txt indexed, indexed %v", paths)
+	}
+
+	// An allowlist restricts indexing to the listed extensions.
+	paths = indexedPaths(t, IndexOptions{ExtensionAllowlist: []string{".go"}})
+	if len(paths) != 1 || !paths["a.go"] {
+		t.Fatalf("want only a.go indexed, indexed %v", paths)
+	}
+
+	// An empty, non-nil denylist excludes nothing.
+	paths = indexedPaths(t, IndexOptions{ExtensionDenylist: []string{}})
+	if len(paths) != 3 {
+		t.Fatalf("want all 3 files indexed, indexed %v", paths)
+	}
+}
+
+func TestEstimateFalsePositiveRate(t *testing.T) {
+	dir := initRepo(t, map[string]string{
+		"a.txt": "the quick brown fox\njumps over the lazy dog\n",
+		"b.txt": "lorem ipsum dolor sit amet\n",
+		"c.txt": "package bitmask is an experimental index\n",
+	})
+
+	index, err := NewRepoIndex(dir, IndexOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	queries := []string{
+		"quick brown",
+		"lorem ipsum",
+		"experimental index",
+		"zzz_not_present_zzz",
+		"another absent needle",
+	}
+
+	// With filters sized for a 1% false positive rate, a small sample over a
+	// tiny index should report (close to) none.
+	fpr := index.EstimateFalsePositiveRate(queries)
+	if fpr < 0 || fpr > 0.05 {
+		t.Fatalf("false positive rate outside expected range [0, 0.05]: %f", fpr)
+	}
+}
+
+func TestIndexOptions(t *testing.T) {
+	files := map[string]string{
+		"a.txt": "the quick brown fox\njumps over the lazy dog\n",
+		"b.txt": "lorem ipsum dolor sit amet\n",
+		"c.txt": "package bitmask is an experimental index\n",
+	}
+	dir := initRepo(t, files)
+
+	// A deliberately loose filter should report a false positive rate at
+	// least as high as one sized an order of magnitude tighter.
+	loose, err := NewRepoIndex(dir, IndexOptions{FalsePositiveRate: 0.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tight, err := NewRepoIndex(dir, IndexOptions{FalsePositiveRate: 0.001})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loose.Options.FalsePositiveRate != 0.5 || tight.Options.FalsePositiveRate != 0.001 {
+		t.Fatalf("options not persisted: %+v, %+v", loose.Options, tight.Options)
+	}
+
+	queries := []string{
+		"zzz_not_present_zzz",
+		"another absent needle",
+		"qqq qqq qqq",
+		"xyzzy plugh",
+	}
+	if lr, tr := loose.EstimateFalsePositiveRate(queries), tight.EstimateFalsePositiveRate(queries); lr < tr {
+		t.Fatalf("loose filter reported a lower false positive rate (%f) than tight filter (%f)", lr, tr)
+	}
+
+	for _, opts := range []IndexOptions{
+		{FalsePositiveRate: -1},
+		{FalsePositiveRate: 1},
+		{SizePadding: -1},
+	} {
+		if _, err := NewRepoIndex(dir, opts); err == nil {
+			t.Fatalf("want error for options %+v", opts)
+		}
+	}
+}
+
+// serializedBenchmarkIndex builds a moderately sized index, without needing a
+// git repository, and returns its serialized form.
+func serializedBenchmarkIndex(b *testing.B) []byte {
+	b.Helper()
+
+	index := &RepoIndex{Dir: "bench", Branch: "main", Commit: "deadbeef"}
+	for i := 0; i < 256; i++ {
+		filter := newFilter(4096, 0.01)
+		for gram := range ngrams(strings.Repeat(fmt.Sprintf("content of blob %d, ", i), 256)) {
+			filter.add(gram)
+		}
+		index.Blobs = append(index.Blobs, BlobIndex{Path: fmt.Sprintf("file%d.txt", i), Filter: filter})
+	}
+
+	var buf bytes.Buffer
+	if err := SerializeRepoIndex(&buf, index); err != nil {
+		b.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// benchmarkFirstQuery measures the latency of the first query against a
+// freshly deserialized index, optionally warming the filters beforehand.
+func benchmarkFirstQuery(b *testing.B, warm bool) {
+	data := serializedBenchmarkIndex(b)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		index, err := DeserializeRepoIndex(bytes.NewReader(data))
+		if err != nil {
+			b.Fatal(err)
+		}
+		if warm {
+			index.Warm()
+		}
+		_ = index.PathsMatchingQuery("zzz_not_present_zzz")
+	}
+}
+
+func BenchmarkFirstQueryCold(b *testing.B) { benchmarkFirstQuery(b, false) }
+func BenchmarkFirstQueryWarm(b *testing.B) { benchmarkFirstQuery(b, true) }
@@ -0,0 +1,73 @@
+package bitmask
+
+import (
+	"hash/fnv"
+	"math"
+
+	"github.com/bits-and-blooms/bitset"
+)
+
+// Filter is a bloom filter over the ngrams of a blob. It answers "does this
+// blob possibly contain s" with a false positive rate controlled by the
+// estimate the filter was sized for.
+//
+// Fields are exported so the filter can be serialized along with its index.
+type Filter struct {
+	Bits             *bitset.BitSet
+	NumHashFunctions uint
+}
+
+// newFilter returns a filter sized to hold n members with the given target
+// false positive rate.
+func newFilter(n int, falsePositiveRate float64) *Filter {
+	if n < 1 {
+		n = 1
+	}
+	m := uint(math.Ceil(-float64(n) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2)))
+	if m < 1 {
+		m = 1
+	}
+	k := uint(math.Round(float64(m) / float64(n) * math.Ln2))
+	if k < 1 {
+		k = 1
+	}
+	return &Filter{Bits: bitset.New(m), NumHashFunctions: k}
+}
+
+func (f *Filter) add(s string) {
+	h1, h2 := hashPair(s)
+	m := f.Bits.Len()
+	for i := uint(0); i < f.NumHashFunctions; i++ {
+		f.Bits.Set((h1 + i*h2) % m)
+	}
+}
+
+func (f *Filter) test(s string) bool {
+	h1, h2 := hashPair(s)
+	m := f.Bits.Len()
+	for i := uint(0); i < f.NumHashFunctions; i++ {
+		if !f.Bits.Test((h1 + i*h2) % m) {
+			return false
+		}
+	}
+	return true
+}
+
+// testAll reports whether every gram is possibly a member of the filter.
+func (f *Filter) testAll(grams map[string]struct{}) bool {
+	for g := range grams {
+		if !f.test(g) {
+			return false
+		}
+	}
+	return true
+}
+
+// hashPair returns two hashes of s used for double hashing. The second hash
+// is forced odd so successive probes cover the whole bit array.
+func hashPair(s string) (uint, uint) {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(s))
+	sum := h.Sum64()
+	return uint(sum & 0xffffffff), uint(sum>>32) | 1
+}
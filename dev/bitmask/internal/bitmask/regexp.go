@@ -0,0 +1,183 @@
+package bitmask
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"sync"
+	"time"
+)
+
+// compileRegexp is a test seam for counting compilations.
+var compileRegexp = regexp.Compile
+
+// regexpEntry is a cached compiled regexp along with the ngrams of a literal
+// every match must contain. A nil grams map means no literal could be
+// extracted and the pattern can't be pruned against the filters.
+type regexpEntry struct {
+	re    *regexp.Regexp
+	grams map[string]struct{}
+}
+
+// regexpCache is a size-bounded LRU of regexpEntry keyed by pattern, so
+// repeated queries don't recompile their regexp or re-derive its required
+// ngrams. It is safe for concurrent use.
+type regexpCache struct {
+	mu      sync.Mutex
+	max     int
+	order   *list.List // front is most recently used; values are *regexpCacheItem
+	entries map[string]*list.Element
+}
+
+type regexpCacheItem struct {
+	pattern string
+	entry   *regexpEntry
+}
+
+func newRegexpCache(max int) *regexpCache {
+	return &regexpCache{
+		max:     max,
+		order:   list.New(),
+		entries: make(map[string]*list.Element, max),
+	}
+}
+
+// get returns the cached entry for pattern, compiling and caching it on a
+// miss. Compilation errors are not cached.
+func (c *regexpCache) get(pattern string) (*regexpEntry, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[pattern]; ok {
+		c.order.MoveToFront(el)
+		return el.Value.(*regexpCacheItem).entry, nil
+	}
+
+	re, err := compileRegexp(pattern)
+	if err != nil {
+		return nil, err
+	}
+	entry := &regexpEntry{re: re, grams: requiredGrams(pattern)}
+
+	c.entries[pattern] = c.order.PushFront(&regexpCacheItem{pattern: pattern, entry: entry})
+	if c.order.Len() > c.max {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*regexpCacheItem).pattern)
+	}
+
+	return entry, nil
+}
+
+// len returns the number of cached patterns.
+func (c *regexpCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// defaultRegexpCacheSize bounds the package-level cache used by GrepRegexp.
+const defaultRegexpCacheSize = 128
+
+var defaultRegexpCache = newRegexpCache(defaultRegexpCacheSize)
+
+// requiredGrams returns the ngrams of the longest case-sensitive literal every
+// match of pattern must contain, or nil if no such literal of at least
+// ngramSize exists.
+func requiredGrams(pattern string) map[string]struct{} {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return nil
+	}
+	lit := longestRequiredLiteral(re.Simplify())
+	if len(lit) < ngramSize {
+		return nil
+	}
+	return ngrams(lit)
+}
+
+// longestRequiredLiteral returns the longest literal that every match of re
+// must contain, or the empty string if there is none (e.g. under an
+// alternation or an optional part).
+func longestRequiredLiteral(re *syntax.Regexp) string {
+	switch re.Op {
+	case syntax.OpLiteral:
+		if re.Flags&syntax.FoldCase != 0 {
+			return ""
+		}
+		return string(re.Rune)
+	case syntax.OpConcat, syntax.OpCapture, syntax.OpPlus:
+		// All subexpressions of a concatenation must match, and a capture or
+		// plus matches its subexpression at least once.
+		best := ""
+		for _, sub := range re.Sub {
+			if lit := longestRequiredLiteral(sub); len(lit) > len(best) {
+				best = lit
+			}
+		}
+		return best
+	}
+	return ""
+}
+
+// GrepRegexp returns the lines matching the regexp pattern, scanning only the
+// blobs whose filters match the ngrams of a literal the pattern requires.
+// Patterns without such a literal scan every indexed blob.
+func (r *RepoIndex) GrepRegexp(pattern string) ([]LineMatch, error) {
+	matches, _, err := r.GrepRegexpWithOptions(context.Background(), pattern, GrepOptions{})
+	return matches, err
+}
+
+// GrepRegexpWithOptions is GrepRegexp with a context covering the whole
+// search and per-call options.
+func (r *RepoIndex) GrepRegexpWithOptions(ctx context.Context, pattern string, opts GrepOptions) ([]LineMatch, GrepStats, error) {
+	entry, err := defaultRegexpCache.get(pattern)
+	if err != nil {
+		return nil, GrepStats{}, err
+	}
+
+	var stats GrepStats
+	var matches []LineMatch
+	for _, b := range r.Blobs {
+		if err := ctx.Err(); err != nil {
+			return nil, stats, err
+		}
+		if entry.grams != nil && !b.Filter.testAll(entry.grams) {
+			continue
+		}
+
+		data, err := r.readBlob(b.Path)
+		if err != nil {
+			return nil, stats, err
+		}
+
+		var deadline time.Time
+		if opts.PerFileDeadline > 0 {
+			deadline = now().Add(opts.PerFileDeadline)
+		}
+
+		skipped := false
+		var fileMatches []LineMatch
+		for i, line := range strings.Split(string(data), "\n") {
+			if !deadline.IsZero() && now().After(deadline) {
+				skipped = true
+				break
+			}
+			if entry.re.MatchString(line) {
+				fileMatches = append(fileMatches, LineMatch{Path: b.Path, LineNumber: i + 1, Line: line})
+			}
+		}
+		if skipped {
+			stats.FilesSkipped++
+			stats.Warnings = append(stats.Warnings, fmt.Sprintf("%s: scan exceeded per-file deadline of %s, file skipped", b.Path, opts.PerFileDeadline))
+			continue
+		}
+
+		stats.FilesScanned++
+		matches = append(matches, fileMatches...)
+	}
+	return matches, stats, nil
+}
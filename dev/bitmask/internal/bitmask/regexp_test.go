@@ -0,0 +1,130 @@
+package bitmask
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestRegexpCache(t *testing.T) {
+	compiles := 0
+	compileRegexp = func(pattern string) (*regexp.Regexp, error) {
+		compiles++
+		return regexp.Compile(pattern)
+	}
+	defer func() { compileRegexp = regexp.Compile }()
+
+	cache := newRegexpCache(2)
+
+	if _, err := cache.get("quick [bB]rown"); err != nil {
+		t.Fatal(err)
+	}
+	if compiles != 1 {
+		t.Fatalf("want 1 compile, got %d", compiles)
+	}
+
+	// A repeated pattern must not be recompiled.
+	entry, err := cache.get("quick [bB]rown")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if compiles != 1 {
+		t.Fatalf("want repeated pattern not to be recompiled, got %d compiles", compiles)
+	}
+	if entry.grams == nil {
+		t.Fatal("want required ngrams to be extracted from the literal prefix")
+	}
+
+	// Filling the cache beyond its bound evicts the least recently used
+	// pattern, so re-requesting it recompiles.
+	if _, err := cache.get("second"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cache.get("third"); err != nil {
+		t.Fatal(err)
+	}
+	if cache.len() != 2 {
+		t.Fatalf("want cache bounded at 2 entries, got %d", cache.len())
+	}
+
+	if _, err := cache.get("quick [bB]rown"); err != nil {
+		t.Fatal(err)
+	}
+	if compiles != 4 {
+		t.Fatalf("want evicted pattern to be recompiled, got %d compiles", compiles)
+	}
+
+	// Invalid patterns are not cached.
+	if _, err := cache.get("("); err == nil {
+		t.Fatal("want error for invalid pattern")
+	}
+	if cache.len() != 2 {
+		t.Fatalf("want invalid pattern not to be cached, got %d entries", cache.len())
+	}
+}
+
+func TestLongestRequiredLiteral(t *testing.T) {
+	testCases := map[string]string{
+		"quick brown":        "quick brown",
+		"quick [bB]rown":     "quick ",
+		"(quick) brown":      " brown",
+		"quick|brown":        "",
+		"(?i)quick":          "",
+		"qu(ick brown)+ fox": "ick brown",
+	}
+
+	for pattern, want := range testCases {
+		grams := requiredGrams(pattern)
+		if want == "" {
+			if grams != nil {
+				t.Errorf("%q: want no required ngrams, got %v", pattern, grams)
+			}
+			continue
+		}
+		if grams == nil {
+			t.Errorf("%q: want required ngrams of %q, got none", pattern, want)
+			continue
+		}
+		for g := range ngrams(want) {
+			if _, ok := grams[g]; !ok {
+				t.Errorf("%q: want ngram %q to be required", pattern, g)
+			}
+		}
+	}
+}
+
+func TestGrepRegexp(t *testing.T) {
+	dir := initRepo(t, map[string]string{
+		"a.txt":     "the quick brown fox\njumps over the lazy dog\n",
+		"sub/b.txt": "lorem ipsum dolor sit amet\n",
+	})
+
+	index, err := NewRepoIndex(dir, IndexOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	matches, err := index.GrepRegexp("qu[i]ck b.own")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 {
+		t.Fatalf("want 1 match, got %d: %+v", len(matches), matches)
+	}
+	if matches[0].Path != "a.txt" || matches[0].LineNumber != 1 {
+		t.Fatalf("unexpected match: %+v", matches[0])
+	}
+
+	// A pattern without a required literal still finds its matches by
+	// scanning every blob.
+	matches, err = index.GrepRegexp("ipsum|absent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 || matches[0].Path != "sub/b.txt" {
+		t.Fatalf("unexpected matches: %+v", matches)
+	}
+
+	if _, err := index.GrepRegexp("("); err == nil {
+		t.Fatal("want error for invalid pattern")
+	}
+}
@@ -0,0 +1,113 @@
+// Command bitmask builds and queries an experimental bloom-filter index over
+// the files of a git repository.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/dev/bitmask/internal/bitmask"
+)
+
+func main() {
+	log.SetFlags(0)
+
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "index":
+		err = indexCommand(os.Args[2:])
+	case "grep":
+		err = grepCommand(os.Args[2:])
+	default:
+		usage()
+	}
+	if err != nil {
+		log.Fatalf("error: %s", err)
+	}
+}
+
+func usage() {
+	log.Fatalf("usage: bitmask index [-repo DIR] [-out FILE] | bitmask grep [-index FILE] QUERY")
+}
+
+func indexCommand(args []string) error {
+	fs := flag.NewFlagSet("index", flag.ExitOnError)
+	repo := fs.String("repo", ".", "path of the git repository to index")
+	out := fs.String("out", "bitmask.index", "path the serialized index is written to")
+	fpr := fs.Float64("fpr", 0, "target false positive rate of the per-blob filters (default 0.01)")
+	padding := fs.Int("padding", 0, "padding added to the ngram count before sizing a filter (default 10)")
+	ref := fs.String("ref", "", "branch, tag or commit to index (default the current branch)")
+	_ = fs.Parse(args)
+
+	index, err := bitmask.NewRepoIndex(*repo, bitmask.IndexOptions{
+		FalsePositiveRate: *fpr,
+		SizePadding:       *padding,
+		Ref:               *ref,
+	})
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := bitmask.SerializeRepoIndex(f, index); err != nil {
+		return err
+	}
+	log.Printf("indexed %d blobs of %s at %s (%s)", len(index.Blobs), index.Dir, index.Branch, index.Commit)
+	return nil
+}
+
+func grepCommand(args []string) error {
+	fs := flag.NewFlagSet("grep", flag.ExitOnError)
+	indexPath := fs.String("index", "bitmask.index", "path of the serialized index")
+	fileDeadline := fs.Duration("file-deadline", 0, "maximum time to spend scanning a single file (default unlimited)")
+	warm := fs.Bool("warm", false, "touch all filters after loading so the first query isn't slower than the rest")
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		usage()
+	}
+	query := fs.Arg(0)
+
+	f, err := os.Open(*indexPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	loadStart := time.Now()
+	index, err := bitmask.DeserializeRepoIndex(f)
+	if err != nil {
+		return err
+	}
+	if *warm {
+		index.Warm()
+	}
+	log.Printf("loaded index of %d blobs in %s", len(index.Blobs), time.Since(loadStart))
+
+	matches, stats, err := index.GrepWithOptions(context.Background(), query, bitmask.GrepOptions{
+		PerFileDeadline: *fileDeadline,
+	})
+	if err != nil {
+		return err
+	}
+	for _, m := range matches {
+		fmt.Printf("%s:%d:%s\n", m.Path, m.LineNumber, m.Line)
+	}
+	for _, w := range stats.Warnings {
+		log.Printf("warning: %s", w)
+	}
+	return nil
+}
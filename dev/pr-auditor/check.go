@@ -14,6 +14,8 @@ type checkResult struct {
 	Reviewed bool
 	// TestPlan is the content provided after the acceptance checklist checkbox.
 	TestPlan string
+	// TestPlanQuality classifies how substantive the test plan is.
+	TestPlanQuality testPlanQuality
 	// Error indicating any issue that might have occured during the check.
 	Error error
 }
@@ -22,6 +24,45 @@ func (r checkResult) HasTestPlan() bool {
 	return r.TestPlan != ""
 }
 
+// testPlanQuality classifies a test plan beyond mere presence.
+type testPlanQuality string
+
+const (
+	// testPlanMissing means no test plan section was found at all.
+	testPlanMissing testPlanQuality = "missing"
+	// testPlanPlaceholder means the plan is a known-empty placeholder like "n/a".
+	testPlanPlaceholder testPlanQuality = "placeholder"
+	// testPlanTooShort means the plan is shorter than the configured minimum length.
+	testPlanTooShort testPlanQuality = "too short"
+	// testPlanSubstantive means the plan passes all quality checks.
+	testPlanSubstantive testPlanQuality = "substantive"
+)
+
+// defaultMinTestPlanLength is the minimum test plan length applied when
+// checkOpts does not provide one.
+const defaultMinTestPlanLength = 10
+
+// testPlanPlaceholders are trivial plans that convey no information; they are
+// compared case-insensitively against the whole plan.
+var testPlanPlaceholders = []string{"n/a", "na", "none", "todo", "tbd"}
+
+// classifyTestPlan grades a cleaned-up test plan against the placeholder list
+// and the minimum length.
+func classifyTestPlan(plan string, minLength int) testPlanQuality {
+	if minLength <= 0 {
+		minLength = defaultMinTestPlanLength
+	}
+	for _, placeholder := range testPlanPlaceholders {
+		if strings.EqualFold(plan, placeholder) {
+			return testPlanPlaceholder
+		}
+	}
+	if len(plan) < minLength {
+		return testPlanTooShort
+	}
+	return testPlanSubstantive
+}
+
 var (
 	testPlanDividerRegexp       = regexp.MustCompile("(?m)(^#+ Test [pP]lan)|(^Test [pP]lan:)")
 	noReviewNeededDividerRegexp = regexp.MustCompile("(?m)([nN]o [rR]eview [rR]equired:)")
@@ -30,6 +71,9 @@ var (
 
 type checkOpts struct {
 	ValidateReviews bool
+	// MinTestPlanLength is the length below which a test plan is graded
+	// testPlanTooShort. Defaults to defaultMinTestPlanLength.
+	MinTestPlanLength int
 }
 
 func checkPR(ctx context.Context, ghc *github.Client, payload *EventPayload, opts checkOpts) checkResult {
@@ -51,8 +95,9 @@ func checkPR(ctx context.Context, ghc *github.Client, payload *EventPayload, opt
 	sections := testPlanDividerRegexp.Split(pr.Body, 2)
 	if len(sections) < 2 {
 		return checkResult{
-			Reviewed: reviewed,
-			Error:    err,
+			Reviewed:        reviewed,
+			TestPlanQuality: testPlanMissing,
+			Error:           err,
 		}
 	}
 
@@ -67,9 +112,10 @@ func checkPR(ctx context.Context, ghc *github.Client, payload *EventPayload, opt
 	}
 
 	return checkResult{
-		Reviewed: reviewed,
-		TestPlan: testPlan,
-		Error:    err,
+		Reviewed:        reviewed,
+		TestPlan:        testPlan,
+		TestPlanQuality: classifyTestPlan(testPlan, opts.MinTestPlanLength),
+		Error:           err,
 	}
 }
 
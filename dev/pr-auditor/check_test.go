@@ -21,22 +21,43 @@ func TestCheckTestPlan(t *testing.T) {
 			name:     "has test plan",
 			bodyFile: "testdata/pull_request_body/has-plan.md",
 			want: checkResult{
-				Reviewed: false,
-				TestPlan: "I have a plan!",
+				Reviewed:        false,
+				TestPlan:        "I have a plan!",
+				TestPlanQuality: testPlanSubstantive,
 			},
 		},
 		{
 			name:     "no test plan",
 			bodyFile: "testdata/pull_request_body/no-plan.md",
 			want: checkResult{
-				Reviewed: false,
+				Reviewed:        false,
+				TestPlanQuality: testPlanMissing,
+			},
+		},
+		{
+			name:     "placeholder test plan",
+			bodyFile: "testdata/pull_request_body/placeholder-plan.md",
+			want: checkResult{
+				Reviewed:        false,
+				TestPlan:        "n/a",
+				TestPlanQuality: testPlanPlaceholder,
+			},
+		},
+		{
+			name:     "trivially short test plan",
+			bodyFile: "testdata/pull_request_body/short-plan.md",
+			want: checkResult{
+				Reviewed:        false,
+				TestPlan:        "CI",
+				TestPlanQuality: testPlanTooShort,
 			},
 		},
 		{
 			name:     "complicated test plan",
 			bodyFile: "testdata/pull_request_body/has-plan-fancy.md",
 			want: checkResult{
-				Reviewed: false,
+				Reviewed:        false,
+				TestPlanQuality: testPlanSubstantive,
 				TestPlan: `This is a plan!
 Quite lengthy
 
@@ -51,7 +72,8 @@ And a little complicated; there's also the following reasons:
 			name:     "inline test plan",
 			bodyFile: "testdata/pull_request_body/inline-plan.md",
 			want: checkResult{
-				Reviewed: false,
+				Reviewed:        false,
+				TestPlanQuality: testPlanSubstantive,
 				TestPlan: `This is a plan!
 Quite lengthy
 
@@ -66,8 +88,9 @@ And a little complicated; there's also the following reasons:
 			name:     "no review required",
 			bodyFile: "testdata/pull_request_body/no-review-required.md",
 			want: checkResult{
-				Reviewed: true,
-				TestPlan: "I have a plan! No review required: this is a bot PR",
+				Reviewed:        true,
+				TestPlan:        "I have a plan! No review required: this is a bot PR",
+				TestPlanQuality: testPlanSubstantive,
 			},
 		},
 	}
@@ -84,6 +107,7 @@ And a little complicated; there's also the following reasons:
 				ValidateReviews: false,
 			})
 			assert.Equal(t, tt.want.HasTestPlan(), got.HasTestPlan())
+			assert.Equal(t, tt.want.TestPlanQuality, got.TestPlanQuality)
 			t.Log("got.TestPlan: ", got.TestPlan)
 			if tt.want.TestPlan == "" {
 				assert.Empty(t, got.TestPlan)
@@ -145,6 +145,17 @@ func withOAuthExternalClient(r *http.Request) *http.Request {
 
 var traceLogEnabled, _ = strconv.ParseBool(env.Get("INSECURE_OAUTH2_LOG_TRACES", "false", "Log all OAuth2-related HTTP requests and responses. Only use during testing because the log messages will contain sensitive data."))
 
+// bodyPreviewLimit is the maximum number of body bytes included in OAuth2
+// trace log previews. The default is enough for most error payloads while
+// keeping log lines manageable.
+var bodyPreviewLimit = func() int {
+	limit, err := strconv.Atoi(env.Get("INSECURE_OAUTH2_LOG_TRACES_PREVIEW_BYTES", "1000", "Maximum number of body bytes included in OAuth2 trace log previews."))
+	if err != nil || limit < 0 {
+		return 1000
+	}
+	return limit
+}()
+
 type loggingRoundTripper struct {
 	underlying http.RoundTripper
 }
@@ -154,13 +165,15 @@ func previewAndDuplicateReader(reader io.ReadCloser) (preview string, freshReade
 		return "", reader, nil
 	}
 	defer reader.Close()
+	// Always buffer the full body so the downstream reader is unaffected by
+	// the preview limit.
 	b, err := io.ReadAll(reader)
 	if err != nil {
 		return "", nil, err
 	}
 	preview = string(b)
-	if len(preview) > 1000 {
-		preview = preview[:1000]
+	if len(preview) > bodyPreviewLimit {
+		preview = preview[:bodyPreviewLimit]
 	}
 	return preview, io.NopCloser(bytes.NewReader(b)), nil
 }
@@ -2,6 +2,8 @@ package oauth
 
 import (
 	"context"
+	"io"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -12,6 +14,28 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 )
 
+func Test_previewAndDuplicateReader(t *testing.T) {
+	origLimit := bodyPreviewLimit
+	bodyPreviewLimit = 10
+	defer func() { bodyPreviewLimit = origLimit }()
+
+	body := strings.Repeat("x", 25)
+	preview, fresh, err := previewAndDuplicateReader(io.NopCloser(strings.NewReader(body)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The preview is truncated at the limit but the downstream reader still
+	// sees the full body.
+	assert.Equal(t, body[:10], preview)
+
+	b, err := io.ReadAll(fresh)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, body, string(b))
+}
+
 func Test_getExtraScopes(t *testing.T) {
 	dotcom := envvar.SourcegraphDotComMode()
 	envvar.MockSourcegraphDotComMode(true)
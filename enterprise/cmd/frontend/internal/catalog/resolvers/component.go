@@ -0,0 +1,58 @@
+package resolvers
+
+import (
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+)
+
+// catalogComponentResolver resolves a single component in the catalog.
+type catalogComponentResolver struct {
+	db database.DB
+
+	// catalog is the catalog the component belongs to, for resolvers that
+	// relate it to other components (Dependencies, Dependents).
+	catalog *catalogResolver
+
+	name        string
+	kind        string
+	description string
+	system      string
+	owner       string
+	tags        []string
+
+	sourceRepo   api.RepoName
+	sourceCommit api.CommitID
+	sourcePaths  []string
+
+	usagePatterns []usagePattern
+
+	// dependsOn holds the names of the components this one declares a
+	// dependency on.
+	dependsOn []string
+
+	usagesCache
+}
+
+// usagePattern is a search query whose results are usages of the component.
+type usagePattern struct {
+	query string
+}
+
+func (r *catalogComponentResolver) Name() string        { return r.name }
+func (r *catalogComponentResolver) Kind() string        { return r.kind }
+func (r *catalogComponentResolver) Description() string { return r.description }
+func (r *catalogComponentResolver) System() *string {
+	if r.system == "" {
+		return nil
+	}
+	return &r.system
+}
+func (r *catalogComponentResolver) Owner() string  { return r.owner }
+func (r *catalogComponentResolver) Tags() []string { return r.tags }
+func (r *catalogComponentResolver) SourceRepo() string {
+	return string(r.sourceRepo)
+}
+func (r *catalogComponentResolver) SourceCommit() string {
+	return string(r.sourceCommit)
+}
+func (r *catalogComponentResolver) SourcePaths() []string { return r.sourcePaths }
@@ -0,0 +1,33 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+// componentConnectionResolver resolves a list of components.
+type componentConnectionResolver struct {
+	components []*catalogComponentResolver
+}
+
+func (r *componentConnectionResolver) Nodes() []*catalogComponentResolver { return r.components }
+func (r *componentConnectionResolver) TotalCount() int32                  { return int32(len(r.components)) }
+
+// ComponentsForRepo returns the components whose source lives in the given
+// repository, so a repo page can show the components it defines.
+func (r *catalogResolver) ComponentsForRepo(ctx context.Context, repoName api.RepoName) *componentConnectionResolver {
+	return &componentConnectionResolver{components: componentsForRepo(r.Components(), repoName)}
+}
+
+// componentsForRepo filters components to those whose sourceRepo matches
+// repoName, keeping their relative order.
+func componentsForRepo(components []*catalogComponentResolver, repoName api.RepoName) []*catalogComponentResolver {
+	var matched []*catalogComponentResolver
+	for _, c := range components {
+		if c.sourceRepo == repoName {
+			matched = append(matched, c)
+		}
+	}
+	return matched
+}
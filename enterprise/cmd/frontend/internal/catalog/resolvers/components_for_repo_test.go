@@ -0,0 +1,40 @@
+package resolvers
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestComponentsForRepo(t *testing.T) {
+	components := []*catalogComponentResolver{
+		{name: "frontend", sourceRepo: "github.com/foo/mono"},
+		{name: "gitserver", sourceRepo: "github.com/foo/mono"},
+		{name: "docs", sourceRepo: "github.com/foo/docs"},
+	}
+
+	tests := []struct {
+		repoName api.RepoName
+		want     []string
+	}{
+		{repoName: "github.com/foo/mono", want: []string{"frontend", "gitserver"}},
+		{repoName: "github.com/foo/docs", want: []string{"docs"}},
+		{repoName: "github.com/foo/other", want: nil},
+	}
+	for _, test := range tests {
+		t.Run(string(test.repoName), func(t *testing.T) {
+			var names []string
+			for _, c := range componentsForRepo(components, test.repoName) {
+				names = append(names, c.Name())
+			}
+			if len(names) != len(test.want) {
+				t.Fatalf("want components %v, got %v", test.want, names)
+			}
+			for i := range names {
+				if names[i] != test.want[i] {
+					t.Fatalf("want components %v, got %v", test.want, names)
+				}
+			}
+		})
+	}
+}
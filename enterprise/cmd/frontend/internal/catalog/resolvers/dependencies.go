@@ -0,0 +1,43 @@
+package resolvers
+
+// Dependencies returns the components this one declares a dependency on, in
+// declaration order.
+func (r *catalogComponentResolver) Dependencies() []*catalogComponentResolver {
+	if r.catalog == nil {
+		return nil
+	}
+
+	byName := make(map[string]*catalogComponentResolver)
+	for _, c := range r.catalog.Components() {
+		byName[c.name] = c
+	}
+
+	dependencies := make([]*catalogComponentResolver, 0, len(r.dependsOn))
+	for _, name := range r.dependsOn {
+		if c, ok := byName[name]; ok {
+			dependencies = append(dependencies, c)
+		}
+	}
+	return dependencies
+}
+
+// Dependents returns the components that declare a dependency on this one,
+// for impact analysis ("what breaks if I change X"). It inverts the declared
+// dependency edges by a single-level scan, so dependency cycles are harmless:
+// components in a cycle simply appear in each other's dependents.
+func (r *catalogComponentResolver) Dependents() []*catalogComponentResolver {
+	if r.catalog == nil {
+		return nil
+	}
+
+	var dependents []*catalogComponentResolver
+	for _, c := range r.catalog.Components() {
+		for _, name := range c.dependsOn {
+			if name == r.name {
+				dependents = append(dependents, c)
+				break
+			}
+		}
+	}
+	return dependents
+}
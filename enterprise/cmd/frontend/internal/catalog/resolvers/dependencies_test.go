@@ -0,0 +1,100 @@
+package resolvers
+
+import (
+	"strings"
+	"testing"
+)
+
+func componentNames(components []*catalogComponentResolver) []string {
+	names := make([]string, 0, len(components))
+	for _, c := range components {
+		names = append(names, c.name)
+	}
+	return names
+}
+
+func TestDependents(t *testing.T) {
+	doc := `
+components:
+  - name: frontend
+    kind: SERVICE
+    sourceRepo: github.com/sourcegraph/sourcegraph
+    dependsOn: [gitserver, repo-updater]
+  - name: repo-updater
+    kind: SERVICE
+    sourceRepo: github.com/sourcegraph/sourcegraph
+    dependsOn: [gitserver]
+  - name: gitserver
+    kind: SERVICE
+    sourceRepo: github.com/sourcegraph/sourcegraph
+`
+
+	r := NewCatalogResolver(nil)
+	if err := r.ImportComponents([]byte(doc)); err != nil {
+		t.Fatal(err)
+	}
+
+	byName := make(map[string]*catalogComponentResolver)
+	for _, c := range r.Components() {
+		byName[c.name] = c
+	}
+
+	if got := componentNames(byName["gitserver"].Dependents()); len(got) != 2 || got[0] != "frontend" || got[1] != "repo-updater" {
+		t.Errorf("unexpected dependents of gitserver: %v", got)
+	}
+	if got := componentNames(byName["repo-updater"].Dependents()); len(got) != 1 || got[0] != "frontend" {
+		t.Errorf("unexpected dependents of repo-updater: %v", got)
+	}
+	if got := byName["frontend"].Dependents(); len(got) != 0 {
+		t.Errorf("unexpected dependents of frontend: %v", componentNames(got))
+	}
+
+	if got := componentNames(byName["frontend"].Dependencies()); len(got) != 2 || got[0] != "gitserver" || got[1] != "repo-updater" {
+		t.Errorf("unexpected dependencies of frontend: %v", got)
+	}
+}
+
+func TestDependentsCycle(t *testing.T) {
+	doc := `
+components:
+  - name: a
+    kind: SERVICE
+    sourceRepo: github.com/sourcegraph/sourcegraph
+    dependsOn: [b]
+  - name: b
+    kind: SERVICE
+    sourceRepo: github.com/sourcegraph/sourcegraph
+    dependsOn: [a]
+`
+
+	r := NewCatalogResolver(nil)
+	if err := r.ImportComponents([]byte(doc)); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range r.Components() {
+		dependents := c.Dependents()
+		if len(dependents) != 1 || dependents[0].name == c.name {
+			t.Errorf("unexpected dependents of %q: %v", c.name, componentNames(dependents))
+		}
+	}
+}
+
+func TestImportComponentsUnknownDependency(t *testing.T) {
+	doc := `
+components:
+  - name: frontend
+    kind: SERVICE
+    sourceRepo: github.com/sourcegraph/sourcegraph
+    dependsOn: [gitserver]
+`
+
+	r := NewCatalogResolver(nil)
+	err := r.ImportComponents([]byte(doc))
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+	if want := `unknown dependency "gitserver"`; !strings.Contains(err.Error(), want) {
+		t.Fatalf("want error containing %q, got %q", want, err)
+	}
+}
@@ -0,0 +1,61 @@
+package resolvers
+
+import (
+	"github.com/sourcegraph/sourcegraph/internal/database"
+)
+
+// dummyData returns a hard-coded component catalog, used until components can
+// be defined by site configuration or in-repo metadata files.
+func dummyData(db database.DB) []*catalogComponentResolver {
+	const (
+		sourceRepo   = "github.com/sourcegraph/sourcegraph"
+		sourceCommit = "2ada4911722e2c812cc4f1bbfb6d5d1756891392"
+	)
+
+	components := []*catalogComponentResolver{
+		{
+			name:         "frontend",
+			kind:         "SERVICE",
+			description:  "The frontend serves the web app, public APIs, and internal APIs.",
+			system:       "core",
+			owner:        "search-core",
+			sourceRepo:   sourceRepo,
+			sourceCommit: sourceCommit,
+			sourcePaths:  []string{"cmd/frontend", "enterprise/cmd/frontend"},
+			usagePatterns: []usagePattern{
+				{query: `lang:go \.api/graphql patternType:regexp`},
+			},
+		},
+		{
+			name:         "gitserver",
+			kind:         "SERVICE",
+			description:  "Mirrors repositories from code hosts and serves Git data.",
+			system:       "repo-management",
+			owner:        "repo-management",
+			sourceRepo:   sourceRepo,
+			sourceCommit: sourceCommit,
+			sourcePaths:  []string{"cmd/gitserver"},
+			usagePatterns: []usagePattern{
+				{query: `lang:go gitserver\.Client patternType:regexp`},
+			},
+		},
+		{
+			name:         "repo-updater",
+			kind:         "SERVICE",
+			description:  "Tracks the state of repositories and schedules updates.",
+			system:       "repo-management",
+			owner:        "repo-management",
+			sourceRepo:   sourceRepo,
+			sourceCommit: sourceCommit,
+			sourcePaths:  []string{"cmd/repo-updater"},
+			usagePatterns: []usagePattern{
+				{query: `lang:go repoupdater\.DefaultClient patternType:regexp`},
+			},
+		},
+	}
+
+	for _, c := range components {
+		c.db = db
+	}
+	return components
+}
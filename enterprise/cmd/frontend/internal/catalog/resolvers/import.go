@@ -0,0 +1,97 @@
+package resolvers
+
+import (
+	"github.com/ghodss/yaml"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// catalogDocument is the top-level structure of a declarative catalog
+// document, so teams can manage the catalog as code instead of relying on the
+// hard-coded dummy data.
+type catalogDocument struct {
+	Components []componentSpec `json:"components"`
+}
+
+// componentSpec is the declarative form of one catalog component. The JSON
+// field names double as the YAML field names (documents are parsed by
+// converting YAML to JSON first).
+type componentSpec struct {
+	Name          string   `json:"name"`
+	Kind          string   `json:"kind"`
+	Description   string   `json:"description,omitempty"`
+	System        string   `json:"system,omitempty"`
+	Owner         string   `json:"owner,omitempty"`
+	Tags          []string `json:"tags,omitempty"`
+	SourceRepo    string   `json:"sourceRepo"`
+	SourceCommit  string   `json:"sourceCommit,omitempty"`
+	SourcePaths   []string `json:"sourcePaths,omitempty"`
+	UsagePatterns []string `json:"usagePatterns,omitempty"`
+	DependsOn     []string `json:"dependsOn,omitempty"`
+}
+
+// ImportComponents replaces the catalog's components with the ones defined in
+// the given YAML or JSON document. The document is rejected as a whole if any
+// component is missing a required field (name, kind, sourceRepo), if two
+// components share a name, or if a dependency names a component not defined in
+// the document. Dependency cycles are permitted.
+func (r *catalogResolver) ImportComponents(data []byte) error {
+	var doc catalogDocument
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return errors.Wrap(err, "invalid catalog document")
+	}
+
+	seen := make(map[string]struct{}, len(doc.Components))
+	components := make([]*catalogComponentResolver, 0, len(doc.Components))
+	for i, spec := range doc.Components {
+		if spec.Name == "" {
+			return errors.Newf("component %d: name is required", i)
+		}
+		if spec.Kind == "" {
+			return errors.Newf("component %q: kind is required", spec.Name)
+		}
+		if spec.SourceRepo == "" {
+			return errors.Newf("component %q: sourceRepo is required", spec.Name)
+		}
+		if _, ok := seen[spec.Name]; ok {
+			return errors.Newf("duplicate component name %q", spec.Name)
+		}
+		seen[spec.Name] = struct{}{}
+
+		component := &catalogComponentResolver{
+			db:           r.db,
+			catalog:      r,
+			name:         spec.Name,
+			kind:         spec.Kind,
+			description:  spec.Description,
+			system:       spec.System,
+			owner:        spec.Owner,
+			tags:         spec.Tags,
+			sourceRepo:   api.RepoName(spec.SourceRepo),
+			sourceCommit: api.CommitID(spec.SourceCommit),
+			sourcePaths:  spec.SourcePaths,
+		}
+		for _, query := range spec.UsagePatterns {
+			component.usagePatterns = append(component.usagePatterns, usagePattern{query: query})
+		}
+		component.dependsOn = spec.DependsOn
+		components = append(components, component)
+	}
+
+	// Dependencies may reference components defined later in the document (or
+	// form cycles), so they can only be validated once all names are known.
+	for _, component := range components {
+		for _, dep := range component.dependsOn {
+			if _, ok := seen[dep]; !ok {
+				return errors.Newf("component %q: unknown dependency %q", component.name, dep)
+			}
+		}
+	}
+
+	r.mu.Lock()
+	r.components = components
+	r.mu.Unlock()
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package resolvers
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestImportComponents(t *testing.T) {
+	doc := `
+components:
+  - name: frontend
+    kind: SERVICE
+    description: Serves the web app.
+    system: core
+    owner: search-core
+    tags: [go, http]
+    sourceRepo: github.com/sourcegraph/sourcegraph
+    sourceCommit: deadbeefdeadbeefdeadbeefdeadbeefdeadbeef
+    sourcePaths: [cmd/frontend]
+    usagePatterns:
+      - 'lang:go \.api/graphql patternType:regexp'
+  - name: gitserver
+    kind: SERVICE
+    sourceRepo: github.com/sourcegraph/sourcegraph
+`
+
+	r := NewCatalogResolver(nil)
+	if err := r.ImportComponents([]byte(doc)); err != nil {
+		t.Fatal(err)
+	}
+
+	components := r.Components()
+	if len(components) != 2 {
+		t.Fatalf("want 2 components, got %d", len(components))
+	}
+
+	frontend := components[0]
+	if frontend.Name() != "frontend" || frontend.Kind() != "SERVICE" {
+		t.Fatalf("unexpected component %q of kind %q", frontend.Name(), frontend.Kind())
+	}
+	if frontend.Owner() != "search-core" {
+		t.Errorf("want owner %q, got %q", "search-core", frontend.Owner())
+	}
+	if system := frontend.System(); system == nil || *system != "core" {
+		t.Errorf("want system %q, got %v", "core", system)
+	}
+	if len(frontend.Tags()) != 2 || frontend.Tags()[0] != "go" {
+		t.Errorf("unexpected tags %v", frontend.Tags())
+	}
+	if frontend.SourceRepo() != "github.com/sourcegraph/sourcegraph" {
+		t.Errorf("unexpected source repo %q", frontend.SourceRepo())
+	}
+	if len(frontend.SourcePaths()) != 1 || frontend.SourcePaths()[0] != "cmd/frontend" {
+		t.Errorf("unexpected source paths %v", frontend.SourcePaths())
+	}
+	if len(frontend.usagePatterns) != 1 {
+		t.Errorf("unexpected usage patterns %v", frontend.usagePatterns)
+	}
+
+	if components[1].Name() != "gitserver" {
+		t.Fatalf("want second component %q, got %q", "gitserver", components[1].Name())
+	}
+}
+
+func TestImportComponentsJSON(t *testing.T) {
+	doc := `{"components": [{"name": "frontend", "kind": "SERVICE", "sourceRepo": "github.com/sourcegraph/sourcegraph"}]}`
+
+	r := NewCatalogResolver(nil)
+	if err := r.ImportComponents([]byte(doc)); err != nil {
+		t.Fatal(err)
+	}
+	if components := r.Components(); len(components) != 1 || components[0].Name() != "frontend" {
+		t.Fatalf("unexpected components %v", components)
+	}
+}
+
+func TestImportComponentsInvalid(t *testing.T) {
+	testCases := map[string]struct {
+		doc     string
+		wantErr string
+	}{
+		"duplicate name": {
+			doc: `
+components:
+  - name: frontend
+    kind: SERVICE
+    sourceRepo: github.com/sourcegraph/sourcegraph
+  - name: frontend
+    kind: SERVICE
+    sourceRepo: github.com/sourcegraph/sourcegraph
+`,
+			wantErr: `duplicate component name "frontend"`,
+		},
+		"missing name": {
+			doc:     "components:\n  - kind: SERVICE\n    sourceRepo: github.com/sourcegraph/sourcegraph\n",
+			wantErr: "name is required",
+		},
+		"missing kind": {
+			doc:     "components:\n  - name: frontend\n    sourceRepo: github.com/sourcegraph/sourcegraph\n",
+			wantErr: "kind is required",
+		},
+		"missing sourceRepo": {
+			doc:     "components:\n  - name: frontend\n    kind: SERVICE\n",
+			wantErr: "sourceRepo is required",
+		},
+		"malformed document": {
+			doc:     ":",
+			wantErr: "invalid catalog document",
+		},
+	}
+
+	for label, testCase := range testCases {
+		t.Run(label, func(t *testing.T) {
+			r := NewCatalogResolver(nil)
+			err := r.ImportComponents([]byte(testCase.doc))
+			if err == nil {
+				t.Fatal("expected error, got none")
+			}
+			if !strings.Contains(err.Error(), testCase.wantErr) {
+				t.Fatalf("want error containing %q, got %q", testCase.wantErr, err)
+			}
+
+			// A rejected document must not replace the current components.
+			if r.components != nil {
+				t.Fatal("want no components imported from a rejected document")
+			}
+		})
+	}
+}
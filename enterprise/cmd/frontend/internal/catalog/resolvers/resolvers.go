@@ -0,0 +1,40 @@
+// Package resolvers contains the resolvers for the experimental component
+// catalog: a directory of the services and libraries an organization runs,
+// where they live in source, and how to find their usages.
+package resolvers
+
+import (
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+)
+
+// NewCatalogResolver returns a new resolver for the component catalog.
+func NewCatalogResolver(db database.DB) *catalogResolver {
+	return &catalogResolver{db: db}
+}
+
+type catalogResolver struct {
+	db database.DB
+
+	mu         sync.Mutex
+	components []*catalogComponentResolver // imported components, or nil to fall back to dummyData
+}
+
+// Components returns all components in the catalog.
+func (r *catalogResolver) Components() []*catalogComponentResolver {
+	r.mu.Lock()
+	components := r.components
+	r.mu.Unlock()
+
+	if components == nil {
+		// Imported components get their catalog backref in ImportComponents;
+		// the dummy resolvers are freshly constructed per call, so setting it
+		// here doesn't race with concurrent field resolution.
+		components = dummyData(r.db)
+		for _, c := range components {
+			c.catalog = r
+		}
+	}
+	return components
+}
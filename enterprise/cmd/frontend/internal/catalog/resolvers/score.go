@@ -0,0 +1,100 @@
+package resolvers
+
+import (
+	"context"
+	"time"
+)
+
+// scoreWeights determines how much each signal contributes to a component's
+// overall health score. It's a variable rather than a set of constants so a
+// deployment (or test) can rebalance the weights; they should sum to 1.
+var scoreWeights = struct {
+	Owner     float64
+	Usages    float64
+	Freshness float64
+}{
+	Owner:     0.4,
+	Usages:    0.3,
+	Freshness: 0.3,
+}
+
+const (
+	// scoreUsagesTarget is the number of usage locations at which the usages
+	// signal saturates at 1.
+	scoreUsagesTarget = 10
+
+	// scoreFreshnessWindow is the age at which the freshness signal decays to
+	// 0. A component committed to just now scores 1.
+	scoreFreshnessWindow = 90 * 24 * time.Hour
+)
+
+// timeNow is mocked in tests.
+var timeNow = time.Now
+
+// CatalogComponentScore is a weighted aggregate of a component's health
+// signals along with the per-signal breakdown it was computed from.
+type CatalogComponentScore struct {
+	score     float64
+	breakdown []*scoreSignalResolver
+}
+
+func (r *CatalogComponentScore) Score() float64                    { return r.score }
+func (r *CatalogComponentScore) Breakdown() []*scoreSignalResolver { return r.breakdown }
+
+// scoreSignalResolver resolves a single signal that contributed to a
+// component's score. Its value is in [0, 1] before weighting.
+type scoreSignalResolver struct {
+	name   string
+	value  float64
+	weight float64
+}
+
+func (r *scoreSignalResolver) Name() string    { return r.name }
+func (r *scoreSignalResolver) Value() float64  { return r.value }
+func (r *scoreSignalResolver) Weight() float64 { return r.weight }
+
+// Score aggregates the component's health signals — owner presence, usage
+// pattern matches, and last commit recency — into a single weighted score in
+// [0, 1] so teams get an at-a-glance health indicator per component.
+func (r *catalogComponentResolver) Score(ctx context.Context) (*CatalogComponentScore, error) {
+	var ownerValue float64
+	if r.owner != "" {
+		ownerValue = 1
+	}
+
+	usages, err := r.Usages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	locations := 0
+	for _, usage := range usages {
+		locations += len(usage.Locations())
+	}
+	usagesValue := float64(locations) / scoreUsagesTarget
+	if usagesValue > 1 {
+		usagesValue = 1
+	}
+
+	var freshnessValue float64
+	lastCommit, err := r.LastCommit(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if lastCommit != nil {
+		if age := timeNow().Sub(lastCommit.AuthorDate()); age < scoreFreshnessWindow {
+			freshnessValue = 1 - age.Seconds()/scoreFreshnessWindow.Seconds()
+		}
+	}
+
+	score := &CatalogComponentScore{
+		breakdown: []*scoreSignalResolver{
+			{name: "owner", value: ownerValue, weight: scoreWeights.Owner},
+			{name: "usages", value: usagesValue, weight: scoreWeights.Usages},
+			{name: "freshness", value: freshnessValue, weight: scoreWeights.Freshness},
+		},
+	}
+	for _, signal := range score.breakdown {
+		score.score += signal.value * signal.weight
+	}
+	return score, nil
+}
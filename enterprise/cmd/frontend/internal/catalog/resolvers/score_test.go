@@ -0,0 +1,100 @@
+package resolvers
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+func TestComponentScore(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	origNow := timeNow
+	timeNow = func() time.Time { return now }
+	t.Cleanup(func() { timeNow = origNow })
+
+	// Half of scoreUsagesTarget locations, so the usages signal is 0.5.
+	orig := searchMatches
+	searchMatches = func(ctx context.Context, db database.DB, query string) (result.Matches, error) {
+		matches := make(result.Matches, scoreUsagesTarget/2)
+		for i := range matches {
+			matches[i] = &result.FileMatch{
+				File: result.File{Repo: types.MinimalRepo{Name: "github.com/foo/bar"}},
+			}
+		}
+		return matches, nil
+	}
+	t.Cleanup(func() { searchMatches = orig })
+
+	// A last commit exactly half the freshness window old, so the freshness
+	// signal is 0.5.
+	git.Mocks.Commits = func(repo api.RepoName, opt git.CommitsOptions) ([]*gitdomain.Commit, error) {
+		return []*gitdomain.Commit{{
+			ID:     "1111111111111111111111111111111111111111",
+			Author: gitdomain.Signature{Name: "alice", Date: now.Add(-scoreFreshnessWindow / 2)},
+		}}, nil
+	}
+	t.Cleanup(git.ResetMocks)
+
+	component := &catalogComponentResolver{
+		name:          "frontend",
+		owner:         "frontend-team",
+		sourceRepo:    "github.com/foo/bar",
+		sourcePaths:   []string{"cmd/frontend"},
+		usagePatterns: []usagePattern{{query: `lang:go \.api/graphql patternType:regexp`}},
+	}
+
+	score, err := component.Score(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// owner 1*0.4 + usages 0.5*0.3 + freshness 0.5*0.3
+	if want := 0.7; math.Abs(score.Score()-want) > 1e-9 {
+		t.Errorf("want score %v, got %v", want, score.Score())
+	}
+
+	wantSignals := map[string]float64{"owner": 1, "usages": 0.5, "freshness": 0.5}
+	if len(score.Breakdown()) != len(wantSignals) {
+		t.Fatalf("want %d signals, got %d", len(wantSignals), len(score.Breakdown()))
+	}
+	for _, signal := range score.Breakdown() {
+		if want, ok := wantSignals[signal.Name()]; !ok {
+			t.Errorf("unexpected signal %q", signal.Name())
+		} else if signal.Value() != want {
+			t.Errorf("want signal %q value %v, got %v", signal.Name(), want, signal.Value())
+		}
+	}
+}
+
+func TestComponentScoreNoSignals(t *testing.T) {
+	ctx := context.Background()
+
+	git.Mocks.Commits = func(repo api.RepoName, opt git.CommitsOptions) ([]*gitdomain.Commit, error) {
+		return nil, nil
+	}
+	t.Cleanup(git.ResetMocks)
+
+	component := &catalogComponentResolver{
+		name:        "frontend",
+		sourceRepo:  "github.com/foo/bar",
+		sourcePaths: []string{"cmd/frontend"},
+	}
+
+	score, err := component.Score(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if score.Score() != 0 {
+		t.Errorf("want score 0 for a component with no signals, got %v", score.Score())
+	}
+}
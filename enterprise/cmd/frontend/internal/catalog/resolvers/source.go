@@ -0,0 +1,108 @@
+package resolvers
+
+import (
+	"bytes"
+	"context"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+// SourceLocations returns the tree entries under each of the component's
+// source paths at its source commit. Source paths that don't exist at the
+// commit are skipped.
+func (r *catalogComponentResolver) SourceLocations(ctx context.Context) ([]*sourceLocationResolver, error) {
+	var locs []*sourceLocationResolver
+	for _, path := range r.sourcePaths {
+		entries, err := git.ReadDir(ctx, authz.DefaultSubRepoPermsChecker, r.sourceRepo, r.sourceCommit, path, true)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		locs = append(locs, &sourceLocationResolver{path: path, entries: entries})
+	}
+	return locs, nil
+}
+
+// LastCommit resolves the most recent commit on the repo's default branch
+// touching any of the component's source paths, so the UI can show how
+// recently a component changed. Nil is returned when no commit touches the
+// source paths.
+func (r *catalogComponentResolver) LastCommit(ctx context.Context) (*gitCommitResolver, error) {
+	var last *gitdomain.Commit
+	for _, path := range r.sourcePaths {
+		// No Range means git log starts at HEAD, i.e. the default branch.
+		commits, err := git.Commits(ctx, r.sourceRepo, git.CommitsOptions{N: 1, Path: path}, authz.DefaultSubRepoPermsChecker)
+		if err != nil {
+			return nil, err
+		}
+		if len(commits) == 0 {
+			continue
+		}
+		if commit := commits[0]; last == nil || commit.Author.Date.After(last.Author.Date) {
+			last = commit
+		}
+	}
+	if last == nil {
+		return nil, nil
+	}
+	return &gitCommitResolver{commit: last}, nil
+}
+
+// gitCommitResolver resolves the commit a component's freshness indicator
+// points at.
+type gitCommitResolver struct {
+	commit *gitdomain.Commit
+}
+
+func (r *gitCommitResolver) OID() string           { return string(r.commit.ID) }
+func (r *gitCommitResolver) Author() string        { return r.commit.Author.Name }
+func (r *gitCommitResolver) AuthorDate() time.Time { return r.commit.Author.Date }
+func (r *gitCommitResolver) Message() string       { return string(r.commit.Message) }
+
+// sourceLocationResolver resolves the tree entries under one source path of a
+// component.
+type sourceLocationResolver struct {
+	path    string
+	entries []fs.FileInfo
+}
+
+func (r *sourceLocationResolver) Path() string           { return r.path }
+func (r *sourceLocationResolver) Entries() []fs.FileInfo { return r.entries }
+
+// SourceSize sums the line counts and byte sizes of all files under the
+// component's source paths at its source commit. Source paths that don't
+// exist at the commit are skipped.
+func (r *catalogComponentResolver) SourceSize(ctx context.Context) (linesOfCode int32, byteSize int32, err error) {
+	locs, err := r.SourceLocations(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, loc := range locs {
+		for _, entry := range loc.entries {
+			if !entry.Mode().IsRegular() {
+				continue
+			}
+
+			data, err := git.ReadFile(ctx, r.sourceRepo, r.sourceCommit, entry.Name(), 0, authz.DefaultSubRepoPermsChecker)
+			if err != nil {
+				if os.IsNotExist(err) {
+					continue
+				}
+				return 0, 0, err
+			}
+
+			byteSize += int32(len(data))
+			linesOfCode += int32(bytes.Count(data, []byte("\n")))
+		}
+	}
+
+	return linesOfCode, byteSize, nil
+}
@@ -0,0 +1,125 @@
+package resolvers
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/util"
+)
+
+func TestComponentLastCommit(t *testing.T) {
+	ctx := context.Background()
+
+	older := &gitdomain.Commit{
+		ID:      "1111111111111111111111111111111111111111",
+		Author:  gitdomain.Signature{Name: "alice", Date: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)},
+		Message: "older change",
+	}
+	newer := &gitdomain.Commit{
+		ID:      "2222222222222222222222222222222222222222",
+		Author:  gitdomain.Signature{Name: "bob", Date: time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC)},
+		Message: "newer change",
+	}
+
+	git.Mocks.Commits = func(repo api.RepoName, opt git.CommitsOptions) ([]*gitdomain.Commit, error) {
+		switch opt.Path {
+		case "cmd/frontend":
+			return []*gitdomain.Commit{older}, nil
+		case "lib/frontend":
+			return []*gitdomain.Commit{newer}, nil
+		default:
+			return nil, nil
+		}
+	}
+	t.Cleanup(git.ResetMocks)
+
+	component := &catalogComponentResolver{
+		name:       "frontend",
+		sourceRepo: "github.com/foo/bar",
+		// The last source path has no commits and must be skipped.
+		sourcePaths: []string{"cmd/frontend", "lib/frontend", "enterprise/cmd/frontend"},
+	}
+
+	commit, err := component.LastCommit(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if commit == nil {
+		t.Fatal("want a last commit, got nil")
+	}
+	if commit.OID() != string(newer.ID) {
+		t.Errorf("want the most recent commit across source paths, got %s", commit.OID())
+	}
+	if commit.Author() != "bob" || !commit.AuthorDate().Equal(newer.Author.Date) {
+		t.Errorf("unexpected author metadata: %s %s", commit.Author(), commit.AuthorDate())
+	}
+
+	// A component whose source paths have no commits has no last commit.
+	component.sourcePaths = []string{"enterprise/cmd/frontend"}
+	commit, err = component.LastCommit(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if commit != nil {
+		t.Fatalf("want no last commit, got %+v", commit)
+	}
+}
+
+func TestComponentSourceSize(t *testing.T) {
+	ctx := context.Background()
+
+	files := map[string]string{
+		"cmd/frontend/main.go":  "package main\n\nfunc main() {}\n",
+		"cmd/frontend/doc.go":   "// Package main.\npackage main\n",
+		"cmd/frontend/testdata": "", // directory
+	}
+
+	git.Mocks.ReadDir = func(commit api.CommitID, name string, recurse bool) ([]fs.FileInfo, error) {
+		switch name {
+		case "cmd/frontend":
+			return []fs.FileInfo{
+				&util.FileInfo{Name_: "cmd/frontend/main.go", Size_: int64(len(files["cmd/frontend/main.go"]))},
+				&util.FileInfo{Name_: "cmd/frontend/doc.go", Size_: int64(len(files["cmd/frontend/doc.go"]))},
+				&util.FileInfo{Name_: "cmd/frontend/testdata", Mode_: os.ModeDir},
+			}, nil
+		default:
+			return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+		}
+	}
+	git.Mocks.ReadFile = func(commit api.CommitID, name string) ([]byte, error) {
+		content, ok := files[name]
+		if !ok {
+			return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+		}
+		return []byte(content), nil
+	}
+	t.Cleanup(git.ResetMocks)
+
+	component := &catalogComponentResolver{
+		name:         "frontend",
+		sourceRepo:   "github.com/foo/bar",
+		sourceCommit: "deadbeef",
+		// The second source path doesn't exist at the commit and must be
+		// skipped.
+		sourcePaths: []string{"cmd/frontend", "enterprise/cmd/frontend"},
+	}
+
+	linesOfCode, byteSize, err := component.SourceSize(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantBytes := int32(len(files["cmd/frontend/main.go"]) + len(files["cmd/frontend/doc.go"]))
+	if byteSize != wantBytes {
+		t.Errorf("want %d bytes, got %d", wantBytes, byteSize)
+	}
+	if want := int32(5); linesOfCode != want {
+		t.Errorf("want %d lines of code, got %d", want, linesOfCode)
+	}
+}
@@ -0,0 +1,49 @@
+package resolvers
+
+import "sort"
+
+// ungroupedSystemName is the system that components without a system are
+// grouped under.
+const ungroupedSystemName = "ungrouped"
+
+// catalogSystemResolver resolves a named system and the components in it.
+type catalogSystemResolver struct {
+	name       string
+	components []*catalogComponentResolver
+}
+
+func (r *catalogSystemResolver) Name() string                            { return r.name }
+func (r *catalogSystemResolver) Components() []*catalogComponentResolver { return r.components }
+
+// Systems returns the components in the catalog grouped by system, ordered by
+// system name, so the UI can render a system overview.
+func (r *catalogResolver) Systems() []*catalogSystemResolver {
+	return groupBySystem(r.Components())
+}
+
+// groupBySystem groups components by their system, putting components with no
+// system under ungroupedSystemName. Components keep their relative order
+// within each system.
+func groupBySystem(components []*catalogComponentResolver) []*catalogSystemResolver {
+	byName := map[string]*catalogSystemResolver{}
+	for _, c := range components {
+		name := c.system
+		if name == "" {
+			name = ungroupedSystemName
+		}
+
+		s, ok := byName[name]
+		if !ok {
+			s = &catalogSystemResolver{name: name}
+			byName[name] = s
+		}
+		s.components = append(s.components, c)
+	}
+
+	systems := make([]*catalogSystemResolver, 0, len(byName))
+	for _, s := range byName {
+		systems = append(systems, s)
+	}
+	sort.Slice(systems, func(i, j int) bool { return systems[i].name < systems[j].name })
+	return systems
+}
@@ -0,0 +1,45 @@
+package resolvers
+
+import "testing"
+
+func TestGroupBySystem(t *testing.T) {
+	components := []*catalogComponentResolver{
+		{name: "frontend", system: "core"},
+		{name: "gitserver", system: "repo-management"},
+		{name: "repo-updater", system: "repo-management"},
+		{name: "scripts"},
+		{name: "docs"},
+	}
+
+	systems := groupBySystem(components)
+
+	want := map[string][]string{
+		"core":            {"frontend"},
+		"repo-management": {"gitserver", "repo-updater"},
+		"ungrouped":       {"scripts", "docs"},
+	}
+	if len(systems) != len(want) {
+		t.Fatalf("want %d systems, got %d", len(want), len(systems))
+	}
+
+	// Systems are ordered by name.
+	for i, name := range []string{"core", "repo-management", "ungrouped"} {
+		if systems[i].Name() != name {
+			t.Fatalf("want system %d to be %q, got %q", i, name, systems[i].Name())
+		}
+
+		var names []string
+		for _, c := range systems[i].Components() {
+			names = append(names, c.Name())
+		}
+		if wantNames := want[name]; len(names) != len(wantNames) {
+			t.Fatalf("system %q: want components %v, got %v", name, wantNames, names)
+		} else {
+			for j := range names {
+				if names[j] != wantNames[j] {
+					t.Fatalf("system %q: want components %v, got %v", name, wantNames, names)
+				}
+			}
+		}
+	}
+}
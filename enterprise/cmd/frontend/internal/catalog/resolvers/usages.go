@@ -0,0 +1,105 @@
+package resolvers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// usagesCacheTTL bounds how long computed usages are served from cache.
+// Usages are backed by searches over the whole instance, which are too
+// expensive to run on every catalog page load.
+const usagesCacheTTL = 30 * time.Second
+
+type usagesCache struct {
+	mu        sync.Mutex
+	usages    []*componentUsageResolver
+	fetchedAt time.Time
+}
+
+// searchMatches runs a batch search for query and returns its matches. It's a
+// variable so tests can stub the search backend.
+var searchMatches = func(ctx context.Context, db database.DB, query string) (result.Matches, error) {
+	impl, err := graphqlbackend.NewBatchSearchImplementer(ctx, db, &graphqlbackend.SearchArgs{
+		Query:   query,
+		Version: "V2",
+	})
+	if err != nil {
+		return nil, err
+	}
+	results, err := impl.Results(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return results.Matches, nil
+}
+
+// Usages runs each of the component's usage patterns through the search
+// backend and returns the locations that matched. A pattern whose search
+// failed yields a usage with its Error set instead of failing the whole
+// resolver. Results are cached for usagesCacheTTL.
+func (r *catalogComponentResolver) Usages(ctx context.Context) ([]*componentUsageResolver, error) {
+	r.usagesCache.mu.Lock()
+	defer r.usagesCache.mu.Unlock()
+
+	if r.usagesCache.usages != nil && time.Since(r.usagesCache.fetchedAt) < usagesCacheTTL {
+		return r.usagesCache.usages, nil
+	}
+
+	usages := make([]*componentUsageResolver, 0, len(r.usagePatterns))
+	for _, pattern := range r.usagePatterns {
+		usage := &componentUsageResolver{pattern: pattern}
+
+		matches, err := searchMatches(ctx, r.db, pattern.query)
+		if err != nil {
+			usage.err = err
+		} else {
+			for _, m := range matches {
+				loc := &usageLocationResolver{repo: string(m.RepoName().Name)}
+				if fm, ok := m.(*result.FileMatch); ok {
+					loc.path = fm.Path
+				}
+				usage.locations = append(usage.locations, loc)
+			}
+		}
+
+		usages = append(usages, usage)
+	}
+
+	r.usagesCache.usages = usages
+	r.usagesCache.fetchedAt = time.Now()
+	return usages, nil
+}
+
+// componentUsageResolver resolves the matches of a single usage pattern.
+type componentUsageResolver struct {
+	pattern   usagePattern
+	locations []*usageLocationResolver
+	err       error
+}
+
+func (r *componentUsageResolver) Query() string { return r.pattern.query }
+
+func (r *componentUsageResolver) Locations() []*usageLocationResolver { return r.locations }
+
+// Error returns the message of the search error for this pattern, if any.
+func (r *componentUsageResolver) Error() *string {
+	if r.err == nil {
+		return nil
+	}
+	msg := r.err.Error()
+	return &msg
+}
+
+// usageLocationResolver resolves a single location where a component is used.
+type usageLocationResolver struct {
+	repo string
+	path string
+}
+
+func (r *usageLocationResolver) RepoName() string { return r.repo }
+func (r *usageLocationResolver) Path() string     { return r.path }
@@ -0,0 +1,70 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestComponentUsages(t *testing.T) {
+	ctx := context.Background()
+
+	calls := 0
+	orig := searchMatches
+	searchMatches = func(ctx context.Context, db database.DB, query string) (result.Matches, error) {
+		calls++
+		if query == "bad query" {
+			return nil, errors.New("search failed")
+		}
+		return result.Matches{
+			&result.FileMatch{
+				File: result.File{
+					Repo: types.MinimalRepo{Name: "github.com/foo/bar"},
+					Path: "cmd/frontend/main.go",
+				},
+			},
+		}, nil
+	}
+	t.Cleanup(func() { searchMatches = orig })
+
+	component := &catalogComponentResolver{
+		name: "frontend",
+		usagePatterns: []usagePattern{
+			{query: `lang:go \.api/graphql patternType:regexp`},
+			{query: "bad query"},
+		},
+	}
+
+	usages, err := component.Usages(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(usages) != 2 {
+		t.Fatalf("want 2 usages, got %d", len(usages))
+	}
+
+	if have := usages[0].Locations(); len(have) != 1 ||
+		have[0].RepoName() != "github.com/foo/bar" ||
+		have[0].Path() != "cmd/frontend/main.go" {
+		t.Errorf("unexpected locations: %+v", have)
+	}
+	if usages[0].Error() != nil {
+		t.Errorf("unexpected error for first pattern: %v", *usages[0].Error())
+	}
+
+	if have := usages[1].Error(); have == nil || *have != "search failed" {
+		t.Errorf("want per-pattern error %q, got %v", "search failed", have)
+	}
+
+	// A second call within the TTL must be served from cache.
+	if _, err := component.Usages(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("want 2 search calls after cached lookup, got %d", calls)
+	}
+}
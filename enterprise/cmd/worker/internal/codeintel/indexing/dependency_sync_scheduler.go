@@ -31,6 +31,8 @@ func NewDependencySyncScheduler(
 	dbStore DBStore,
 	workerStore dbworkerstore.Store,
 	externalServiceStore ExternalServiceStore,
+	pollInterval time.Duration,
+	numHandlers int,
 	metrics workerutil.WorkerMetrics,
 ) *workerutil.Worker {
 	rootContext := actor.WithActor(context.Background(), &actor.Actor{Internal: true})
@@ -43,13 +45,15 @@ func NewDependencySyncScheduler(
 
 	return dbworker.NewWorker(rootContext, workerStore, handler, workerutil.WorkerOptions{
 		Name:              "precise_code_intel_dependency_sync_scheduler_worker",
-		NumHandlers:       1,
-		Interval:          time.Second * 5,
+		NumHandlers:       numHandlers,
+		Interval:          pollInterval,
 		HeartbeatInterval: 1 * time.Second,
 		Metrics:           metrics,
 	})
 }
 
+// dependencySyncSchedulerHandler is safe to run with multiple handler
+// routines: it holds no mutable state beyond the stores it writes through.
 type dependencySyncSchedulerHandler struct {
 	dbStore     DBStore
 	workerStore dbworkerstore.Store
@@ -3,6 +3,7 @@ package indexing
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 
@@ -11,6 +12,7 @@ import (
 	ossdbstore "github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 )
 
 func TestDependencySyncSchedulerJVM(t *testing.T) {
@@ -107,3 +109,19 @@ func TestDependencySyncSchedulerGomod(t *testing.T) {
 		t.Errorf("unexpected number of calls to InsertCloneableDependencyRepo. want=%d have=%d", 0, len(mockDBStore.InsertCloneableDependencyRepoFunc.History()))
 	}
 }
+
+func TestNewDependencySyncSchedulerConcurrency(t *testing.T) {
+	newOperations(&observation.TestContext)
+
+	worker := NewDependencySyncScheduler(
+		NewMockDBStore(),
+		NewMockWorkerStore(),
+		NewMockExternalServiceStore(),
+		5*time.Second,
+		4,
+		workerutil.NewMetrics(&observation.TestContext, "test_dependency_sync_scheduler"),
+	)
+	if worker == nil {
+		t.Fatal("expected a worker")
+	}
+}
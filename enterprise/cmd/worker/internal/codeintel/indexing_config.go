@@ -18,6 +18,8 @@ type indexingConfig struct {
 	PolicyBatchSize                        int
 	DependencyIndexerSchedulerPollInterval time.Duration
 	DependencyIndexerSchedulerConcurrency  int
+	DependencySyncSchedulerPollInterval    time.Duration
+	DependencySyncSchedulerConcurrency     int
 }
 
 var indexingConfigInst = &indexingConfig{}
@@ -33,11 +35,16 @@ func (c *indexingConfig) Load() {
 	c.PolicyBatchSize = c.GetInt("PRECISE_CODE_INTEL_AUTO_INDEXING_POLICY_BATCH_SIZE", "100", "The number of policies to consider for auto-indexing scheduling at a time.")
 	c.DependencyIndexerSchedulerPollInterval = c.GetInterval("PRECISE_CODE_INTEL_DEPENDENCY_INDEXER_SCHEDULER_POLL_INTERVAL", "1s", "Interval between queries to the dependency indexing job queue.")
 	c.DependencyIndexerSchedulerConcurrency = c.GetInt("PRECISE_CODE_INTEL_DEPENDENCY_INDEXER_SCHEDULER_CONCURRENCY", "1", "The maximum number of dependency graphs that can be processed concurrently.")
+	c.DependencySyncSchedulerPollInterval = c.GetInterval("PRECISE_CODE_INTEL_DEPENDENCY_SYNC_SCHEDULER_POLL_INTERVAL", "5s", "Interval between queries to the dependency syncing job queue.")
+	c.DependencySyncSchedulerConcurrency = c.GetInt("PRECISE_CODE_INTEL_DEPENDENCY_SYNC_SCHEDULER_CONCURRENCY", "1", "The maximum number of dependency syncing jobs that can be processed concurrently.")
 }
 
 func (c *indexingConfig) Validate() error {
 	var errs error
 	errs = errors.Append(errs, c.BaseConfig.Validate())
 	errs = errors.Append(errs, c.AutoIndexEnqueuerConfig.Validate())
+	if c.DependencySyncSchedulerConcurrency < 1 {
+		errs = errors.Append(errs, errors.Newf("invalid value %d for PRECISE_CODE_INTEL_DEPENDENCY_SYNC_SCHEDULER_CONCURRENCY: must be at least 1", c.DependencySyncSchedulerConcurrency))
+	}
 	return errs
 }
@@ -198,6 +198,10 @@ func (s *FakeChangesetSource) ExternalServices() types.ExternalServices {
 
 	return types.ExternalServices{s.Svc}
 }
+
+func (s *FakeChangesetSource) CheckConnection(ctx context.Context) error {
+	return nil
+}
 func (s *FakeChangesetSource) LoadChangeset(ctx context.Context, c *Changeset) error {
 	s.LoadChangesetCalled = true
 
@@ -215,6 +215,66 @@ ON CONFLICT DO NOTHING
 RETURNING 1
 `
 
+// DependencyRepo is a row of the lsif_dependency_repos table, which tracks the package
+// dependencies discovered while processing uploads.
+type DependencyRepo struct {
+	ID          int       `json:"id"`
+	Scheme      string    `json:"scheme"`
+	Name        string    `json:"name"`
+	Version     string    `json:"version"`
+	FirstSeenAt time.Time `json:"firstSeenAt"`
+}
+
+// scanDependencyRepos scans a slice of dependency repos from the return value of
+// `*Store.query`.
+func scanDependencyRepos(rows *sql.Rows, queryErr error) (_ []DependencyRepo, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var dependencyRepos []DependencyRepo
+	for rows.Next() {
+		var dependencyRepo DependencyRepo
+		if err := rows.Scan(
+			&dependencyRepo.ID,
+			&dependencyRepo.Scheme,
+			&dependencyRepo.Name,
+			&dependencyRepo.Version,
+			&dependencyRepo.FirstSeenAt,
+		); err != nil {
+			return nil, err
+		}
+
+		dependencyRepos = append(dependencyRepos, dependencyRepo)
+	}
+
+	return dependencyRepos, nil
+}
+
+// ListDependencyReposCreatedSince returns the dependency repos first seen at or after
+// the given timestamp.
+func (s *Store) ListDependencyReposCreatedSince(ctx context.Context, t time.Time) (dependencyRepos []DependencyRepo, err error) {
+	ctx, endObservation := s.operations.listDependencyReposCreatedSince.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("since", t.Format(time.RFC3339)),
+	}})
+	defer func() {
+		endObservation(1, observation.Args{LogFields: []log.Field{
+			log.Int("numDependencyRepos", len(dependencyRepos)),
+		}})
+	}()
+
+	return scanDependencyRepos(s.Store.Query(ctx, sqlf.Sprintf(listDependencyReposCreatedSinceQuery, t)))
+}
+
+const listDependencyReposCreatedSinceQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/dependency_index.go:ListDependencyReposCreatedSince
+SELECT id, scheme, name, version, first_seen_at
+FROM lsif_dependency_repos
+WHERE first_seen_at >= %s
+ORDER BY first_seen_at, id
+`
+
 func (s *Store) InsertDependencyIndexingJob(ctx context.Context, uploadID int, externalServiceKind string, syncTime time.Time) (id int, err error) {
 	ctx, endObservation := s.operations.insertDependencyIndexingJob.With(ctx, &err, observation.Args{LogFields: []log.Field{
 		log.Int("uploadId", uploadID),
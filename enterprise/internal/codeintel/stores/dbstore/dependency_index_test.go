@@ -5,7 +5,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
+
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
 )
 
 func TestInsertDependencySyncingJob(t *testing.T) {
@@ -38,6 +41,41 @@ func TestInsertDependencySyncingJob(t *testing.T) {
 	}
 }
 
+func TestListDependencyReposCreatedSince(t *testing.T) {
+	db := dbtest.NewDB(t)
+	store := testStore(db)
+
+	for i, dependency := range []precise.Package{
+		{Scheme: "semanticdb", Name: "pkg-a", Version: "1.0.0"},
+		{Scheme: "semanticdb", Name: "pkg-b", Version: "1.0.0"},
+		{Scheme: "semanticdb", Name: "pkg-c", Version: "1.0.0"},
+	} {
+		if _, err := store.InsertCloneableDependencyRepo(context.Background(), dependency); err != nil {
+			t.Fatalf("unexpected error inserting dependency repo: %s", err)
+		}
+
+		// Stagger the first seen timestamps by one day per dependency
+		firstSeenAt := time.Unix(1587396557, 0).UTC().Add(time.Hour * 24 * time.Duration(i))
+		if _, err := db.Exec("UPDATE lsif_dependency_repos SET first_seen_at = $1 WHERE name = $2", firstSeenAt, dependency.Name); err != nil {
+			t.Fatalf("unexpected error updating first seen timestamp: %s", err)
+		}
+	}
+
+	// Only the two most recently seen dependencies fall inside the window
+	dependencyRepos, err := store.ListDependencyReposCreatedSince(context.Background(), time.Unix(1587396557, 0).UTC().Add(time.Hour*24))
+	if err != nil {
+		t.Fatalf("unexpected error listing dependency repos: %s", err)
+	}
+
+	var names []string
+	for _, dependencyRepo := range dependencyRepos {
+		names = append(names, dependencyRepo.Name)
+	}
+	if diff := cmp.Diff([]string{"pkg-b", "pkg-c"}, names); diff != "" {
+		t.Errorf("unexpected dependency repo names (-want +got):\n%s", diff)
+	}
+}
+
 func TestInsertDependencyIndexingJob(t *testing.T) {
 	db := dbtest.NewDB(t)
 	store := testStore(db)
@@ -48,6 +48,7 @@ type operations struct {
 	insertIndex                                 *observation.Operation
 	insertUpload                                *observation.Operation
 	isQueued                                    *observation.Operation
+	listDependencyReposCreatedSince             *observation.Operation
 	markComplete                                *observation.Operation
 	markErrored                                 *observation.Operation
 	markFailed                                  *observation.Operation
@@ -146,6 +147,7 @@ func newOperations(observationContext *observation.Context, metrics *metrics.RED
 		insertIndex:                         op("InsertIndex"),
 		insertUpload:                        op("InsertUpload"),
 		isQueued:                            op("IsQueued"),
+		listDependencyReposCreatedSince:     op("ListDependencyReposCreatedSince"),
 		markComplete:                        op("MarkComplete"),
 		markErrored:                         op("MarkErrored"),
 		markFailed:                          op("MarkFailed"),
@@ -11,10 +11,12 @@ import (
 type Config struct {
 	env.BaseConfig
 
-	Backend      string
-	ManageBucket bool
-	Bucket       string
-	TTL          time.Duration
+	Backend          string
+	ManageBucket     bool
+	Bucket           string
+	TTL              time.Duration
+	UploadMaxRetries int
+	UploadTimeout    time.Duration
 
 	S3Region          string
 	S3Endpoint        string
@@ -32,11 +34,20 @@ func (c *Config) Load() {
 	c.ManageBucket = c.GetBool("PRECISE_CODE_INTEL_UPLOAD_MANAGE_BUCKET", "false", "Whether or not the client should manage the target bucket configuration.")
 	c.Bucket = c.Get("PRECISE_CODE_INTEL_UPLOAD_BUCKET", "lsif-uploads", "The name of the bucket to store LSIF uploads in.")
 	c.TTL = c.GetInterval("PRECISE_CODE_INTEL_UPLOAD_TTL", "168h", "The maximum age of an upload before deletion.")
+	c.UploadMaxRetries = c.GetInt("PRECISE_CODE_INTEL_UPLOAD_MAX_RETRIES", "3", "The maximum number of times a transiently failing upload request is retried.")
+	c.UploadTimeout = c.GetInterval("PRECISE_CODE_INTEL_UPLOAD_TIMEOUT", "10m", "The maximum duration of a single upload request. Zero means no timeout.")
 
 	if c.Backend != "minio" && c.Backend != "s3" && c.Backend != "gcs" {
 		c.AddError(errors.Errorf("invalid backend %q for PRECISE_CODE_INTEL_UPLOAD_BACKEND: must be S3, GCS, or MinIO", c.Backend))
 	}
 
+	if c.UploadMaxRetries < 0 {
+		c.AddError(errors.Errorf("invalid negative value %d for PRECISE_CODE_INTEL_UPLOAD_MAX_RETRIES", c.UploadMaxRetries))
+	}
+	if c.UploadTimeout < 0 {
+		c.AddError(errors.Errorf("invalid negative value %s for PRECISE_CODE_INTEL_UPLOAD_TIMEOUT", c.UploadTimeout))
+	}
+
 	if c.Backend == "minio" || c.Backend == "s3" {
 		c.S3Region = c.Get("PRECISE_CODE_INTEL_UPLOAD_AWS_REGION", "us-east-1", "The target AWS region.")
 		c.S3Endpoint = c.Get("PRECISE_CODE_INTEL_UPLOAD_AWS_ENDPOINT", "http://minio:9000", "The target AWS endpoint.")
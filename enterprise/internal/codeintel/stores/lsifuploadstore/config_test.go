@@ -32,6 +32,12 @@ func TestConfigDefaults(t *testing.T) {
 	if config.S3Region != "us-east-1" {
 		t.Errorf("unexpected value for S3.Region. want=%s have=%s", "us-east-1", config.S3Region)
 	}
+	if config.UploadMaxRetries != 3 {
+		t.Errorf("unexpected value for UploadMaxRetries. want=%d have=%d", 3, config.UploadMaxRetries)
+	}
+	if config.UploadTimeout != 10*time.Minute {
+		t.Errorf("unexpected value for UploadTimeout. want=%v have=%v", 10*time.Minute, config.UploadTimeout)
+	}
 }
 
 func TestConfigS3(t *testing.T) {
@@ -39,6 +45,8 @@ func TestConfigS3(t *testing.T) {
 		"PRECISE_CODE_INTEL_UPLOAD_BACKEND":               "S3",
 		"PRECISE_CODE_INTEL_UPLOAD_BUCKET":                "lsif-uploads",
 		"PRECISE_CODE_INTEL_UPLOAD_TTL":                   "8h",
+		"PRECISE_CODE_INTEL_UPLOAD_MAX_RETRIES":           "5",
+		"PRECISE_CODE_INTEL_UPLOAD_TIMEOUT":               "1m",
 		"PRECISE_CODE_INTEL_UPLOAD_MANAGE_BUCKET":         "true",
 		"PRECISE_CODE_INTEL_UPLOAD_AWS_ACCESS_KEY_ID":     "access-key-id",
 		"PRECISE_CODE_INTEL_UPLOAD_AWS_SECRET_ACCESS_KEY": "secret-access-key",
@@ -68,6 +76,27 @@ func TestConfigS3(t *testing.T) {
 	if config.S3SessionToken != "session-token" {
 		t.Errorf("unexpected value for S3.SessionToken. want=%s have=%s", "session-token", config.S3SessionToken)
 	}
+	if config.UploadMaxRetries != 5 {
+		t.Errorf("unexpected value for UploadMaxRetries. want=%d have=%d", 5, config.UploadMaxRetries)
+	}
+	if config.UploadTimeout != time.Minute {
+		t.Errorf("unexpected value for UploadTimeout. want=%v have=%v", time.Minute, config.UploadTimeout)
+	}
+}
+
+func TestConfigNegativeRetrySettings(t *testing.T) {
+	env := map[string]string{
+		"PRECISE_CODE_INTEL_UPLOAD_MAX_RETRIES": "-1",
+		"PRECISE_CODE_INTEL_UPLOAD_TIMEOUT":     "-1m",
+	}
+
+	config := Config{}
+	config.SetMockGetter(mapGetter(env))
+	config.Load()
+
+	if err := config.Validate(); err == nil {
+		t.Fatal("expected validation error for negative retry settings")
+	}
 }
 
 func TestConfigGCS(t *testing.T) {
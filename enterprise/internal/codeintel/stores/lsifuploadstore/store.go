@@ -9,10 +9,12 @@ import (
 
 func New(ctx context.Context, conf *Config, observationContext *observation.Context) (uploadstore.Store, error) {
 	c := uploadstore.Config{
-		Backend:      conf.Backend,
-		ManageBucket: conf.ManageBucket,
-		Bucket:       conf.Bucket,
-		TTL:          conf.TTL,
+		Backend:       conf.Backend,
+		ManageBucket:  conf.ManageBucket,
+		Bucket:        conf.Bucket,
+		TTL:           conf.TTL,
+		MaxRetries:    conf.UploadMaxRetries,
+		UploadTimeout: conf.UploadTimeout,
 		S3: uploadstore.S3Config{
 			Region:          conf.S3Region,
 			Endpoint:        conf.S3Endpoint,
@@ -112,6 +112,27 @@ func (e *InsightsPingEmitter) GetOrgVisibleInsightCounts(ctx context.Context) (_
 	return results, nil
 }
 
+// GetOrgVisibleInsightCountsByOrg returns the number of org-visible insights
+// per org, keyed by org ID. Orgs without any insights are omitted.
+func (e *InsightsPingEmitter) GetOrgVisibleInsightCountsByOrg(ctx context.Context) (_ map[int32]int, err error) {
+	rows, err := e.insightsDb.QueryContext(ctx, orgVisibleInsightCountsByOrgQuery)
+	if err != nil {
+		return map[int32]int{}, err
+	}
+	defer func() { err = rows.Close() }()
+
+	results := make(map[int32]int)
+	for rows.Next() {
+		var orgID int32
+		var count int
+		if err := rows.Scan(&orgID, &count); err != nil {
+			return map[int32]int{}, err
+		}
+		results[orgID] = count
+	}
+	return results, nil
+}
+
 func getDays(intervalValue int, intervalUnit insightTypes.IntervalUnit) int {
 	switch intervalUnit {
 	case insightTypes.Month:
@@ -192,3 +213,10 @@ JOIN insight_view_grants AS ivg ON iv.id = ivg.insight_view_id
 WHERE ivg.org_id IS NOT NULL
 GROUP BY iv.presentation_type;
 `
+
+const orgVisibleInsightCountsByOrgQuery = `
+SELECT ivg.org_id, COUNT(*) FROM insight_view AS iv
+JOIN insight_view_grants AS ivg ON iv.id = ivg.insight_view_id
+WHERE ivg.org_id IS NOT NULL
+GROUP BY ivg.org_id;
+`
@@ -3,6 +3,8 @@ package pings
 import (
 	"context"
 	"encoding/json"
+	"hash/fnv"
+	"sync"
 	"time"
 
 	"github.com/inconshreveable/log15"
@@ -31,6 +33,12 @@ func NewInsightsPingEmitterJob(ctx context.Context, base dbutil.DB, insights dbu
 type InsightsPingEmitter struct {
 	postgresDb dbutil.DB
 	insightsDb dbutil.DB
+
+	// mu guards lastEmitted, which records a fingerprint of the argument last
+	// saved per ping name. Subsequent cycles whose aggregations are unchanged
+	// skip the save instead of writing a duplicate event every hour.
+	mu          sync.Mutex
+	lastEmitted map[string]uint64
 }
 
 func (e *InsightsPingEmitter) emit(ctx context.Context) error {
@@ -116,10 +124,36 @@ func (e *InsightsPingEmitter) emitOrgVisibleInsightCounts(ctx context.Context) e
 	if err != nil {
 		return errors.Wrap(err, "SaveEvent")
 	}
+
+	// The per-org breakdown is emitted as its own ping so consumers of the
+	// aggregate ping above are unaffected.
+	countsByOrg, err := e.GetOrgVisibleInsightCountsByOrg(ctx)
+	if err != nil {
+		return errors.Wrap(err, "GetOrgVisibleInsightCountsByOrg")
+	}
+
+	marshal, err = json.Marshal(countsByOrg)
+	if err != nil {
+		return errors.Wrap(err, "Marshal")
+	}
+
+	err = e.SaveEvent(ctx, usagestats.InsightsOrgVisibleInsightsByOrgPingName, marshal)
+	if err != nil {
+		return errors.Wrap(err, "SaveEvent")
+	}
 	return nil
 }
 
+// SaveEvent writes the ping to the event log, unless the argument is
+// unchanged from the last saved event of the same name, in which case the
+// cycle is recorded but no duplicate event is written.
 func (e *InsightsPingEmitter) SaveEvent(ctx context.Context, name string, argument json.RawMessage) error {
+	fingerprint := fingerprintArgument(argument)
+	if !e.changedSinceLastEmit(name, fingerprint) {
+		log15.Debug("Skipping unchanged Code Insights ping", "name", name)
+		return nil
+	}
+
 	store := database.EventLogs(e.postgresDb)
 
 	err := store.Insert(ctx, &database.Event{
@@ -133,5 +167,31 @@ func (e *InsightsPingEmitter) SaveEvent(ctx context.Context, name string, argume
 	if err != nil {
 		return err
 	}
+
+	e.recordEmit(name, fingerprint)
 	return nil
 }
+
+func fingerprintArgument(argument json.RawMessage) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write(argument)
+	return h.Sum64()
+}
+
+func (e *InsightsPingEmitter) changedSinceLastEmit(name string, fingerprint uint64) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	prev, ok := e.lastEmitted[name]
+	return !ok || prev != fingerprint
+}
+
+// recordEmit is only called after a successful save so that a failed insert
+// is retried on the next cycle.
+func (e *InsightsPingEmitter) recordEmit(name string, fingerprint uint64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.lastEmitted == nil {
+		e.lastEmitted = make(map[string]uint64)
+	}
+	e.lastEmitted[name] = fingerprint
+}
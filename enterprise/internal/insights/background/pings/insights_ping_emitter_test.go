@@ -0,0 +1,94 @@
+package pings
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	insightsdbtesting "github.com/sourcegraph/sourcegraph/enterprise/internal/insights/dbtesting"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/usagestats"
+)
+
+func TestSaveEventSkipsUnchangedArgument(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+
+	ctx := context.Background()
+	postgres := dbtest.NewDB(t)
+	e := &InsightsPingEmitter{postgresDb: postgres}
+
+	countEvents := func() int {
+		var count int
+		err := postgres.QueryRowContext(ctx, "SELECT COUNT(*) FROM event_logs WHERE name = $1", usagestats.InsightsTotalCountPingName).
+			Scan(&count)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return count
+	}
+
+	// Two consecutive emits with identical data save only one event.
+	argument := json.RawMessage(`{"view_counts": 1}`)
+	for i := 0; i < 2; i++ {
+		if err := e.SaveEvent(ctx, usagestats.InsightsTotalCountPingName, argument); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if count := countEvents(); count != 1 {
+		t.Fatalf("want 1 saved event after identical emits, got %d", count)
+	}
+
+	// A changed argument is saved again.
+	if err := e.SaveEvent(ctx, usagestats.InsightsTotalCountPingName, json.RawMessage(`{"view_counts": 2}`)); err != nil {
+		t.Fatal(err)
+	}
+	if count := countEvents(); count != 2 {
+		t.Fatalf("want 2 saved events after changed emit, got %d", count)
+	}
+}
+
+func TestGetOrgVisibleInsightCountsByOrg(t *testing.T) {
+	timescale, cleanup := insightsdbtesting.TimescaleDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	e := &InsightsPingEmitter{insightsDb: timescale}
+
+	_, err := timescale.Exec(`INSERT INTO insight_view (id, title, description, unique_id)
+									VALUES (1, 'test title', 'test description', 'unique-1'),
+									       (2, 'test title 2', 'test description 2', 'unique-2'),
+									       (3, 'test title 3', 'test description 3', 'unique-3'),
+									       (4, 'test title 4', 'test description 4', 'unique-4')`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Org 1 has two insights, org 2 has one, and one insight is only
+	// user-visible and must not be counted.
+	_, err = timescale.Exec(`INSERT INTO insight_view_grants (insight_view_id, org_id, user_id)
+									VALUES (1, 1, null),
+									       (2, 1, null),
+									       (3, 2, null),
+									       (4, null, 1)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	have, err := e.GetOrgVisibleInsightCountsByOrg(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[int32]int{
+		1: 2,
+		2: 1,
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatal(diff)
+	}
+}
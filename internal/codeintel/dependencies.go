@@ -3,8 +3,10 @@ package codeintel
 import (
 	"context"
 	"database/sql"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/inconshreveable/log15"
 	"github.com/opentracing/opentracing-go"
@@ -19,8 +21,10 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	codeinteldbstore "github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/lockfiles"
 )
@@ -28,10 +32,26 @@ import (
 // DependenciesServices encapsulates the resolution and persistence of dependencies at the repository
 // and package levels.
 type DependenciesService struct {
-	db              database.DB
-	syncer          Syncer
-	lockfileService *lockfiles.Service
-	operations      *dependencyServiceOperations
+	db                database.DB
+	syncer            Syncer
+	lockfileService   LockfileService
+	gitTagMappers     map[string]GitTagMapper
+	operations        *dependencyServiceOperations
+	metrics           *dependencyServiceMetrics
+	perRepoFetchLimit int
+	perRepoSemsMu     sync.Mutex
+	perRepoSems       map[api.RepoName]*semaphore.Weighted
+}
+
+// GitTagMapper maps a package name and version onto the git tag that pins the
+// version in the dependency repo, for ecosystems whose tags don't follow
+// GitTagFromVersion.
+type GitTagMapper func(pkg, version string) string
+
+// LockfileService is the subset of lockfiles.Service used to resolve the
+// dependencies declared by a repository revision.
+type LockfileService interface {
+	ListDependencies(ctx context.Context, repo api.RepoName, rev string) ([]reposource.PackageDependency, error)
 }
 
 type Syncer interface {
@@ -65,16 +85,97 @@ func newDependenciesService(
 	observationContext *observation.Context,
 ) *DependenciesService {
 	return &DependenciesService{
-		db:              db,
-		syncer:          syncer,
-		lockfileService: &lockfiles.Service{GitArchive: gitserver.DefaultClient.Archive},
-		operations:      newDependencyServiceOperations(observationContext),
+		db:                db,
+		syncer:            syncer,
+		lockfileService:   &lockfiles.Service{GitArchive: gitserver.DefaultClient.Archive},
+		gitTagMappers:     map[string]GitTagMapper{},
+		operations:        newDependencyServiceOperations(observationContext),
+		metrics:           newDependencyServiceMetrics(observationContext),
+		perRepoFetchLimit: defaultPerRepoFetchLimit,
+		perRepoSems:       map[api.RepoName]*semaphore.Weighted{},
+	}
+}
+
+// defaultPerRepoFetchLimit bounds how many lockfile archive fetches may run
+// concurrently against a single repo. Archives for different revs of one repo
+// hit the same gitserver shard, so they are serialized by default.
+const defaultPerRepoFetchLimit = 1
+
+// SetPerRepoFetchLimit overrides how many lockfile archive fetches may run
+// concurrently against a single repo. Limits below one are ignored. It must
+// not be called concurrently with Dependencies.
+func (r *DependenciesService) SetPerRepoFetchLimit(limit int) {
+	if limit > 0 {
+		r.perRepoFetchLimit = limit
+	}
+}
+
+// repoFetchSem returns the semaphore bounding concurrent lockfile archive
+// fetches for the given repo, creating it on first use.
+func (r *DependenciesService) repoFetchSem(repo api.RepoName) *semaphore.Weighted {
+	r.perRepoSemsMu.Lock()
+	defer r.perRepoSemsMu.Unlock()
+	sem, ok := r.perRepoSems[repo]
+	if !ok {
+		sem = semaphore.NewWeighted(int64(r.perRepoFetchLimit))
+		r.perRepoSems[repo] = sem
+	}
+	return sem
+}
+
+// RegisterGitTagMapper overrides how versions of the given scheme are mapped
+// onto git tags. Schemes without a registered mapper fall back to the
+// dependency's GitTagFromVersion. It must not be called concurrently with
+// Dependencies.
+func (r *DependenciesService) RegisterGitTagMapper(scheme string, mapper GitTagMapper) {
+	r.gitTagMappers[scheme] = mapper
+}
+
+// gitTagFromVersion resolves the git tag pinning dep, preferring a mapper
+// registered for the dependency's scheme.
+func (r *DependenciesService) gitTagFromVersion(dep reposource.PackageDependency) string {
+	if mapper, ok := r.gitTagMappers[dep.Scheme()]; ok {
+		return mapper(dep.PackageSyntax(), dep.PackageVersion())
 	}
+	return dep.GitTagFromVersion()
 }
 
 // RevSpecSet is a utility type for a set of RevSpecs.
 type RevSpecSet map[api.RevSpec]struct{}
 
+// Union returns a new set containing the revs of both s and other.
+func (s RevSpecSet) Union(other RevSpecSet) RevSpecSet {
+	union := make(RevSpecSet, len(s)+len(other))
+	for rev := range s {
+		union[rev] = struct{}{}
+	}
+	for rev := range other {
+		union[rev] = struct{}{}
+	}
+	return union
+}
+
+// Intersect returns a new set containing the revs present in both s and other.
+func (s RevSpecSet) Intersect(other RevSpecSet) RevSpecSet {
+	intersection := RevSpecSet{}
+	for rev := range s {
+		if _, ok := other[rev]; ok {
+			intersection[rev] = struct{}{}
+		}
+	}
+	return intersection
+}
+
+// Slice returns the revs of the set, sorted.
+func (s RevSpecSet) Slice() []api.RevSpec {
+	revs := make([]api.RevSpec, 0, len(s))
+	for rev := range s {
+		revs = append(revs, rev)
+	}
+	sort.Slice(revs, func(i, j int) bool { return revs[i] < revs[j] })
+	return revs
+}
+
 // Dependencies resolves the (transitive) dependencies for a set of repository and revisions.
 // Both the input repoRevs and the output dependencyRevs are a map from repository names to revspecs.
 func (r *DependenciesService) Dependencies(ctx context.Context, repoRevs map[api.RepoName]RevSpecSet) (dependencyRevs map[api.RepoName]RevSpecSet, err error) {
@@ -102,6 +203,10 @@ func (r *DependenciesService) Dependencies(ctx context.Context, repoRevs map[api
 		}})
 	}()
 
+	defer func(started time.Time) {
+		r.metrics.resolutionDuration.Observe(time.Since(started).Seconds())
+	}(time.Now())
+
 	var mu sync.Mutex
 	dependencyRevs = make(map[api.RepoName]RevSpecSet)
 
@@ -120,11 +225,22 @@ func (r *DependenciesService) Dependencies(ctx context.Context, repoRevs map[api
 				}
 				defer sem.Release(1)
 
+				// Layered under the global semaphore so several revs of one
+				// repo can't monopolize archive fetches on its gitserver
+				// shard.
+				repoSem := r.repoFetchSem(repoName)
+				if err := repoSem.Acquire(ctx, 1); err != nil {
+					return err
+				}
 				deps, err := r.lockfileService.ListDependencies(ctx, repoName, string(rev))
+				repoSem.Release(1)
 				if err != nil {
 					return err
 				}
 
+				r.metrics.lockfilesParsed.Inc()
+				r.metrics.dependenciesDiscovered.Add(float64(len(deps)))
+
 				for _, dep := range deps {
 					if err := sem.Acquire(ctx, 1); err != nil {
 						return err
@@ -136,23 +252,30 @@ func (r *DependenciesService) Dependencies(ctx context.Context, repoRevs map[api
 						defer sem.Release(1)
 
 						if err := depsStore.UpsertDependencyRepo(ctx, dep); err != nil {
+							r.metrics.upsertErrors.Inc()
 							return err
 						}
 
+						// A dependency repo that already exists doesn't need
+						// another sync: Sync is only there to pick up repos
+						// the upsert above just introduced.
 						depName := dep.RepoName()
-						if err := r.syncer.Sync(ctx, []api.RepoName{depName}); err != nil {
-							return err
+						if _, err := r.db.Repos().GetByName(ctx, depName); err != nil {
+							if !errcode.IsNotFound(err) {
+								return err
+							}
+							if err := r.syncer.Sync(ctx, []api.RepoName{depName}); err != nil {
+								r.metrics.syncErrors.Inc()
+								return err
+							}
 						}
 
-						depRev := api.RevSpec(dep.GitTagFromVersion())
+						depRev := api.RevSpec(r.gitTagFromVersion(dep))
 
 						mu.Lock()
 						defer mu.Unlock()
 
-						if _, ok := dependencyRevs[depName]; !ok {
-							dependencyRevs[depName] = RevSpecSet{}
-						}
-						dependencyRevs[depName][depRev] = struct{}{}
+						dependencyRevs[depName] = dependencyRevs[depName].Union(RevSpecSet{depRev: {}})
 
 						return nil
 					})
@@ -0,0 +1,267 @@
+package codeintel
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+type fakeLockfileService struct {
+	deps []reposource.PackageDependency
+
+	mu        sync.Mutex
+	active    int
+	maxActive int
+}
+
+func (s *fakeLockfileService) ListDependencies(ctx context.Context, repo api.RepoName, rev string) ([]reposource.PackageDependency, error) {
+	s.mu.Lock()
+	s.active++
+	if s.active > s.maxActive {
+		s.maxActive = s.active
+	}
+	s.mu.Unlock()
+
+	// Give overlapping calls a chance to be observed.
+	time.Sleep(10 * time.Millisecond)
+
+	s.mu.Lock()
+	s.active--
+	s.mu.Unlock()
+
+	return s.deps, nil
+}
+
+type fakeSyncer struct {
+	mu     sync.Mutex
+	synced []api.RepoName
+}
+
+func (s *fakeSyncer) Sync(ctx context.Context, repos []api.RepoName) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.synced = append(s.synced, repos...)
+	return nil
+}
+
+func TestDependenciesMetrics(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	db := database.NewDB(dbtest.NewDB(t))
+
+	svc := newDependenciesService(db, &fakeSyncer{}, &observation.TestContext)
+	svc.lockfileService = &fakeLockfileService{deps: []reposource.PackageDependency{
+		parseNPMDependency(t, "left-pad@1.3.0"),
+		parseNPMDependency(t, "bar@2.0.0"),
+	}}
+
+	dependencyRevs, err := svc.Dependencies(ctx, map[api.RepoName]RevSpecSet{
+		"github.com/example/repo": {"deadbeef": struct{}{}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dependencyRevs) != 2 {
+		t.Fatalf("want 2 dependency revs, got %d", len(dependencyRevs))
+	}
+
+	if got := testutil.ToFloat64(svc.metrics.lockfilesParsed); got != 1 {
+		t.Errorf("want 1 lockfile parsed, got %f", got)
+	}
+	if got := testutil.ToFloat64(svc.metrics.dependenciesDiscovered); got != 2 {
+		t.Errorf("want 2 dependencies discovered, got %f", got)
+	}
+	if got := testutil.ToFloat64(svc.metrics.upsertErrors); got != 0 {
+		t.Errorf("want 0 upsert errors, got %f", got)
+	}
+	if got := testutil.ToFloat64(svc.metrics.syncErrors); got != 0 {
+		t.Errorf("want 0 sync errors, got %f", got)
+	}
+}
+
+type fakeDependency struct{ name, version string }
+
+func (d fakeDependency) Scheme() string               { return "fake" }
+func (d fakeDependency) PackageSyntax() string        { return d.name }
+func (d fakeDependency) PackageManagerSyntax() string { return d.name + "@" + d.version }
+func (d fakeDependency) PackageVersion() string       { return d.version }
+func (d fakeDependency) RepoName() api.RepoName       { return api.RepoName("fake/" + d.name) }
+func (d fakeDependency) GitTagFromVersion() string    { return "v" + d.version }
+
+func TestDependenciesGitTagMapper(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	db := database.NewDB(dbtest.NewDB(t))
+
+	svc := newDependenciesService(db, &fakeSyncer{}, &observation.TestContext)
+	svc.lockfileService = &fakeLockfileService{deps: []reposource.PackageDependency{
+		fakeDependency{name: "widget", version: "1.2.3"},
+	}}
+	svc.RegisterGitTagMapper("fake", func(pkg, version string) string {
+		return pkg + "/" + version
+	})
+
+	dependencyRevs, err := svc.Dependencies(ctx, map[api.RepoName]RevSpecSet{
+		"github.com/example/repo": {"deadbeef": struct{}{}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	revs, ok := dependencyRevs["fake/widget"]
+	if !ok {
+		t.Fatalf("want a revspec for fake/widget, got %v", dependencyRevs)
+	}
+	if _, ok := revs["widget/1.2.3"]; !ok {
+		t.Fatalf("want revspec widget/1.2.3 from the registered mapper, got %v", revs)
+	}
+}
+
+func TestDependenciesSkipsKnownRepos(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	db := database.NewDB(dbtest.NewDB(t))
+
+	syncer := &fakeSyncer{}
+	svc := newDependenciesService(db, syncer, &observation.TestContext)
+	svc.lockfileService = &fakeLockfileService{deps: []reposource.PackageDependency{
+		fakeDependency{name: "known", version: "1.0.0"},
+		fakeDependency{name: "unknown", version: "1.0.0"},
+	}}
+
+	// The known dependency already has a repo, so only the unknown one should
+	// be scheduled for a sync.
+	if err := db.Repos().Create(ctx, &types.Repo{Name: "fake/known"}); err != nil {
+		t.Fatal(err)
+	}
+
+	dependencyRevs, err := svc.Dependencies(ctx, map[api.RepoName]RevSpecSet{
+		"github.com/example/repo": {"deadbeef": struct{}{}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dependencyRevs) != 2 {
+		t.Fatalf("want revs for both dependencies, got %v", dependencyRevs)
+	}
+
+	if len(syncer.synced) != 1 || syncer.synced[0] != "fake/unknown" {
+		t.Fatalf("want only fake/unknown to be synced, got %v", syncer.synced)
+	}
+}
+
+func TestDependenciesPerRepoFetchLimit(t *testing.T) {
+	ctx := context.Background()
+
+	// No dependencies are discovered, so neither the database nor the syncer
+	// is ever touched.
+	lockfiles := &fakeLockfileService{}
+	svc := newDependenciesService(database.NewDB(nil), &fakeSyncer{}, &observation.TestContext)
+	svc.lockfileService = lockfiles
+
+	_, err := svc.Dependencies(ctx, map[api.RepoName]RevSpecSet{
+		"github.com/example/repo": {"deadbeef": struct{}{}, "cafebabe": struct{}{}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if lockfiles.maxActive != 1 {
+		t.Fatalf("want archive fetches for one repo to be serialized, got %d concurrent", lockfiles.maxActive)
+	}
+}
+
+func parseNPMDependency(t testing.TB, dep string) reposource.PackageDependency {
+	t.Helper()
+
+	d, err := reposource.ParseNPMDependency(dep)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestRevSpecSetUnion(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b RevSpecSet
+		want []api.RevSpec
+	}{
+		{name: "both empty", a: RevSpecSet{}, b: RevSpecSet{}, want: []api.RevSpec{}},
+		{name: "left empty", a: nil, b: RevSpecSet{"v1": {}}, want: []api.RevSpec{"v1"}},
+		{name: "right empty", a: RevSpecSet{"v1": {}}, b: nil, want: []api.RevSpec{"v1"}},
+		{name: "overlapping", a: RevSpecSet{"v1": {}, "v2": {}}, b: RevSpecSet{"v2": {}, "v3": {}}, want: []api.RevSpec{"v1", "v2", "v3"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assertRevSpecs(t, test.a.Union(test.b), test.want)
+		})
+	}
+}
+
+func TestRevSpecSetIntersect(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b RevSpecSet
+		want []api.RevSpec
+	}{
+		{name: "both empty", a: RevSpecSet{}, b: RevSpecSet{}, want: []api.RevSpec{}},
+		{name: "left empty", a: nil, b: RevSpecSet{"v1": {}}, want: []api.RevSpec{}},
+		{name: "disjoint", a: RevSpecSet{"v1": {}}, b: RevSpecSet{"v2": {}}, want: []api.RevSpec{}},
+		{name: "overlapping", a: RevSpecSet{"v1": {}, "v2": {}}, b: RevSpecSet{"v2": {}, "v3": {}}, want: []api.RevSpec{"v2"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assertRevSpecs(t, test.a.Intersect(test.b), test.want)
+		})
+	}
+}
+
+func TestRevSpecSetSlice(t *testing.T) {
+	if got := (RevSpecSet{}).Slice(); len(got) != 0 {
+		t.Fatalf("want empty slice, got %v", got)
+	}
+
+	got := RevSpecSet{"v3": {}, "v1": {}, "v2": {}}.Slice()
+	want := []api.RevSpec{"v1", "v2", "v3"}
+	if len(got) != len(want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("want %v sorted, got %v", want, got)
+		}
+	}
+}
+
+func assertRevSpecs(t *testing.T, got RevSpecSet, want []api.RevSpec) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("want revs %v, got %v", want, got)
+	}
+	for _, rev := range want {
+		if _, ok := got[rev]; !ok {
+			t.Fatalf("want revs %v, got %v", want, got)
+		}
+	}
+}
@@ -3,6 +3,8 @@ package codeintel
 import (
 	"fmt"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/sourcegraph/sourcegraph/internal/metrics"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 )
@@ -31,3 +33,36 @@ func newDependencyServiceOperations(observationContext *observation.Context) *de
 		dependencies: op("Dependencies"),
 	}
 }
+
+type dependencyServiceMetrics struct {
+	lockfilesParsed        prometheus.Counter
+	dependenciesDiscovered prometheus.Counter
+	upsertErrors           prometheus.Counter
+	syncErrors             prometheus.Counter
+	resolutionDuration     prometheus.Histogram
+}
+
+func newDependencyServiceMetrics(observationContext *observation.Context) *dependencyServiceMetrics {
+	counter := func(name, help string) prometheus.Counter {
+		c := prometheus.NewCounter(prometheus.CounterOpts{
+			Name: name,
+			Help: help,
+		})
+		observationContext.Registerer.MustRegister(c)
+		return c
+	}
+
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name: "src_codeintel_dependencies_resolution_duration_seconds",
+		Help: "Total time spent resolving the dependencies of a set of repo revisions.",
+	})
+	observationContext.Registerer.MustRegister(histogram)
+
+	return &dependencyServiceMetrics{
+		lockfilesParsed:        counter("src_codeintel_dependencies_lockfiles_parsed_total", "The number of repo revisions whose lockfiles were parsed."),
+		dependenciesDiscovered: counter("src_codeintel_dependencies_discovered_total", "The number of dependencies discovered in lockfiles."),
+		upsertErrors:           counter("src_codeintel_dependencies_upsert_errors_total", "The number of errors upserting dependency repos."),
+		syncErrors:             counter("src_codeintel_dependencies_sync_errors_total", "The number of errors syncing dependency repos."),
+		resolutionDuration:     histogram,
+	}
+}
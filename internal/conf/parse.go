@@ -30,6 +30,17 @@ func parseConfigData(data string, cfg interface{}) error {
 	return nil
 }
 
+// NormalizeJSONC converts a JSONC document (JSON with comments and trailing
+// commas) into standard JSON. It returns an error if the input has faults the
+// parser can't unambiguously resolve.
+func NormalizeJSONC(raw string) (string, error) {
+	data, err := jsonc.Parse(raw)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
 // ParseConfig parses the raw configuration.
 func ParseConfig(data conftypes.RawUnified) (*Unified, error) {
 	cfg := &Unified{
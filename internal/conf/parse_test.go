@@ -0,0 +1,57 @@
+package conf
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNormalizeJSONC(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		"commented github config": {
+			input: `{
+	// The URL of the code host
+	"url": "https://github.com",
+	"token": "deadbeef",
+	"repositoryQuery": ["affiliated"],
+}`,
+			want: `{"repositoryQuery":["affiliated"],"token":"deadbeef","url":"https://github.com"}`,
+		},
+		"commented gitlab config": {
+			input: `{
+	"url": "https://gitlab.com", // comments are allowed
+	"token": "deadbeef",
+	"projectQuery": ["projects?membership=true"],
+}`,
+			want: `{"projectQuery":["projects?membership=true"],"token":"deadbeef","url":"https://gitlab.com"}`,
+		},
+		"plain json passes through": {
+			input: `{"url":"https://github.com"}`,
+			want:  `{"url":"https://github.com"}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := NormalizeJSONC(test.input)
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !json.Valid([]byte(got)) {
+				t.Fatalf("output is not valid JSON: %s", got)
+			}
+			if got != test.want {
+				t.Fatalf("unexpected output:\nwant: %s\nhave: %s", test.want, got)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package database
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"database/sql"
 	"fmt"
 	"net/url"
@@ -18,6 +19,7 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
@@ -25,6 +27,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
 	"github.com/sourcegraph/sourcegraph/internal/types"
@@ -37,11 +40,36 @@ import (
 var BeforeCreateExternalService func(context.Context, ExternalServiceStore) error
 
 type ExternalServiceStore interface {
+	// AppendSyncJobLog appends a log line to the given sync job, keeping at
+	// most maxSyncJobLogLines lines per job by discarding the oldest ones.
+	AppendSyncJobLog(ctx context.Context, jobID int64, line string) error
+
+	// BackfillHasWebhooks recomputes has_webhooks from the configuration of
+	// every external service, batchSize services at a time, and returns how
+	// many rows had a NULL or stale value corrected. Services created before
+	// has_webhooks was computed on write may still have a NULL value.
+	//
+	// 🚨 SECURITY: The caller must ensure that the actor is a site admin.
+	BackfillHasWebhooks(ctx context.Context, batchSize int) (updated int, err error)
+
 	// Count counts all external services that satisfy the options (ignoring limit and offset).
 	//
 	// 🚨 SECURITY: The caller must ensure that the actor is a site admin or owner of the external service.
 	Count(ctx context.Context, opt ExternalServicesListOptions) (int, error)
 
+	// CountReposByNamespace returns the number of distinct repos added by the
+	// external services owned by the given user or org namespace. Together with
+	// ListByNamespace it lets a pre-delete confirmation show what deleting the
+	// namespace would cascade to.
+	//
+	// 🚨 SECURITY: The caller must ensure that the actor is a site admin or the
+	// owner of the namespace.
+	CountReposByNamespace(ctx context.Context, userID, orgID int32) (int32, error)
+
+	// CountSyncJobsByState returns the number of sync jobs in each state, keyed
+	// by state name (e.g. "queued", "processing", "errored").
+	CountSyncJobsByState(ctx context.Context) (map[string]int, error)
+
 	// Create creates an external service.
 	//
 	// Since this method is used before the configuration server has started (search
@@ -68,28 +96,94 @@ type ExternalServiceStore interface {
 	// DistinctKinds returns the distinct list of external services kinds that are stored in the database.
 	DistinctKinds(ctx context.Context) ([]string, error)
 
+	// EffectiveRateLimit returns the rate limit that applies to the external
+	// service with the given id, expressed in requests per hour, along with its
+	// source: RateLimitSourceConfig when the service config sets an explicit
+	// "rateLimit", or RateLimitSourceDefault when the default for the service's
+	// kind applies.
+	//
+	// 🚨 SECURITY: The caller must ensure that the actor is a site admin or owner of the external service.
+	EffectiveRateLimit(ctx context.Context, id int64) (requestsPerHour float64, source string, err error)
+
+	// ExportConfigs returns the configs of all external services with secret
+	// fields replaced by types.RedactedSecret, for inclusion in support
+	// bundles. It never includes real tokens.
+	//
+	// 🚨 SECURITY: The caller must ensure that the actor is a site admin.
+	ExportConfigs(ctx context.Context) ([]RedactedExternalService, error)
+
+	// FindDuplicates returns groups of external services that appear to point
+	// at the same code host: same kind, same normalized url and same token.
+	// Groups with a single member are omitted. Configs of the returned
+	// services have their secret fields redacted, so no raw tokens appear in
+	// the output.
+	//
+	// 🚨 SECURITY: The caller must ensure that the actor is a site admin.
+	FindDuplicates(ctx context.Context) ([][]*types.ExternalService, error)
+
 	// GetAffiliatedSyncErrors returns the most recent sync failure message for each
 	// external service affiliated with the supplied user. If the latest run did not
 	// have an error, the string will be empty. We fetch external services owned by
-	// the supplied user and if they are a site admin we additionally return site
-	// level external services. We exclude cloud_default repos as they are never
-	// synced.
+	// the supplied user or by an org they are a member of, and if they are a site
+	// admin we additionally return site level external services. We exclude
+	// cloud_default repos as they are never synced.
 	GetAffiliatedSyncErrors(ctx context.Context, u *types.User) (map[int64]string, error)
 
+	// GetAffiliatedSyncErrorDetails is GetAffiliatedSyncErrors with enough
+	// service metadata to render each failure: affiliated services whose most
+	// recent finished sync succeeded are omitted.
+	GetAffiliatedSyncErrorDetails(ctx context.Context, u *types.User) ([]SyncErrorDetail, error)
+
 	// GetByID returns the external service for id.
 	//
 	// 🚨 SECURITY: The caller must ensure that the actor is a site admin or owner of the external service.
 	GetByID(ctx context.Context, id int64) (*types.ExternalService, error)
 
+	// GetByIDs returns the external services for the given ids, in no
+	// particular order. IDs of missing or deleted services are simply absent
+	// from the result.
+	//
+	// 🚨 SECURITY: The caller must ensure that the actor is a site admin or owner of the external services.
+	GetByIDs(ctx context.Context, ids []int64) ([]*types.ExternalService, error)
+
+	// GetCloudDefault returns the cloud-default external service for the given
+	// kind. A not-found error is returned when no service of the kind is
+	// cloud-default, or when more than one is (a misconfiguration).
+	GetCloudDefault(ctx context.Context, kind string) (*types.ExternalService, error)
+
 	// GetLastSyncError returns the error associated with the latest sync of the
 	// supplied external service.
 	//
 	// 🚨 SECURITY: The caller must ensure that the actor is a site admin or owner of the external service
 	GetLastSyncError(ctx context.Context, id int64) (string, error)
 
+	// GetRepoLastSyncError returns the most recent sync failure message among
+	// the external services that own the repo with the given name. An empty
+	// string is returned when none of the owning services have failed.
+	//
+	// 🚨 SECURITY: The caller must ensure that the actor is a site admin or owner of the external service
+	GetRepoLastSyncError(ctx context.Context, repoName api.RepoName) (string, error)
+
+	// GetSyncJobLogs returns the log lines appended to the given sync job, in
+	// the order they were appended.
+	GetSyncJobLogs(ctx context.Context, jobID int64) ([]string, error)
+
 	// GetSyncJobs gets all sync jobs
 	GetSyncJobs(ctx context.Context) ([]*types.ExternalServiceSyncJob, error)
 
+	// GetSyncJobsPaginated returns the page of sync jobs that satisfy the
+	// options, ordered by finished_at descending, along with the total number
+	// of jobs matching the filters (ignoring limit and offset).
+	GetSyncJobsPaginated(ctx context.Context, opt SyncJobsListOptions) ([]*types.ExternalServiceSyncJob, int, error)
+
+	// LastSuccessfulSync returns the finish time of the most recent completed
+	// sync of the supplied external service, as opposed to the most recent
+	// attempt. The zero time is returned if the service never synced
+	// successfully.
+	//
+	// 🚨 SECURITY: The caller must ensure that the actor is a site admin or owner of the external service
+	LastSuccessfulSync(ctx context.Context, id int64) (time.Time, error)
+
 	// List returns external services under given namespace.
 	// If no namespace is given, it returns all external services.
 	//
@@ -98,12 +192,60 @@ type ExternalServiceStore interface {
 	// 	- The opt.NamespaceUserID is same as authenticated user ID (i.e. actor.UID)
 	List(ctx context.Context, opt ExternalServicesListOptions) ([]*types.ExternalService, error)
 
+	// ListByNamespace returns the external services owned by the given user or
+	// org namespace, i.e. the services that would be deleted along with the
+	// namespace. Exactly one of userID and orgID must be non-zero.
+	//
+	// 🚨 SECURITY: The caller must ensure that the actor is a site admin or the
+	// owner of the namespace.
+	ListByNamespace(ctx context.Context, userID, orgID int32) ([]*types.ExternalService, error)
+
+	// ListRepos returns the repos added by the external service with the given
+	// id, ordered by id, with pagination applied via opts.
+	//
+	// 🚨 SECURITY: The caller must ensure that the actor is a site admin or owner of the external service.
+	ListRepos(ctx context.Context, id int64, opts LimitOffset) ([]*types.Repo, error)
+
+	// Pause stops background syncing of the external service with the given id
+	// until Resume is called. The service and its repos are left untouched.
+	//
+	// 🚨 SECURITY: The caller must ensure that the actor is a site admin or owner of the external service.
+	Pause(ctx context.Context, id int64) error
+
+	// ReconcileExternalServiceRepos deletes external_service_repos rows whose
+	// repo or external service was soft-deleted, which the delete triggers
+	// don't always catch. It returns the number of rows removed.
+	//
+	// 🚨 SECURITY: The caller must ensure that the actor is a site admin.
+	ReconcileExternalServiceRepos(ctx context.Context) (removed int, err error)
+
 	// RepoCount returns the number of repos synced by the external service with the
 	// given id.
 	//
 	// 🚨 SECURITY: The caller must ensure that the actor is a site admin or owner of the external service.
 	RepoCount(ctx context.Context, id int64) (int32, error)
 
+	// RequeueStuckSyncJobs resets processing sync jobs whose last heartbeat is
+	// older than the given threshold back to queued, so jobs orphaned by a dead
+	// worker are picked up again. It returns the number of jobs requeued.
+	//
+	// 🚨 SECURITY: The caller must ensure that the actor is a site admin.
+	RequeueStuckSyncJobs(ctx context.Context, olderThan time.Duration) (requeued int, err error)
+
+	// Resume re-enables background syncing of the external service with the
+	// given id after a Pause.
+	//
+	// 🚨 SECURITY: The caller must ensure that the actor is a site admin or owner of the external service.
+	Resume(ctx context.Context, id int64) error
+
+	// SetUnrestricted toggles the unrestricted flag of the external service with
+	// the given id, independent of the "authorization" field in its config. The
+	// flag is recomputed from the config on the next Update.
+	//
+	// 🚨 SECURITY: The caller must ensure that the actor is a site admin. Marking
+	// a service unrestricted makes all of its repos visible to every user.
+	SetUnrestricted(ctx context.Context, id int64, unrestricted bool) error
+
 	// SyncDue returns true if any of the supplied external services are due to sync
 	// now or within given duration from now.
 	SyncDue(ctx context.Context, intIDs []int64, d time.Duration) (bool, error)
@@ -275,6 +417,9 @@ type ExternalServicesListOptions struct {
 	// When true, will only return services that have the cloud_default flag set to
 	// true.
 	OnlyCloudDefault bool
+	// When true, will only return services that have the cloud_default flag set to
+	// false, i.e. the services that are safe to sync.
+	ExcludeCloudDefault bool
 
 	*LimitOffset
 
@@ -322,6 +467,9 @@ func (o ExternalServicesListOptions) sqlConditions() []*sqlf.Query {
 	if o.OnlyCloudDefault {
 		conds = append(conds, sqlf.Sprintf("cloud_default = true"))
 	}
+	if o.ExcludeCloudDefault {
+		conds = append(conds, sqlf.Sprintf("cloud_default = false"))
+	}
 	if o.NoCachedWebhooks {
 		conds = append(conds, sqlf.Sprintf("has_webhooks IS NULL"))
 	}
@@ -348,6 +496,31 @@ func (e *ValidateExternalServiceConfigOptions) IsSiteOwned() bool {
 	return e.NamespaceUserID == 0 && e.NamespaceOrgID == 0
 }
 
+// defaultUserExternalServiceURLs is the allowlist of code-host URLs for
+// user-added and org-added external services when the
+// "externalService.allowedCodeHosts" site config is not set.
+var defaultUserExternalServiceURLs = []string{"https://github.com/", "https://gitlab.com/"}
+
+// allowedUserExternalServiceURLs returns the normalized code-host URLs that
+// user-added and org-added external services are allowed to connect to. The
+// list comes from the "externalService.allowedCodeHosts" site config, falling
+// back to GitHub.com and GitLab.com when unset. Invalid URLs are skipped.
+func allowedUserExternalServiceURLs() []string {
+	hosts := conf.Get().ExternalServiceAllowedCodeHosts
+	if len(hosts) == 0 {
+		hosts = defaultUserExternalServiceURLs
+	}
+	urls := make([]string, 0, len(hosts))
+	for _, h := range hosts {
+		u, err := url.Parse(h)
+		if err != nil {
+			continue
+		}
+		urls = append(urls, extsvc.NormalizeBaseURL(u).String())
+	}
+	return urls
+}
+
 func (e *externalServiceStore) ValidateConfig(ctx context.Context, opt ValidateExternalServiceConfigOptions) (normalized []byte, err error) {
 	ext, ok := ExternalServiceKinds[opt.Kind]
 	if !ok {
@@ -385,16 +558,24 @@ func (e *externalServiceStore) ValidateConfig(ctx context.Context, opt ValidateE
 
 	// For user-added and org-added external services, we need to prevent them from using disallowed fields.
 	if !opt.IsSiteOwned() {
-		// We do not allow users to add external service other than GitHub.com and GitLab.com
+		// We only allow users to add external services for code hosts on the
+		// allowlist, which defaults to GitHub.com and GitLab.com.
 		result := gjson.GetBytes(normalized, "url")
 		baseURL, err := url.Parse(result.String())
 		if err != nil {
 			return nil, errors.Wrap(err, "parse base URL")
 		}
 		normalizedURL := extsvc.NormalizeBaseURL(baseURL).String()
-		if normalizedURL != "https://github.com/" &&
-			normalizedURL != "https://gitlab.com/" {
-			return nil, errors.New("external service only allowed for https://github.com/ and https://gitlab.com/")
+		allowedURLs := allowedUserExternalServiceURLs()
+		allowed := false
+		for _, u := range allowedURLs {
+			if normalizedURL == u {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return nil, errors.Errorf("external service only allowed for %s", strings.Join(allowedURLs, " and "))
 		}
 
 		disallowedFields := []string{"repositoryPathPattern", "nameTransformations", "rateLimit"}
@@ -409,6 +590,8 @@ func (e *externalServiceStore) ValidateConfig(ctx context.Context, opt ValidateE
 		if err := e.validateSingleKindPerNamespace(ctx, opt.ExternalServiceID, opt.Kind, opt.NamespaceUserID, opt.NamespaceOrgID); err != nil {
 			return nil, err
 		}
+	} else if err := validateRepositoryPathPattern(opt.Kind, normalized); err != nil {
+		return nil, err
 	}
 
 	res, err := sc.Validate(gojsonschema.NewBytesLoader(normalized))
@@ -473,6 +656,53 @@ func (e *externalServiceStore) ValidateConfig(ctx context.Context, opt ValidateE
 	return normalized, errors.Append(errs, err)
 }
 
+// repositoryPathPatternPlaceholders lists the placeholders that a kind's
+// "repositoryPathPattern" may reference. Kinds without an entry are not
+// validated.
+var repositoryPathPatternPlaceholders = map[string][]string{
+	extsvc.KindGitHub:          {"host", "nameWithOwner"},
+	extsvc.KindGitLab:          {"host", "pathWithNamespace"},
+	extsvc.KindBitbucketServer: {"host", "projectKey", "repositorySlug"},
+	extsvc.KindBitbucketCloud:  {"host", "nameWithOwner"},
+	extsvc.KindAWSCodeCommit:   {"name"},
+	extsvc.KindGitolite:        {"prefix", "nameWithPrefix"},
+}
+
+var repositoryPathPatternPlaceholderMatcher = lazyregexp.New(`\{([^{}]*)\}`)
+
+// validateRepositoryPathPattern checks that a site-level config's
+// "repositoryPathPattern" only references placeholders known for the kind, so
+// a typo doesn't silently render as a literal in every repo name.
+func validateRepositoryPathPattern(kind string, normalized []byte) error {
+	result := gjson.GetBytes(normalized, "repositoryPathPattern")
+	if !result.Exists() {
+		return nil
+	}
+
+	known, ok := repositoryPathPatternPlaceholders[kind]
+	if !ok {
+		return nil
+	}
+
+	for _, match := range repositoryPathPatternPlaceholderMatcher.FindAllStringSubmatch(result.String(), -1) {
+		found := false
+		for _, placeholder := range known {
+			if match[1] == placeholder {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errors.Errorf(
+				"repositoryPathPattern: unknown placeholder %q, supported placeholders are {%s}",
+				match[0],
+				strings.Join(known, "}, {"),
+			)
+		}
+	}
+	return nil
+}
+
 // Neither our JSON schema library nor the Monaco editor we use supports
 // object dependencies well, so we must validate here that repo items
 // match the uri-reference format when url is set, instead of uri when
@@ -855,6 +1085,7 @@ func (e *externalServiceStore) Upsert(ctx context.Context, svcs ...*types.Extern
 			&svcs[i].CloudDefault,
 			&encryptionKeyID,
 			&dbutil.NullBool{B: svcs[i].HasWebhooks},
+			&svcs[i].Paused,
 		)
 		if err != nil {
 			return err
@@ -959,7 +1190,8 @@ RETURNING
 	unrestricted,
 	cloud_default,
 	encryption_key_id,
-	has_webhooks
+	has_webhooks,
+	paused
 `
 
 // ExternalServiceUpdate contains optional fields to update.
@@ -1176,14 +1408,196 @@ func (e *externalServiceStore) GetByID(ctx context.Context, id int64) (*types.Ex
 	return ess[0], nil
 }
 
+func (e *externalServiceStore) GetByIDs(ctx context.Context, ids []int64) ([]*types.ExternalService, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	return e.List(ctx, ExternalServicesListOptions{IDs: ids})
+}
+
+type cloudDefaultNotFoundError struct {
+	kind string
+}
+
+func (e cloudDefaultNotFoundError) Error() string {
+	return fmt.Sprintf("no single cloud-default external service found for kind %q", e.kind)
+}
+
+func (e cloudDefaultNotFoundError) NotFound() bool {
+	return true
+}
+
+func (e *externalServiceStore) GetCloudDefault(ctx context.Context, kind string) (*types.ExternalService, error) {
+	ess, err := e.List(ctx, ExternalServicesListOptions{
+		Kinds:            []string{kind},
+		OnlyCloudDefault: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(ess) != 1 {
+		return nil, cloudDefaultNotFoundError{kind: kind}
+	}
+	return ess[0], nil
+}
+
+func (e *externalServiceStore) CountSyncJobsByState(ctx context.Context) (map[string]int, error) {
+	q := sqlf.Sprintf(`SELECT state, COUNT(*) FROM external_service_sync_jobs GROUP BY state`)
+
+	rows, err := e.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var state string
+		var count int
+		if err := rows.Scan(&state, &count); err != nil {
+			return nil, errors.Wrap(err, "scanning sync job count row")
+		}
+		counts[state] = count
+	}
+
+	return counts, rows.Err()
+}
+
+// maxSyncJobLogLines bounds the log lines stored per sync job. Appending
+// beyond the bound discards the oldest lines.
+const maxSyncJobLogLines = 100
+
+func (e *externalServiceStore) AppendSyncJobLog(ctx context.Context, jobID int64, line string) error {
+	q := sqlf.Sprintf(`
+INSERT INTO external_service_sync_job_logs (sync_job_id, message)
+VALUES (%s, %s)
+`, jobID, line)
+	if err := e.Exec(ctx, q); err != nil {
+		return err
+	}
+
+	q = sqlf.Sprintf(`
+DELETE FROM external_service_sync_job_logs
+WHERE sync_job_id = %s
+AND id NOT IN (
+	SELECT id FROM external_service_sync_job_logs
+	WHERE sync_job_id = %s
+	ORDER BY id DESC
+	LIMIT %s
+)
+`, jobID, jobID, maxSyncJobLogLines)
+	return e.Exec(ctx, q)
+}
+
+func (e *externalServiceStore) BackfillHasWebhooks(ctx context.Context, batchSize int) (updated int, err error) {
+	var afterID int64
+	for {
+		svcs, err := e.List(ctx, ExternalServicesListOptions{
+			AfterID:     afterID,
+			LimitOffset: &LimitOffset{Limit: batchSize},
+		})
+		if err != nil {
+			return updated, err
+		}
+		if len(svcs) == 0 {
+			return updated, nil
+		}
+
+		for _, svc := range svcs {
+			afterID = svc.ID
+
+			hasWebhooks := false
+			cfg, err := svc.Configuration()
+			if err == nil {
+				hasWebhooks = configurationHasWebhooks(cfg)
+			} else {
+				// Legacy configurations might not be valid JSON; in that case,
+				// they also can't have webhooks, so we'll just log the issue
+				// and move on.
+				log15.Warn("cannot parse external service configuration as JSON", "err", err, "id", svc.ID)
+			}
+			if svc.HasWebhooks != nil && *svc.HasWebhooks == hasWebhooks {
+				continue
+			}
+
+			q := sqlf.Sprintf(
+				"UPDATE external_services SET has_webhooks = %s WHERE id = %s AND deleted_at IS NULL",
+				hasWebhooks, svc.ID,
+			)
+			if err := e.Exec(ctx, q); err != nil {
+				return updated, err
+			}
+			updated++
+		}
+	}
+}
+
+func (e *externalServiceStore) GetSyncJobLogs(ctx context.Context, jobID int64) ([]string, error) {
+	q := sqlf.Sprintf(`
+SELECT message FROM external_service_sync_job_logs
+WHERE sync_job_id = %s
+ORDER BY id ASC
+`, jobID)
+
+	return basestore.ScanStrings(e.Query(ctx, q))
+}
+
 func (e *externalServiceStore) GetSyncJobs(ctx context.Context) ([]*types.ExternalServiceSyncJob, error) {
+	jobs, _, err := e.GetSyncJobsPaginated(ctx, SyncJobsListOptions{})
+	return jobs, err
+}
+
+// SyncJobsListOptions specifies the options for listing external service sync jobs.
+type SyncJobsListOptions struct {
+	// When non-zero, only include sync jobs of the given external service.
+	ExternalServiceID int64
+	// When non-empty, only include sync jobs in the given state.
+	State string
+	// When non-zero, only include sync jobs started or finished after the
+	// given time, ordered by that timestamp ascending so pollers can resume
+	// from the last job they saw.
+	Since time.Time
+
+	*LimitOffset
+}
+
+func (o SyncJobsListOptions) sqlConditions() []*sqlf.Query {
+	conds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if o.ExternalServiceID != 0 {
+		conds = append(conds, sqlf.Sprintf("external_service_id = %s", o.ExternalServiceID))
+	}
+	if o.State != "" {
+		conds = append(conds, sqlf.Sprintf("state = %s", o.State))
+	}
+	if !o.Since.IsZero() {
+		conds = append(conds, sqlf.Sprintf("COALESCE(finished_at, started_at) > %s", o.Since))
+	}
+	return conds
+}
+
+func (e *externalServiceStore) GetSyncJobsPaginated(ctx context.Context, opt SyncJobsListOptions) ([]*types.ExternalServiceSyncJob, int, error) {
+	conds := sqlf.Join(opt.sqlConditions(), ") AND (")
+
+	var total int
+	if err := e.QueryRow(ctx, sqlf.Sprintf("SELECT COUNT(*) FROM external_service_sync_jobs WHERE (%s)", conds)).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	orderBy := sqlf.Sprintf("finished_at DESC")
+	if !opt.Since.IsZero() {
+		orderBy = sqlf.Sprintf("COALESCE(finished_at, started_at) ASC")
+	}
+
 	q := sqlf.Sprintf(`SELECT id, state, failure_message, started_at, finished_at, process_after, num_resets, external_service_id, num_failures
-FROM external_service_sync_jobs ORDER BY started_at desc
-`)
+FROM external_service_sync_jobs
+WHERE (%s)
+ORDER BY %s
+%s`, conds, orderBy, opt.LimitOffset.SQL())
 
 	rows, err := e.Query(ctx, q)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	var jobs []*types.ExternalServiceSyncJob
@@ -1200,15 +1614,120 @@ FROM external_service_sync_jobs ORDER BY started_at desc
 			&dbutil.NullInt64{N: &job.ExternalServiceID},
 			&job.NumFailures,
 		); err != nil {
-			return nil, errors.Wrap(err, "scanning external service job row")
+			return nil, 0, errors.Wrap(err, "scanning external service job row")
 		}
 		jobs = append(jobs, &job)
 	}
-	if rows.Err() != nil {
-		return nil, errors.Wrap(err, "row scanning error")
+	if err := rows.Err(); err != nil {
+		return nil, 0, errors.Wrap(err, "row scanning error")
+	}
+
+	return jobs, total, nil
+}
+
+// Sources of the rate limit returned by EffectiveRateLimit.
+const (
+	// RateLimitSourceConfig indicates the limit was set explicitly in the
+	// external service config via the "rateLimit" field.
+	RateLimitSourceConfig = "config"
+	// RateLimitSourceDefault indicates the limit is the default for the
+	// external service's kind.
+	RateLimitSourceDefault = "default"
+)
+
+func (e *externalServiceStore) EffectiveRateLimit(ctx context.Context, id int64) (float64, string, error) {
+	svc, err := e.GetByID(ctx, id)
+	if err != nil {
+		return 0, "", err
+	}
+
+	rlc, err := extsvc.ExtractRateLimitConfig(svc.Config, svc.Kind, svc.DisplayName)
+	if err != nil {
+		return 0, "", errors.Wrap(err, "extracting rate limit config")
+	}
+
+	source := RateLimitSourceConfig
+	if rlc.IsDefault {
+		source = RateLimitSourceDefault
+	}
+	// rlc.Limit is expressed in requests per second.
+	return float64(rlc.Limit) * 3600, source, nil
+}
+
+// RedactedExternalService describes an external service whose config has had
+// all secret fields replaced by types.RedactedSecret.
+type RedactedExternalService struct {
+	ID          int64
+	Kind        string
+	DisplayName string
+	Config      string
+}
+
+func (e *externalServiceStore) ExportConfigs(ctx context.Context) ([]RedactedExternalService, error) {
+	svcs, err := e.List(ctx, ExternalServicesListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	exported := make([]RedactedExternalService, 0, len(svcs))
+	for _, svc := range svcs {
+		redacted, err := svc.RedactConfigSecrets()
+		if err != nil {
+			return nil, errors.Wrapf(err, "redacting config of external service %d", svc.ID)
+		}
+		exported = append(exported, RedactedExternalService{
+			ID:          svc.ID,
+			Kind:        svc.Kind,
+			DisplayName: svc.DisplayName,
+			Config:      redacted,
+		})
+	}
+	return exported, nil
+}
+
+func (e *externalServiceStore) FindDuplicates(ctx context.Context) ([][]*types.ExternalService, error) {
+	svcs, err := e.List(ctx, ExternalServicesListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	groups := map[string][]*types.ExternalService{}
+	var keys []string
+	for _, svc := range svcs {
+		normalized, err := jsonc.Parse(svc.Config)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing config of external service %d", svc.ID)
+		}
+
+		rawURL := gjson.GetBytes(normalized, "url").String()
+		if baseURL, err := url.Parse(rawURL); err == nil && rawURL != "" {
+			rawURL = extsvc.NormalizeBaseURL(baseURL).String()
+		}
+
+		// Group on a hash of the token, never the token itself.
+		tokenHash := sha256.Sum256([]byte(gjson.GetBytes(normalized, "token").String()))
+		key := fmt.Sprintf("%s\x00%s\x00%x", svc.Kind, rawURL, tokenHash)
+
+		redacted, err := svc.RedactConfigSecrets()
+		if err != nil {
+			return nil, errors.Wrapf(err, "redacting config of external service %d", svc.ID)
+		}
+		svc := svc.Clone()
+		svc.Config = redacted
+
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], svc)
 	}
 
-	return jobs, nil
+	var duplicates [][]*types.ExternalService
+	for _, key := range keys {
+		if group := groups[key]; len(group) > 1 {
+			duplicates = append(duplicates, group)
+		}
+	}
+	return duplicates, nil
 }
 
 func (e *externalServiceStore) GetLastSyncError(ctx context.Context, id int64) (string, error) {
@@ -1224,6 +1743,35 @@ LIMIT 1
 	return lastError, err
 }
 
+func (e *externalServiceStore) LastSuccessfulSync(ctx context.Context, id int64) (time.Time, error) {
+	q := sqlf.Sprintf(`
+SELECT finished_at from external_service_sync_jobs
+WHERE external_service_id = %d
+AND state = 'completed'
+ORDER BY finished_at DESC
+LIMIT 1
+`, id)
+
+	finishedAt, _, err := basestore.ScanFirstTime(e.Query(ctx, q))
+	return finishedAt, err
+}
+
+func (e *externalServiceStore) GetRepoLastSyncError(ctx context.Context, repoName api.RepoName) (string, error) {
+	q := sqlf.Sprintf(`
+SELECT j.failure_message FROM external_service_sync_jobs j
+JOIN external_service_repos esr ON esr.external_service_id = j.external_service_id
+JOIN repo r ON r.id = esr.repo_id
+WHERE r.name = %s
+AND j.state IN ('completed','errored','failed')
+AND j.failure_message IS NOT NULL
+ORDER BY j.finished_at DESC
+LIMIT 1
+`, repoName)
+
+	lastError, _, err := basestore.ScanFirstNullString(e.Query(ctx, q))
+	return lastError, err
+}
+
 func (e *externalServiceStore) GetAffiliatedSyncErrors(ctx context.Context, u *types.User) (map[int64]string, error) {
 	if Mocks.ExternalServices.ListSyncErrors != nil {
 		return Mocks.ExternalServices.ListSyncErrors(ctx)
@@ -1238,11 +1786,13 @@ FROM external_services es
                    ON es.id = essj.external_service_id
                        AND essj.state IN ('completed', 'errored', 'failed')
                        AND essj.finished_at IS NOT NULL
-WHERE ((es.namespace_user_id = %s) OR (%s AND es.namespace_user_id IS NULL))
+WHERE ((es.namespace_user_id = %s)
+    OR (es.namespace_org_id IN (SELECT org_id FROM org_members WHERE user_id = %s))
+    OR (%s AND es.namespace_user_id IS NULL))
   AND es.deleted_at IS NULL
   AND NOT es.cloud_default
 ORDER BY es.id, essj.finished_at DESC
-`, u.ID, u.SiteAdmin)
+`, u.ID, u.ID, u.SiteAdmin)
 
 	rows, err := e.Query(ctx, q)
 	if err != nil {
@@ -1266,6 +1816,65 @@ ORDER BY es.id, essj.finished_at DESC
 	return messages, nil
 }
 
+// SyncErrorDetail describes the most recent sync failure of an external
+// service, along with enough service metadata to render it.
+type SyncErrorDetail struct {
+	ServiceID   int64
+	DisplayName string
+	Kind        string
+	Message     string
+	FailedAt    time.Time
+}
+
+func (e *externalServiceStore) GetAffiliatedSyncErrorDetails(ctx context.Context, u *types.User) ([]SyncErrorDetail, error) {
+	if u == nil {
+		return nil, errors.New("nil user")
+	}
+	q := sqlf.Sprintf(`
+SELECT DISTINCT ON (es.id) es.id, es.display_name, es.kind, essj.failure_message, essj.finished_at
+FROM external_services es
+         LEFT JOIN external_service_sync_jobs essj
+                   ON es.id = essj.external_service_id
+                       AND essj.state IN ('completed', 'errored', 'failed')
+                       AND essj.finished_at IS NOT NULL
+WHERE ((es.namespace_user_id = %s)
+    OR (es.namespace_org_id IN (SELECT org_id FROM org_members WHERE user_id = %s))
+    OR (%s AND es.namespace_user_id IS NULL))
+  AND es.deleted_at IS NULL
+  AND NOT es.cloud_default
+ORDER BY es.id, essj.finished_at DESC
+`, u.ID, u.ID, u.SiteAdmin)
+
+	rows, err := e.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var details []SyncErrorDetail
+
+	for rows.Next() {
+		var d SyncErrorDetail
+		var message sql.NullString
+		var finishedAt sql.NullTime
+		if err := rows.Scan(&d.ServiceID, &d.DisplayName, &d.Kind, &message, &finishedAt); err != nil {
+			return nil, err
+		}
+		// The latest finished run of this service didn't fail.
+		if message.String == "" {
+			continue
+		}
+		d.Message = message.String
+		d.FailedAt = finishedAt.Time
+		details = append(details, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return details, nil
+}
+
 func (e *externalServiceStore) List(ctx context.Context, opt ExternalServicesListOptions) ([]*types.ExternalService, error) {
 	if Mocks.ExternalServices.List != nil {
 		return Mocks.ExternalServices.List(opt)
@@ -1286,7 +1895,7 @@ func (e *externalServiceStore) List(ctx context.Context, opt ExternalServicesLis
 	}
 
 	q := sqlf.Sprintf(`
-		SELECT id, kind, display_name, config, encryption_key_id, created_at, updated_at, deleted_at, last_sync_at, next_sync_at, namespace_user_id, namespace_org_id, unrestricted, cloud_default, has_webhooks
+		SELECT id, kind, display_name, config, encryption_key_id, created_at, updated_at, deleted_at, last_sync_at, next_sync_at, namespace_user_id, namespace_org_id, unrestricted, cloud_default, has_webhooks, paused
 		FROM external_services
 		WHERE (%s)
 		ORDER BY id `+opt.OrderByDirection+`
@@ -1317,7 +1926,7 @@ func (e *externalServiceStore) List(ctx context.Context, opt ExternalServicesLis
 			keyID           string
 			hasWebhooks     sql.NullBool
 		)
-		if err := rows.Scan(&h.ID, &h.Kind, &h.DisplayName, &h.Config, &keyID, &h.CreatedAt, &h.UpdatedAt, &deletedAt, &lastSyncAt, &nextSyncAt, &namespaceUserID, &namespaceOrgID, &h.Unrestricted, &h.CloudDefault, &hasWebhooks); err != nil {
+		if err := rows.Scan(&h.ID, &h.Kind, &h.DisplayName, &h.Config, &keyID, &h.CreatedAt, &h.UpdatedAt, &deletedAt, &lastSyncAt, &nextSyncAt, &namespaceUserID, &namespaceOrgID, &h.Unrestricted, &h.CloudDefault, &hasWebhooks, &h.Paused); err != nil {
 			return nil, err
 		}
 
@@ -1404,6 +2013,85 @@ func (e *externalServiceStore) Count(ctx context.Context, opt ExternalServicesLi
 	return count, nil
 }
 
+func (e *externalServiceStore) CountReposByNamespace(ctx context.Context, userID, orgID int32) (int32, error) {
+	cond, err := namespaceCond(userID, orgID)
+	if err != nil {
+		return 0, err
+	}
+
+	q := sqlf.Sprintf(`
+SELECT COUNT(DISTINCT esr.repo_id)
+FROM external_service_repos esr
+JOIN external_services es ON es.id = esr.external_service_id
+WHERE es.deleted_at IS NULL AND %s
+`, cond)
+
+	var count int32
+	if err := e.QueryRow(ctx, q).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// namespaceCond returns the condition matching external services owned by the
+// given user or org namespace, of which exactly one must be non-zero.
+func namespaceCond(userID, orgID int32) (*sqlf.Query, error) {
+	switch {
+	case userID != 0 && orgID != 0:
+		return nil, errors.New("only one of userID and orgID can be set")
+	case userID != 0:
+		return sqlf.Sprintf("es.namespace_user_id = %s", userID), nil
+	case orgID != 0:
+		return sqlf.Sprintf("es.namespace_org_id = %s", orgID), nil
+	default:
+		return nil, errors.New("one of userID and orgID must be set")
+	}
+}
+
+func (e *externalServiceStore) ListByNamespace(ctx context.Context, userID, orgID int32) ([]*types.ExternalService, error) {
+	if _, err := namespaceCond(userID, orgID); err != nil {
+		return nil, err
+	}
+
+	return e.List(ctx, ExternalServicesListOptions{
+		NamespaceUserID: userID,
+		NamespaceOrgID:  orgID,
+	})
+}
+
+func (e *externalServiceStore) ListRepos(ctx context.Context, id int64, opts LimitOffset) ([]*types.Repo, error) {
+	return ReposWith(e).List(ctx, ReposListOptions{
+		ExternalServiceIDs: []int64{id},
+		OrderBy:            RepoListOrderBy{{Field: RepoListID}},
+		LimitOffset:        &opts,
+	})
+}
+
+func (e *externalServiceStore) Pause(ctx context.Context, id int64) error {
+	return e.setPaused(ctx, id, true)
+}
+
+func (e *externalServiceStore) ReconcileExternalServiceRepos(ctx context.Context) (removed int, err error) {
+	// Hard deletes are handled by the foreign key cascades; what can linger
+	// are join rows whose repo or service was soft-deleted without the delete
+	// triggers firing (e.g. direct UPDATEs during migrations or cleanups).
+	res, err := e.ExecResult(ctx, sqlf.Sprintf(`
+DELETE FROM external_service_repos esr
+WHERE EXISTS (SELECT FROM repo WHERE repo.id = esr.repo_id AND repo.deleted_at IS NOT NULL)
+OR EXISTS (SELECT FROM external_services es WHERE es.id = esr.external_service_id AND es.deleted_at IS NOT NULL)
+`))
+	if err != nil {
+		return 0, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(affected), nil
+}
+
 func (e *externalServiceStore) RepoCount(ctx context.Context, id int64) (int32, error) {
 	q := sqlf.Sprintf("SELECT COUNT(*) FROM external_service_repos WHERE external_service_id = %s", id)
 	var count int32
@@ -1415,6 +2103,74 @@ func (e *externalServiceStore) RepoCount(ctx context.Context, id int64) (int32,
 	return count, nil
 }
 
+func (e *externalServiceStore) RequeueStuckSyncJobs(ctx context.Context, olderThan time.Duration) (requeued int, err error) {
+	// Jobs that never sent a heartbeat fall back to started_at, so a worker
+	// that died right after dequeueing doesn't leave the job stuck forever.
+	res, err := e.ExecResult(ctx, sqlf.Sprintf(`
+UPDATE external_service_sync_jobs
+SET state = 'queued', started_at = NULL, last_heartbeat_at = NULL, num_resets = num_resets + 1
+WHERE state = 'processing'
+AND COALESCE(last_heartbeat_at, started_at) < now() - (%s * interval '1 second')
+`, olderThan.Seconds()))
+	if err != nil {
+		return 0, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(affected), nil
+}
+
+func (e *externalServiceStore) Resume(ctx context.Context, id int64) error {
+	return e.setPaused(ctx, id, false)
+}
+
+// setPaused toggles the paused flag of the external service with the given id.
+// Note that Upsert deliberately does not write the paused column, so a pause
+// taken while a sync is running isn't clobbered by that sync's final upsert.
+func (e *externalServiceStore) setPaused(ctx context.Context, id int64, paused bool) error {
+	res, err := e.ExecResult(ctx, sqlf.Sprintf(`
+UPDATE external_services
+SET paused = %s, updated_at = now()
+WHERE id = %s AND deleted_at IS NULL
+`, paused, id))
+	if err != nil {
+		return err
+	}
+
+	nrows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if nrows == 0 {
+		return externalServiceNotFoundError{id: id}
+	}
+	return nil
+}
+
+func (e *externalServiceStore) SetUnrestricted(ctx context.Context, id int64, unrestricted bool) error {
+	res, err := e.ExecResult(ctx, sqlf.Sprintf(`
+UPDATE external_services
+SET unrestricted = %s, updated_at = now()
+WHERE id = %s AND deleted_at IS NULL
+`, unrestricted, id))
+	if err != nil {
+		return err
+	}
+
+	nrows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if nrows == 0 {
+		return externalServiceNotFoundError{id: id}
+	}
+	return nil
+}
+
 func (e *externalServiceStore) SyncDue(ctx context.Context, intIDs []int64, d time.Duration) (bool, error) {
 	if len(intIDs) == 0 {
 		return false, nil
@@ -1432,6 +2188,7 @@ WHERE EXISTS(
         SELECT
         FROM external_services
         WHERE id %s
+          AND NOT paused
           AND (
                 next_sync_at IS NULL
                 OR next_sync_at <= %s)
@@ -18,6 +18,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
 	"github.com/sourcegraph/sourcegraph/internal/encryption"
@@ -41,6 +42,7 @@ func TestExternalServicesListOptions_sqlConditions(t *testing.T) {
 		afterID              int64
 		wantQuery            string
 		onlyCloudDefault     bool
+		excludeCloudDefault  bool
 		noCachedWebhooks     bool
 		wantArgs             []interface{}
 	}{
@@ -95,6 +97,11 @@ func TestExternalServicesListOptions_sqlConditions(t *testing.T) {
 			onlyCloudDefault: true,
 			wantQuery:        "deleted_at IS NULL AND cloud_default = true",
 		},
+		{
+			name:                "has ExcludeCloudDefault",
+			excludeCloudDefault: true,
+			wantQuery:           "deleted_at IS NULL AND cloud_default = false",
+		},
 		{
 			name:             "has noCachedWebhooks",
 			noCachedWebhooks: true,
@@ -111,6 +118,7 @@ func TestExternalServicesListOptions_sqlConditions(t *testing.T) {
 				Kinds:                test.kinds,
 				AfterID:              test.afterID,
 				OnlyCloudDefault:     test.onlyCloudDefault,
+				ExcludeCloudDefault:  test.excludeCloudDefault,
 				NoCachedWebhooks:     test.noCachedWebhooks,
 			}
 			q := sqlf.Join(opts.sqlConditions(), "AND")
@@ -215,6 +223,32 @@ func TestExternalServicesStore_ValidateConfig(t *testing.T) {
 			namespaceOrgID: 1,
 			wantErr:        `external service only allowed for https://github.com/ and https://gitlab.com/`,
 		},
+		{
+			name:            "allow code hosts from the site config allowlist",
+			kind:            extsvc.KindGitHub,
+			config:          `{"url": "https://github.example.com", "repositoryQuery": ["none"], "token": "abc"}`,
+			namespaceUserID: 1,
+			setup: func(t *testing.T) {
+				conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+					ExternalServiceAllowedCodeHosts: []string{"https://github.example.com"},
+				}})
+				t.Cleanup(func() { conf.Mock(nil) })
+			},
+			wantErr: "<nil>",
+		},
+		{
+			name:            "prevent code hosts not on the site config allowlist",
+			kind:            extsvc.KindGitHub,
+			config:          `{"url": "https://gitlab.example.com", "repositoryQuery": ["none"], "token": "abc"}`,
+			namespaceUserID: 1,
+			setup: func(t *testing.T) {
+				conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+					ExternalServiceAllowedCodeHosts: []string{"https://github.example.com"},
+				}})
+				t.Cleanup(func() { conf.Mock(nil) })
+			},
+			wantErr: `external service only allowed for https://github.example.com/`,
+		},
 		{
 			name:            "gjson handles comments",
 			kind:            extsvc.KindGitHub,
@@ -243,6 +277,18 @@ func TestExternalServicesStore_ValidateConfig(t *testing.T) {
 			namespaceUserID: 1,
 			wantErr:         `field "rateLimit" is not allowed in a user-added external service`,
 		},
+		{
+			name:    "valid repositoryPathPattern placeholders",
+			kind:    extsvc.KindGitHub,
+			config:  `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc", "repositoryPathPattern": "{host}/{nameWithOwner}"}`,
+			wantErr: "<nil>",
+		},
+		{
+			name:    "unknown repositoryPathPattern placeholder",
+			kind:    extsvc.KindGitHub,
+			config:  `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc", "repositoryPathPattern": "github/{foo}"}`,
+			wantErr: `repositoryPathPattern: unknown placeholder "{foo}", supported placeholders are {host}, {nameWithOwner}`,
+		},
 		{
 			name:            "duplicate kinds not allowed for user owned services",
 			kind:            extsvc.KindGitHub,
@@ -854,7 +900,7 @@ VALUES (%d, 1, '')
 	}
 }
 
-func TestExternalServicesStore_Delete(t *testing.T) {
+func TestExternalServicesStore_PauseResume(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
 	}
@@ -866,361 +912,417 @@ func TestExternalServicesStore_Delete(t *testing.T) {
 	confGet := func() *conf.Unified {
 		return &conf.Unified{}
 	}
-	es1 := &types.ExternalService{
+	es := &types.ExternalService{
 		Kind:        extsvc.KindGitHub,
 		DisplayName: "GITHUB #1",
 		Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc"}`,
 	}
-	err := ExternalServices(db).Create(ctx, confGet, es1)
+	err := ExternalServices(db).Create(ctx, confGet, es)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	es2 := &types.ExternalService{
-		Kind:        extsvc.KindGitHub,
-		DisplayName: "GITHUB #2",
-		Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "def"}`,
-	}
-	err = ExternalServices(db).Create(ctx, confGet, es2)
+	// The service was never synced, so it's due.
+	due, err := ExternalServices(db).SyncDue(ctx, []int64{es.ID}, 0)
 	if err != nil {
 		t.Fatal(err)
 	}
+	if !due {
+		t.Fatal("want service to be due to sync before pausing")
+	}
 
-	// Create two repositories to test trigger of soft-deleting external service:
-	//  - ID=1 is expected to be deleted along with deletion of the external service.
-	//  - ID=2 remains untouched because it is not associated with the external service.
-	_, err = db.ExecContext(ctx, `
-INSERT INTO repo (id, name, description, fork)
-VALUES (1, 'github.com/user/repo', '', FALSE);
-INSERT INTO repo (id, name, description, fork)
-VALUES (2, 'github.com/user/repo2', '', FALSE);
-`)
-	if err != nil {
+	if err := ExternalServices(db).Pause(ctx, es.ID); err != nil {
 		t.Fatal(err)
 	}
 
-	// Insert rows to `external_service_repos` table to test the trigger.
-	q := sqlf.Sprintf(`
-INSERT INTO external_service_repos (external_service_id, repo_id, clone_url)
-VALUES (%d, 1, ''), (%d, 2, '')
-`, es1.ID, es2.ID)
-	_, err = db.ExecContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	got, err := ExternalServices(db).GetByID(ctx, es.ID)
 	if err != nil {
 		t.Fatal(err)
 	}
+	if !got.Paused {
+		t.Fatal("want service to be paused")
+	}
 
-	// Delete this external service
-	err = ExternalServices(db).Delete(ctx, es1.ID)
+	due, err = ExternalServices(db).SyncDue(ctx, []int64{es.ID}, 0)
 	if err != nil {
 		t.Fatal(err)
 	}
-
-	// Delete again should get externalServiceNotFoundError
-	err = ExternalServices(db).Delete(ctx, es1.ID)
-	gotErr := fmt.Sprintf("%v", err)
-	wantErr := fmt.Sprintf("external service not found: %v", es1.ID)
-	if gotErr != wantErr {
-		t.Errorf("error: want %q but got %q", wantErr, gotErr)
+	if due {
+		t.Fatal("want paused service not to be due to sync")
 	}
 
-	_, err = ExternalServices(db).GetByID(ctx, es1.ID)
-	if err == nil {
-		t.Fatal("expected an error")
+	if err := ExternalServices(db).Resume(ctx, es.ID); err != nil {
+		t.Fatal(err)
 	}
 
-	// Should only get back the repo with ID=2
-	repos, err := Repos(db).GetByIDs(ctx, 1, 2)
+	got, err = ExternalServices(db).GetByID(ctx, es.ID)
 	if err != nil {
 		t.Fatal(err)
 	}
+	if got.Paused {
+		t.Fatal("want service to be resumed")
+	}
 
-	want := []*types.Repo{
-		{ID: 2, Name: "github.com/user/repo2"},
+	due, err = ExternalServices(db).SyncDue(ctx, []int64{es.ID}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !due {
+		t.Fatal("want resumed service to be due to sync again")
 	}
 
-	repos = types.Repos(repos).With(func(r *types.Repo) {
-		r.CreatedAt = time.Time{}
-		r.Sources = nil
-	})
-	if diff := cmp.Diff(want, repos); diff != "" {
-		t.Fatalf("Repos mismatch (-want +got):\n%s", diff)
+	// Pausing a missing service returns a not found error.
+	err = ExternalServices(db).Pause(ctx, es.ID+1)
+	if !errcode.IsNotFound(err) {
+		t.Fatalf("want not found error, got %v", err)
 	}
 }
 
-func TestExternalServicesStore_GetByID(t *testing.T) {
+func TestExternalServicesStore_BackfillHasWebhooks(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
 	}
 	t.Parallel()
 	db := dbtest.NewDB(t)
-	ctx := context.Background()
+	ctx := actor.WithInternalActor(context.Background())
 
-	// Create a new external service
 	confGet := func() *conf.Unified {
 		return &conf.Unified{}
 	}
-	es := &types.ExternalService{
+	withWebhooks := &types.ExternalService{
 		Kind:        extsvc.KindGitHub,
 		DisplayName: "GITHUB #1",
+		Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc", "webhooks": [{"org": "org", "secret": "super secret"}]}`,
+	}
+	withoutWebhooks := &types.ExternalService{
+		Kind:        extsvc.KindGitHub,
+		DisplayName: "GITHUB #2",
 		Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc"}`,
 	}
-	err := ExternalServices(db).Create(ctx, confGet, es)
-	if err != nil {
+	for _, es := range []*types.ExternalService{withWebhooks, withoutWebhooks} {
+		if err := ExternalServices(db).Create(ctx, confGet, es); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Simulate services created before has_webhooks was computed on write: one
+	// with a NULL value and one with a stale one.
+	if _, err := db.ExecContext(ctx, "UPDATE external_services SET has_webhooks = NULL WHERE id = $1", withWebhooks.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.ExecContext(ctx, "UPDATE external_services SET has_webhooks = TRUE WHERE id = $1", withoutWebhooks.ID); err != nil {
 		t.Fatal(err)
 	}
 
-	// Should be able to get back by its ID
-	_, err = ExternalServices(db).GetByID(ctx, es.ID)
+	// A batch size of 1 exercises the pagination.
+	updated, err := ExternalServices(db).BackfillHasWebhooks(ctx, 1)
 	if err != nil {
 		t.Fatal(err)
 	}
+	if updated != 2 {
+		t.Fatalf("want 2 services updated, got %d", updated)
+	}
 
-	// Delete this external service
-	err = ExternalServices(db).Delete(ctx, es.ID)
+	for _, tc := range []struct {
+		es   *types.ExternalService
+		want bool
+	}{
+		{withWebhooks, true},
+		{withoutWebhooks, false},
+	} {
+		got, err := ExternalServices(db).GetByID(ctx, tc.es.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.HasWebhooks == nil {
+			t.Fatalf("%s: has_webhooks is still unset", tc.es.DisplayName)
+		} else if *got.HasWebhooks != tc.want {
+			t.Errorf("%s: want has_webhooks %v, got %v", tc.es.DisplayName, tc.want, *got.HasWebhooks)
+		}
+	}
+
+	// A second run finds nothing left to correct.
+	updated, err = ExternalServices(db).BackfillHasWebhooks(ctx, 1)
 	if err != nil {
 		t.Fatal(err)
 	}
-
-	// Should now get externalServiceNotFoundError
-	_, err = ExternalServices(db).GetByID(ctx, es.ID)
-	gotErr := fmt.Sprintf("%v", err)
-	wantErr := fmt.Sprintf("external service not found: %v", es.ID)
-	if gotErr != wantErr {
-		t.Errorf("error: want %q but got %q", wantErr, gotErr)
+	if updated != 0 {
+		t.Fatalf("want no services updated on a second run, got %d", updated)
 	}
 }
 
-func TestExternalServicesStore_GetByID_Encrypted(t *testing.T) {
+func TestExternalServicesStore_ListRepos(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
 	}
 	t.Parallel()
 	db := dbtest.NewDB(t)
-	ctx := context.Background()
+	ctx := actor.WithInternalActor(context.Background())
 
 	// Create a new external service
 	confGet := func() *conf.Unified {
 		return &conf.Unified{}
 	}
-	es := &types.ExternalService{
+	es1 := &types.ExternalService{
 		Kind:        extsvc.KindGitHub,
 		DisplayName: "GITHUB #1",
 		Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc"}`,
 	}
-
-	store := ExternalServices(db).WithEncryptionKey(et.TestKey{})
-
-	err := store.Create(ctx, confGet, es)
+	err := ExternalServices(db).Create(ctx, confGet, es1)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	// create a store with a NoopKey to read the raw encrypted value
-	noopStore := ExternalServices(db).WithEncryptionKey(&encryption.NoopKey{})
-	encrypted, err := noopStore.GetByID(ctx, es.ID)
+	// Create three repositories owned by the external service and one that is not.
+	_, err = db.ExecContext(ctx, `
+INSERT INTO repo (id, name, description, fork)
+VALUES
+	(1, 'github.com/user/repo1', '', FALSE),
+	(2, 'github.com/user/repo2', '', FALSE),
+	(3, 'github.com/user/repo3', '', FALSE),
+	(4, 'github.com/user/repo4', '', FALSE);
+`)
 	if err != nil {
 		t.Fatal(err)
 	}
-	// if the TestKey worked, the config should just be a base64 encoded version
-	if encrypted.Config != base64.StdEncoding.EncodeToString([]byte(es.Config)) {
-		t.Fatalf("expected base64 encoded config, got %s", encrypted.Config)
+
+	q := sqlf.Sprintf(`
+INSERT INTO external_service_repos (external_service_id, repo_id, clone_url)
+VALUES (%d, 1, ''), (%d, 2, ''), (%d, 3, '')
+`, es1.ID, es1.ID, es1.ID)
+	_, err = db.ExecContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		t.Fatal(err)
 	}
 
-	// Should be able to get back by its ID
-	_, err = store.GetByID(ctx, es.ID)
+	// Page through the repos two at a time.
+	page1, err := ExternalServices(db).ListRepos(ctx, es1.ID, LimitOffset{Limit: 2})
 	if err != nil {
 		t.Fatal(err)
 	}
+	if diff := cmp.Diff([]api.RepoID{1, 2}, repoIDs(page1)); diff != "" {
+		t.Fatalf("first page mismatch (-want +got):\n%s", diff)
+	}
 
-	// Delete this external service
-	err = store.Delete(ctx, es.ID)
+	page2, err := ExternalServices(db).ListRepos(ctx, es1.ID, LimitOffset{Limit: 2, Offset: 2})
 	if err != nil {
 		t.Fatal(err)
 	}
+	if diff := cmp.Diff([]api.RepoID{3}, repoIDs(page2)); diff != "" {
+		t.Fatalf("second page mismatch (-want +got):\n%s", diff)
+	}
+}
 
-	// Should now get externalServiceNotFoundError
-	_, err = store.GetByID(ctx, es.ID)
-	gotErr := fmt.Sprintf("%v", err)
-	wantErr := fmt.Sprintf("external service not found: %v", es.ID)
-	if gotErr != wantErr {
-		t.Errorf("error: want %q but got %q", wantErr, gotErr)
+func repoIDs(repos []*types.Repo) []api.RepoID {
+	ids := make([]api.RepoID, 0, len(repos))
+	for _, r := range repos {
+		ids = append(ids, r.ID)
 	}
+	return ids
 }
 
-func TestGetAffiliatedSyncErrors(t *testing.T) {
+func TestExternalServicesStore_ListByNamespace(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
 	}
 	t.Parallel()
 	db := dbtest.NewDB(t)
-	ctx := context.Background()
+	ctx := actor.WithInternalActor(context.Background())
 
-	// Create a new external service
+	user, err := Users(db).Create(ctx,
+		NewUser{
+			Email:           "alice@example.com",
+			Username:        "alice",
+			Password:        "password",
+			EmailIsVerified: true,
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Create two external services owned by the user and one site-level one.
 	confGet := func() *conf.Unified {
 		return &conf.Unified{}
 	}
-
-	// Initial user always gets created as an admin
-	admin, err := Users(db).Create(ctx, NewUser{
-		Email:                 "a1@example.com",
-		Username:              "u1",
-		Password:              "p",
-		EmailVerificationCode: "c",
-	})
-	if err != nil {
-		t.Fatal(err)
+	es1 := &types.ExternalService{
+		Kind:            extsvc.KindGitHub,
+		DisplayName:     "GITHUB #1",
+		Config:          `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc"}`,
+		NamespaceUserID: user.ID,
 	}
-	user2, err := Users(db).Create(ctx, NewUser{
-		Email:                 "u2@example.com",
-		Username:              "u2",
-		Password:              "p",
-		EmailVerificationCode: "c",
-	})
-	if err != nil {
-		t.Fatal(err)
+	es2 := &types.ExternalService{
+		Kind:            extsvc.KindGitHub,
+		DisplayName:     "GITHUB #2",
+		Config:          `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "def"}`,
+		NamespaceUserID: user.ID,
 	}
-
-	createService := func(u *types.User, name string) *types.ExternalService {
-		svc := &types.ExternalService{
-			Kind:        extsvc.KindGitHub,
-			DisplayName: name,
-			Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc"}`,
-		}
-		if u != nil {
-			svc.NamespaceUserID = u.ID
-		}
-		err = ExternalServices(db).Create(ctx, confGet, svc)
-		if err != nil {
+	es3 := &types.ExternalService{
+		Kind:        extsvc.KindGitHub,
+		DisplayName: "GITHUB #3",
+		Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "ghi"}`,
+	}
+	for _, es := range []*types.ExternalService{es1, es2, es3} {
+		if err := ExternalServices(db).Create(ctx, confGet, es); err != nil {
 			t.Fatal(err)
 		}
-		return svc
 	}
 
-	countErrors := func(results map[int64]string) int {
-		var errorCount int
-		for _, v := range results {
-			if len(v) > 0 {
-				errorCount++
-			}
-		}
-		return errorCount
+	_, err = db.ExecContext(ctx, `
+INSERT INTO repo (id, name, description, fork)
+VALUES
+	(1, 'github.com/user/repo1', '', FALSE),
+	(2, 'github.com/user/repo2', '', FALSE),
+	(3, 'github.com/user/repo3', '', FALSE),
+	(4, 'github.com/user/repo4', '', FALSE);
+`)
+	if err != nil {
+		t.Fatal(err)
 	}
 
-	siteLevel := createService(nil, "GITHUB #1")
-	adminOwned := createService(admin, "GITHUB #2")
-	userOwned := createService(user2, "GITHUB #3")
+	// Repo 2 is added by both of the user's services and must only be counted
+	// once; repo 4 belongs to the site-level service and not at all.
+	q := sqlf.Sprintf(`
+INSERT INTO external_service_repos (external_service_id, repo_id, clone_url, user_id)
+VALUES (%d, 1, '', %d), (%d, 2, '', %d), (%d, 2, '', %d), (%d, 3, '', %d), (%d, 4, '', NULL)
+`, es1.ID, user.ID, es1.ID, user.ID, es2.ID, user.ID, es2.ID, user.ID, es3.ID)
+	_, err = db.ExecContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		t.Fatal(err)
+	}
 
-	// Listing errors now should return an empty map as none have been added yet
-	results, err := ExternalServices(db).GetAffiliatedSyncErrors(ctx, admin)
+	svcs, err := ExternalServices(db).ListByNamespace(ctx, user.ID, 0)
 	if err != nil {
 		t.Fatal(err)
 	}
-	if len(results) != 2 {
-		t.Fatalf("Expected 2 results, got %d", len(results))
+	gotIDs := make([]int64, 0, len(svcs))
+	for _, svc := range svcs {
+		gotIDs = append(gotIDs, svc.ID)
 	}
-	errorCount := countErrors(results)
-	if errorCount != 0 {
-		t.Fatal("Expected 0 errors")
+	if diff := cmp.Diff([]int64{es2.ID, es1.ID}, gotIDs); diff != "" {
+		t.Fatalf("service IDs mismatch (-want +got):\n%s", diff)
 	}
 
-	// Add two failures for the same service
-	failure1 := "oops"
-	_, err = db.Exec(`
-INSERT INTO external_service_sync_jobs (external_service_id, state, finished_at, failure_message)
-VALUES ($1,'errored', now(), $2)
-`, siteLevel.ID, failure1)
+	count, err := ExternalServices(db).CountReposByNamespace(ctx, user.ID, 0)
 	if err != nil {
 		t.Fatal(err)
 	}
-	failure2 := "oops again"
-	_, err = db.Exec(`
-INSERT INTO external_service_sync_jobs (external_service_id, state, finished_at, failure_message)
-VALUES ($1,'errored', now(), $2)
-`, siteLevel.ID, failure2)
-	if err != nil {
-		t.Fatal(err)
+	if count != 3 {
+		t.Fatalf("want 3 distinct repos, got %d", count)
 	}
 
-	// We should get the latest failure
-	results, err = ExternalServices(db).GetAffiliatedSyncErrors(ctx, admin)
+	// A namespace without services has nothing to report.
+	count, err = ExternalServices(db).CountReposByNamespace(ctx, 0, 1)
 	if err != nil {
 		t.Fatal(err)
 	}
-	if len(results) != 2 {
-		t.Fatalf("Expected 2 results, got %d", len(results))
+	if count != 0 {
+		t.Fatalf("want 0 repos, got %d", count)
 	}
-	errorCount = countErrors(results)
-	if errorCount != 1 {
-		t.Fatal("Expected 1 error")
+
+	// Exactly one of userID and orgID must be set.
+	if _, err := ExternalServices(db).ListByNamespace(ctx, 0, 0); err == nil {
+		t.Fatal("want error for missing namespace")
 	}
-	failure := results[siteLevel.ID]
-	if failure != failure2 {
-		t.Fatalf("Want %q, got %q", failure2, failure)
+	if _, err := ExternalServices(db).CountReposByNamespace(ctx, user.ID, 1); err == nil {
+		t.Fatal("want error for both namespaces")
 	}
+}
 
-	// Adding a second failing service
-	_, err = db.Exec(`
-INSERT INTO external_service_sync_jobs (external_service_id, state, finished_at, failure_message)
-VALUES ($1,'errored', now(), $2)
-`, adminOwned.ID, failure1)
-	if err != nil {
-		t.Fatal(err)
+func TestExternalServicesStore_Delete(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
 	}
+	t.Parallel()
+	db := dbtest.NewDB(t)
+	ctx := actor.WithInternalActor(context.Background())
 
-	results, err = ExternalServices(db).GetAffiliatedSyncErrors(ctx, admin)
+	// Create a new external service
+	confGet := func() *conf.Unified {
+		return &conf.Unified{}
+	}
+	es1 := &types.ExternalService{
+		Kind:        extsvc.KindGitHub,
+		DisplayName: "GITHUB #1",
+		Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc"}`,
+	}
+	err := ExternalServices(db).Create(ctx, confGet, es1)
 	if err != nil {
 		t.Fatal(err)
 	}
-	if len(results) != 2 {
-		t.Fatal("Expected 2 results")
+
+	es2 := &types.ExternalService{
+		Kind:        extsvc.KindGitHub,
+		DisplayName: "GITHUB #2",
+		Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "def"}`,
 	}
-	errorCount = countErrors(results)
-	if errorCount != 2 {
-		t.Fatal("Expected 2 errors")
+	err = ExternalServices(db).Create(ctx, confGet, es2)
+	if err != nil {
+		t.Fatal(err)
 	}
 
-	// User should not see any failures as they don't own any services
-	results, err = ExternalServices(db).GetAffiliatedSyncErrors(ctx, user2)
+	// Create two repositories to test trigger of soft-deleting external service:
+	//  - ID=1 is expected to be deleted along with deletion of the external service.
+	//  - ID=2 remains untouched because it is not associated with the external service.
+	_, err = db.ExecContext(ctx, `
+INSERT INTO repo (id, name, description, fork)
+VALUES (1, 'github.com/user/repo', '', FALSE);
+INSERT INTO repo (id, name, description, fork)
+VALUES (2, 'github.com/user/repo2', '', FALSE);
+`)
 	if err != nil {
 		t.Fatal(err)
 	}
-	if len(results) != 1 {
-		t.Fatal("Expected 1 result")
-	}
-	errorCount = countErrors(results)
-	if errorCount != 0 {
-		t.Fatal("Expected 0 errors")
-	}
 
-	// Add a failure to user service
-	failure3 := "user failure"
-	_, err = db.Exec(`
-INSERT INTO external_service_sync_jobs (external_service_id, state, finished_at, failure_message)
-VALUES ($1,'errored', now(), $2)
-`, userOwned.ID, failure3)
+	// Insert rows to `external_service_repos` table to test the trigger.
+	q := sqlf.Sprintf(`
+INSERT INTO external_service_repos (external_service_id, repo_id, clone_url)
+VALUES (%d, 1, ''), (%d, 2, '')
+`, es1.ID, es2.ID)
+	_, err = db.ExecContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	// We should get the latest failure
-	results, err = ExternalServices(db).GetAffiliatedSyncErrors(ctx, user2)
+	// Delete this external service
+	err = ExternalServices(db).Delete(ctx, es1.ID)
 	if err != nil {
 		t.Fatal(err)
 	}
-	if len(results) != 1 {
-		t.Fatal("Expected 1 result")
+
+	// Delete again should get externalServiceNotFoundError
+	err = ExternalServices(db).Delete(ctx, es1.ID)
+	gotErr := fmt.Sprintf("%v", err)
+	wantErr := fmt.Sprintf("external service not found: %v", es1.ID)
+	if gotErr != wantErr {
+		t.Errorf("error: want %q but got %q", wantErr, gotErr)
 	}
-	errorCount = countErrors(results)
-	if errorCount != 1 {
-		t.Fatal("Expected 1 error")
+
+	_, err = ExternalServices(db).GetByID(ctx, es1.ID)
+	if err == nil {
+		t.Fatal("expected an error")
 	}
-	failure = results[userOwned.ID]
-	if failure != failure3 {
-		t.Fatalf("Want %q, got %q", failure3, failure)
+
+	// Should only get back the repo with ID=2
+	repos, err := Repos(db).GetByIDs(ctx, 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*types.Repo{
+		{ID: 2, Name: "github.com/user/repo2"},
+	}
+
+	repos = types.Repos(repos).With(func(r *types.Repo) {
+		r.CreatedAt = time.Time{}
+		r.Sources = nil
+	})
+	if diff := cmp.Diff(want, repos); diff != "" {
+		t.Fatalf("Repos mismatch (-want +got):\n%s", diff)
 	}
 }
 
-func TestGetLastSyncError(t *testing.T) {
+func TestExternalServicesStore_ExportConfigs(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
 	}
@@ -1228,73 +1330,88 @@ func TestGetLastSyncError(t *testing.T) {
 	db := dbtest.NewDB(t)
 	ctx := context.Background()
 
-	// Create a new external service
 	confGet := func() *conf.Unified {
 		return &conf.Unified{}
 	}
 	es := &types.ExternalService{
 		Kind:        extsvc.KindGitHub,
 		DisplayName: "GITHUB #1",
-		Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc"}`,
+		Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "very-secret-token"}`,
 	}
-	err := ExternalServices(db).Create(ctx, confGet, es)
-	if err != nil {
+	if err := ExternalServices(db).Create(ctx, confGet, es); err != nil {
 		t.Fatal(err)
 	}
 
-	// Should be able to get back by its ID
-	_, err = ExternalServices(db).GetByID(ctx, es.ID)
+	exported, err := ExternalServices(db).ExportConfigs(ctx)
 	if err != nil {
 		t.Fatal(err)
 	}
+	if len(exported) != 1 {
+		t.Fatalf("Want 1 exported config, got %d", len(exported))
+	}
 
-	lastSyncError, err := ExternalServices(db).GetLastSyncError(ctx, es.ID)
-	if err != nil {
-		t.Fatal(err)
+	// The token must be redacted while non-secret fields remain.
+	config := exported[0].Config
+	if strings.Contains(config, "very-secret-token") {
+		t.Fatalf("exported config contains the real token: %s", config)
 	}
-	if lastSyncError != "" {
-		t.Fatalf("Expected empty error, have %q", lastSyncError)
+	if !strings.Contains(config, types.RedactedSecret) {
+		t.Fatalf("exported config does not contain the redacted secret: %s", config)
 	}
+	if !strings.Contains(config, "https://github.com") {
+		t.Fatalf("exported config lost non-secret fields: %s", config)
+	}
+}
 
-	// Could have failure message
-	_, err = db.Exec(`
-INSERT INTO external_service_sync_jobs (external_service_id, state, finished_at)
-VALUES ($1,'errored', now())
-`, es.ID)
-
-	if err != nil {
-		t.Fatal(err)
+func TestExternalServicesStore_GetByIDs(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
 	}
+	t.Parallel()
+	db := dbtest.NewDB(t)
+	ctx := context.Background()
 
-	lastSyncError, err = ExternalServices(db).GetLastSyncError(ctx, es.ID)
-	if err != nil {
-		t.Fatal(err)
+	confGet := func() *conf.Unified {
+		return &conf.Unified{}
 	}
-	if lastSyncError != "" {
-		t.Fatalf("Expected empty error, have %q", lastSyncError)
+	svcs := make([]*types.ExternalService, 0, 3)
+	for i := 0; i < 3; i++ {
+		es := &types.ExternalService{
+			Kind:        extsvc.KindGitHub,
+			DisplayName: fmt.Sprintf("GITHUB #%d", i),
+			Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc"}`,
+		}
+		if err := ExternalServices(db).Create(ctx, confGet, es); err != nil {
+			t.Fatal(err)
+		}
+		svcs = append(svcs, es)
 	}
 
-	// Add sync error
-	expectedError := "oops"
-	_, err = db.Exec(`
-INSERT INTO external_service_sync_jobs (external_service_id, failure_message, state, finished_at)
-VALUES ($1,$2,'errored', now())
-`, es.ID, expectedError)
-
-	if err != nil {
+	if err := ExternalServices(db).Delete(ctx, svcs[1].ID); err != nil {
 		t.Fatal(err)
 	}
 
-	lastSyncError, err = ExternalServices(db).GetLastSyncError(ctx, es.ID)
+	got, err := ExternalServices(db).GetByIDs(ctx, []int64{svcs[0].ID, svcs[1].ID, svcs[2].ID})
 	if err != nil {
 		t.Fatal(err)
 	}
-	if lastSyncError != expectedError {
-		t.Fatalf("Expected %q, have %q", expectedError, lastSyncError)
+
+	gotIDs := make(map[int64]bool, len(got))
+	for _, es := range got {
+		gotIDs[es.ID] = true
+	}
+	if len(got) != 2 || !gotIDs[svcs[0].ID] || !gotIDs[svcs[2].ID] {
+		t.Fatalf("want the 2 live services, got %v", gotIDs)
+	}
+
+	if got, err := ExternalServices(db).GetByIDs(ctx, nil); err != nil {
+		t.Fatal(err)
+	} else if len(got) != 0 {
+		t.Fatalf("want no services for no ids, got %d", len(got))
 	}
 }
 
-func TestExternalServicesStore_List(t *testing.T) {
+func TestExternalServicesStore_FindDuplicates(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
 	}
@@ -1302,179 +1419,124 @@ func TestExternalServicesStore_List(t *testing.T) {
 	db := dbtest.NewDB(t)
 	ctx := context.Background()
 
-	// Create test user
-	user, err := Users(db).Create(ctx, NewUser{
-		Email:           "alice@example.com",
-		Username:        "alice",
-		Password:        "password",
-		EmailIsVerified: true,
-	})
-	if err != nil {
-		t.Fatal(err)
+	confGet := func() *conf.Unified {
+		return &conf.Unified{}
+	}
+	svcs := []*types.ExternalService{
+		{
+			Kind:        extsvc.KindGitHub,
+			DisplayName: "GITHUB #1",
+			Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc"}`,
+		},
+		{
+			// Same url and token as #1, modulo a trailing slash and a comment.
+			Kind:        extsvc.KindGitHub,
+			DisplayName: "GITHUB #2",
+			Config:      `{"url": "https://github.com/", "repositoryQuery": ["none"], "token": "abc"} // same host`,
+		},
+		{
+			// Different token: not a duplicate.
+			Kind:        extsvc.KindGitHub,
+			DisplayName: "GITHUB #3",
+			Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "def"}`,
+		},
+	}
+	for _, es := range svcs {
+		if err := ExternalServices(db).Create(ctx, confGet, es); err != nil {
+			t.Fatal(err)
+		}
 	}
 
-	// Create test org
-	displayName := "Acme Org"
-	org, err := Orgs(db).Create(ctx, "acme", &displayName)
+	duplicates, err := ExternalServices(db).FindDuplicates(ctx)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	// Create new external services
+	if len(duplicates) != 1 {
+		t.Fatalf("want 1 duplicate group, got %d", len(duplicates))
+	}
+	group := duplicates[0]
+	if len(group) != 2 {
+		t.Fatalf("want 2 services in the group, got %d", len(group))
+	}
+	gotIDs := map[int64]bool{group[0].ID: true, group[1].ID: true}
+	if !gotIDs[svcs[0].ID] || !gotIDs[svcs[1].ID] {
+		t.Fatalf("want services %d and %d grouped, got %v", svcs[0].ID, svcs[1].ID, gotIDs)
+	}
+	for _, svc := range group {
+		if strings.Contains(svc.Config, "abc") {
+			t.Fatalf("config of service %d contains a raw token: %s", svc.ID, svc.Config)
+		}
+	}
+}
+
+func TestExternalServicesStore_GetCloudDefault(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	db := dbtest.NewDB(t)
+	ctx := context.Background()
+
 	confGet := func() *conf.Unified {
 		return &conf.Unified{}
 	}
-	ess := []*types.ExternalService{
+	svcs := []*types.ExternalService{
 		{
-			Kind:            extsvc.KindGitHub,
-			DisplayName:     "GITHUB #1",
-			Config:          `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc", "authorization": {}}`,
-			NamespaceUserID: user.ID,
+			Kind:        extsvc.KindGitHub,
+			DisplayName: "GITHUB #1",
+			Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc"}`,
 		},
 		{
 			Kind:        extsvc.KindGitHub,
 			DisplayName: "GITHUB #2",
 			Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "def"}`,
 		},
-		{
-			Kind:           extsvc.KindGitHub,
-			DisplayName:    "GITHUB #3",
-			Config:         `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "def", "authorization": {}}`,
-			NamespaceOrgID: org.ID,
-		},
 	}
-	for _, es := range ess {
-		err := ExternalServices(db).Create(ctx, confGet, es)
-		if err != nil {
+	for _, es := range svcs {
+		if err := ExternalServices(db).Create(ctx, confGet, es); err != nil {
 			t.Fatal(err)
 		}
 	}
 
-	t.Run("list all external services", func(t *testing.T) {
-		got, err := ExternalServices(db).List(ctx, ExternalServicesListOptions{})
-		if err != nil {
+	setCloudDefault := func(id int64) {
+		t.Helper()
+		if _, err := db.ExecContext(ctx, "UPDATE external_services SET cloud_default = TRUE WHERE id = $1", id); err != nil {
 			t.Fatal(err)
 		}
-		sort.Slice(got, func(i, j int) bool { return got[i].ID < got[j].ID })
+	}
 
-		if diff := cmp.Diff(ess, got); diff != "" {
-			t.Fatalf("Mismatch (-want +got):\n%s", diff)
+	t.Run("no cloud-default service", func(t *testing.T) {
+		_, err := ExternalServices(db).GetCloudDefault(ctx, extsvc.KindGitHub)
+		gotErr := fmt.Sprintf("%v", err)
+		wantErr := fmt.Sprintf(`no single cloud-default external service found for kind %q`, extsvc.KindGitHub)
+		if gotErr != wantErr {
+			t.Fatalf("want error %q, got %q", wantErr, gotErr)
 		}
 	})
 
-	t.Run("list all external services in ascending order", func(t *testing.T) {
-		got, err := ExternalServices(db).List(ctx, ExternalServicesListOptions{OrderByDirection: "ASC"})
+	t.Run("one cloud-default service", func(t *testing.T) {
+		setCloudDefault(svcs[0].ID)
+
+		got, err := ExternalServices(db).GetCloudDefault(ctx, extsvc.KindGitHub)
 		if err != nil {
 			t.Fatal(err)
 		}
-		want := []*types.ExternalService(types.ExternalServices(ess).Clone())
-		sort.Slice(want, func(i, j int) bool { return want[i].ID < want[j].ID })
-
-		if diff := cmp.Diff(want, got); diff != "" {
-			t.Fatalf("Mismatch (-want +got):\n%s", diff)
+		if got.ID != svcs[0].ID {
+			t.Fatalf("want service %d, got %d", svcs[0].ID, got.ID)
 		}
 	})
 
-	t.Run("list all external services in descending order", func(t *testing.T) {
-		got, err := ExternalServices(db).List(ctx, ExternalServicesListOptions{})
-		if err != nil {
-			t.Fatal(err)
-		}
-		want := []*types.ExternalService(types.ExternalServices(ess).Clone())
-		sort.Slice(want, func(i, j int) bool { return want[i].ID > want[j].ID })
+	t.Run("two cloud-default services", func(t *testing.T) {
+		setCloudDefault(svcs[1].ID)
 
-		if diff := cmp.Diff(want, got); diff != "" {
-			t.Fatalf("Mismatch (-want +got):\n%s", diff)
+		if _, err := ExternalServices(db).GetCloudDefault(ctx, extsvc.KindGitHub); err == nil {
+			t.Fatal("want error for duplicated cloud-default services")
 		}
 	})
+}
 
-	t.Run("list external services with certain IDs", func(t *testing.T) {
-		got, err := ExternalServices(db).List(ctx, ExternalServicesListOptions{
-			IDs: []int64{ess[1].ID},
-		})
-		if err != nil {
-			t.Fatal(err)
-		}
-		sort.Slice(got, func(i, j int) bool { return got[i].ID < got[j].ID })
-
-		if diff := cmp.Diff(ess[1:2], got); diff != "" {
-			t.Fatalf("Mismatch (-want +got):\n%s", diff)
-		}
-	})
-
-	t.Run("list external services with no namespace", func(t *testing.T) {
-		got, err := ExternalServices(db).List(ctx, ExternalServicesListOptions{
-			NoNamespace: true,
-		})
-		if err != nil {
-			t.Fatal(err)
-		}
-
-		if len(got) != 1 {
-			t.Fatalf("Want 1 external service but got %d", len(ess))
-		} else if diff := cmp.Diff(ess[1], got[0]); diff != "" {
-			t.Fatalf("Mismatch (-want +got):\n%s", diff)
-		}
-	})
-
-	t.Run("list only test user's external services", func(t *testing.T) {
-		got, err := ExternalServices(db).List(ctx, ExternalServicesListOptions{
-			NamespaceUserID: user.ID,
-		})
-		if err != nil {
-			t.Fatal(err)
-		}
-
-		if len(got) != 1 {
-			t.Fatalf("Want 1 external service but got %d", len(ess))
-		} else if diff := cmp.Diff(ess[0], got[0]); diff != "" {
-			t.Fatalf("Mismatch (-want +got):\n%s", diff)
-		}
-	})
-
-	t.Run("list non-exist user's external services", func(t *testing.T) {
-		ess, err := ExternalServices(db).List(ctx, ExternalServicesListOptions{
-			NamespaceUserID: 404,
-		})
-		if err != nil {
-			t.Fatal(err)
-		}
-
-		if len(ess) != 0 {
-			t.Fatalf("Want 0 external service but got %d", len(ess))
-		}
-	})
-
-	t.Run("list only test org's external services", func(t *testing.T) {
-		got, err := ExternalServices(db).List(ctx, ExternalServicesListOptions{
-			NamespaceOrgID: org.ID,
-		})
-		if err != nil {
-			t.Fatal(err)
-		}
-
-		if len(got) != 1 {
-			t.Fatalf("Want 1 external service but got %d", len(ess))
-		} else if diff := cmp.Diff(ess[2], got[0]); diff != "" {
-			t.Fatalf("Mismatch (-want +got):\n%s", diff)
-		}
-	})
-
-	t.Run("list non-existing org external services", func(t *testing.T) {
-		ess, err := ExternalServices(db).List(ctx, ExternalServicesListOptions{
-			NamespaceOrgID: 404,
-		})
-		if err != nil {
-			t.Fatal(err)
-		}
-
-		if len(ess) != 0 {
-			t.Fatalf("Want 0 external service but got %d", len(ess))
-		}
-	})
-}
-
-func TestExternalServicesStore_DistinctKinds(t *testing.T) {
+func TestExternalServicesStore_GetByID(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
 	}
@@ -1482,67 +1544,42 @@ func TestExternalServicesStore_DistinctKinds(t *testing.T) {
 	db := dbtest.NewDB(t)
 	ctx := context.Background()
 
-	t.Run("no external service won't blow up", func(t *testing.T) {
-		kinds, err := ExternalServices(db).DistinctKinds(ctx)
-		if err != nil {
-			t.Fatal(err)
-		}
-		if len(kinds) != 0 {
-			t.Fatalf("Kinds: want 0 but got %d", len(kinds))
-		}
-	})
-
-	// Create new external services in different kinds
+	// Create a new external service
 	confGet := func() *conf.Unified {
 		return &conf.Unified{}
 	}
-	ess := []*types.ExternalService{
-		{
-			Kind:        extsvc.KindGitHub,
-			DisplayName: "GITHUB #1",
-			Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc"}`,
-		},
-		{
-			Kind:        extsvc.KindGitHub,
-			DisplayName: "GITHUB #2",
-			Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "def"}`,
-		},
-		{
-			Kind:        extsvc.KindGitLab,
-			DisplayName: "GITLAB #1",
-			Config:      `{"url": "https://github.com", "projectQuery": ["none"], "token": "abc"}`,
-		},
-		{
-			Kind:        extsvc.KindOther,
-			DisplayName: "OTHER #1",
-			Config:      `{"repos": []}`,
-		},
+	es := &types.ExternalService{
+		Kind:        extsvc.KindGitHub,
+		DisplayName: "GITHUB #1",
+		Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc"}`,
 	}
-	for _, es := range ess {
-		err := ExternalServices(db).Create(ctx, confGet, es)
-		if err != nil {
-			t.Fatal(err)
-		}
+	err := ExternalServices(db).Create(ctx, confGet, es)
+	if err != nil {
+		t.Fatal(err)
 	}
 
-	// Delete the last external service which should be excluded from the result
-	err := ExternalServices(db).Delete(ctx, ess[3].ID)
+	// Should be able to get back by its ID
+	_, err = ExternalServices(db).GetByID(ctx, es.ID)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	kinds, err := ExternalServices(db).DistinctKinds(ctx)
+	// Delete this external service
+	err = ExternalServices(db).Delete(ctx, es.ID)
 	if err != nil {
 		t.Fatal(err)
 	}
-	sort.Strings(kinds)
-	wantKinds := []string{extsvc.KindGitHub, extsvc.KindGitLab}
-	if diff := cmp.Diff(wantKinds, kinds); diff != "" {
-		t.Fatalf("Kinds mismatch (-want +got):\n%s", diff)
+
+	// Should now get externalServiceNotFoundError
+	_, err = ExternalServices(db).GetByID(ctx, es.ID)
+	gotErr := fmt.Sprintf("%v", err)
+	wantErr := fmt.Sprintf("external service not found: %v", es.ID)
+	if gotErr != wantErr {
+		t.Errorf("error: want %q but got %q", wantErr, gotErr)
 	}
 }
 
-func TestExternalServicesStore_Count(t *testing.T) {
+func TestExternalServicesStore_GetByID_Encrypted(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
 	}
@@ -1559,22 +1596,47 @@ func TestExternalServicesStore_Count(t *testing.T) {
 		DisplayName: "GITHUB #1",
 		Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc"}`,
 	}
-	err := ExternalServices(db).Create(ctx, confGet, es)
+
+	store := ExternalServices(db).WithEncryptionKey(et.TestKey{})
+
+	err := store.Create(ctx, confGet, es)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	count, err := ExternalServices(db).Count(ctx, ExternalServicesListOptions{})
+	// create a store with a NoopKey to read the raw encrypted value
+	noopStore := ExternalServices(db).WithEncryptionKey(&encryption.NoopKey{})
+	encrypted, err := noopStore.GetByID(ctx, es.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// if the TestKey worked, the config should just be a base64 encoded version
+	if encrypted.Config != base64.StdEncoding.EncodeToString([]byte(es.Config)) {
+		t.Fatalf("expected base64 encoded config, got %s", encrypted.Config)
+	}
+
+	// Should be able to get back by its ID
+	_, err = store.GetByID(ctx, es.ID)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	if count != 1 {
-		t.Fatalf("Want 1 external service but got %d", count)
+	// Delete this external service
+	err = store.Delete(ctx, es.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Should now get externalServiceNotFoundError
+	_, err = store.GetByID(ctx, es.ID)
+	gotErr := fmt.Sprintf("%v", err)
+	wantErr := fmt.Sprintf("external service not found: %v", es.ID)
+	if gotErr != wantErr {
+		t.Errorf("error: want %q but got %q", wantErr, gotErr)
 	}
 }
 
-func TestExternalServicesStore_Upsert(t *testing.T) {
+func TestGetAffiliatedSyncErrors(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
 	}
@@ -1582,39 +1644,996 @@ func TestExternalServicesStore_Upsert(t *testing.T) {
 	db := dbtest.NewDB(t)
 	ctx := context.Background()
 
-	clock := timeutil.NewFakeClock(time.Now(), 0)
-
-	svcs := typestest.MakeExternalServices()
+	// Create a new external service
+	confGet := func() *conf.Unified {
+		return &conf.Unified{}
+	}
 
-	t.Run("no external services", func(t *testing.T) {
-		if err := ExternalServices(db).Upsert(ctx); err != nil {
-			t.Fatalf("Upsert error: %s", err)
-		}
+	// Initial user always gets created as an admin
+	admin, err := Users(db).Create(ctx, NewUser{
+		Email:                 "a1@example.com",
+		Username:              "u1",
+		Password:              "p",
+		EmailVerificationCode: "c",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	user2, err := Users(db).Create(ctx, NewUser{
+		Email:                 "u2@example.com",
+		Username:              "u2",
+		Password:              "p",
+		EmailVerificationCode: "c",
 	})
+	if err != nil {
+		t.Fatal(err)
+	}
 
-	t.Run("one external service", func(t *testing.T) {
-		tx, err := ExternalServices(db).Transact(ctx)
+	createService := func(u *types.User, name string) *types.ExternalService {
+		svc := &types.ExternalService{
+			Kind:        extsvc.KindGitHub,
+			DisplayName: name,
+			Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc"}`,
+		}
+		if u != nil {
+			svc.NamespaceUserID = u.ID
+		}
+		err = ExternalServices(db).Create(ctx, confGet, svc)
 		if err != nil {
-			t.Fatalf("Transact error: %s", err)
+			t.Fatal(err)
 		}
-		defer func() {
-			err = tx.Done(err)
-			if err != nil {
-				t.Fatalf("Done error: %s", err)
-			}
-		}()
+		return svc
+	}
 
-		svc := svcs[1]
-		if svc.Kind != extsvc.KindGitLab {
-			t.Fatalf("expected external service at [1] to be GitLab; got %s", svc.Kind)
+	countErrors := func(results map[int64]string) int {
+		var errorCount int
+		for _, v := range results {
+			if len(v) > 0 {
+				errorCount++
+			}
 		}
+		return errorCount
+	}
 
-		if err := tx.Upsert(ctx, svc); err != nil {
-			t.Fatalf("upsert error: %v", err)
-		}
-		if *svc.HasWebhooks != false {
-			t.Fatalf("unexpected HasWebhooks: %v", svc.HasWebhooks)
-		}
+	siteLevel := createService(nil, "GITHUB #1")
+	adminOwned := createService(admin, "GITHUB #2")
+	userOwned := createService(user2, "GITHUB #3")
+
+	// Listing errors now should return an empty map as none have been added yet
+	results, err := ExternalServices(db).GetAffiliatedSyncErrors(ctx, admin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	errorCount := countErrors(results)
+	if errorCount != 0 {
+		t.Fatal("Expected 0 errors")
+	}
+
+	// Add two failures for the same service
+	failure1 := "oops"
+	_, err = db.Exec(`
+INSERT INTO external_service_sync_jobs (external_service_id, state, finished_at, failure_message)
+VALUES ($1,'errored', now(), $2)
+`, siteLevel.ID, failure1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	failure2 := "oops again"
+	_, err = db.Exec(`
+INSERT INTO external_service_sync_jobs (external_service_id, state, finished_at, failure_message)
+VALUES ($1,'errored', now(), $2)
+`, siteLevel.ID, failure2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// We should get the latest failure
+	results, err = ExternalServices(db).GetAffiliatedSyncErrors(ctx, admin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	errorCount = countErrors(results)
+	if errorCount != 1 {
+		t.Fatal("Expected 1 error")
+	}
+	failure := results[siteLevel.ID]
+	if failure != failure2 {
+		t.Fatalf("Want %q, got %q", failure2, failure)
+	}
+
+	// Adding a second failing service
+	_, err = db.Exec(`
+INSERT INTO external_service_sync_jobs (external_service_id, state, finished_at, failure_message)
+VALUES ($1,'errored', now(), $2)
+`, adminOwned.ID, failure1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results, err = ExternalServices(db).GetAffiliatedSyncErrors(ctx, admin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Fatal("Expected 2 results")
+	}
+	errorCount = countErrors(results)
+	if errorCount != 2 {
+		t.Fatal("Expected 2 errors")
+	}
+
+	// User should not see any failures as they don't own any services
+	results, err = ExternalServices(db).GetAffiliatedSyncErrors(ctx, user2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 {
+		t.Fatal("Expected 1 result")
+	}
+	errorCount = countErrors(results)
+	if errorCount != 0 {
+		t.Fatal("Expected 0 errors")
+	}
+
+	// Add a failure to user service
+	failure3 := "user failure"
+	_, err = db.Exec(`
+INSERT INTO external_service_sync_jobs (external_service_id, state, finished_at, failure_message)
+VALUES ($1,'errored', now(), $2)
+`, userOwned.ID, failure3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// We should get the latest failure
+	results, err = ExternalServices(db).GetAffiliatedSyncErrors(ctx, user2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 {
+		t.Fatal("Expected 1 result")
+	}
+	errorCount = countErrors(results)
+	if errorCount != 1 {
+		t.Fatal("Expected 1 error")
+	}
+	failure = results[userOwned.ID]
+	if failure != failure3 {
+		t.Fatalf("Want %q, got %q", failure3, failure)
+	}
+}
+
+func TestGetAffiliatedSyncErrors_OrgMembership(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	db := dbtest.NewDB(t)
+	ctx := context.Background()
+
+	confGet := func() *conf.Unified {
+		return &conf.Unified{}
+	}
+
+	// Initial user always gets created as an admin
+	admin, err := Users(db).Create(ctx, NewUser{
+		Email:                 "a1@example.com",
+		Username:              "u1",
+		Password:              "p",
+		EmailVerificationCode: "c",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	member, err := Users(db).Create(ctx, NewUser{
+		Email:                 "u2@example.com",
+		Username:              "u2",
+		Password:              "p",
+		EmailVerificationCode: "c",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	nonMember, err := Users(db).Create(ctx, NewUser{
+		Email:                 "u3@example.com",
+		Username:              "u3",
+		Password:              "p",
+		EmailVerificationCode: "c",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	org, err := Orgs(db).Create(ctx, "acme", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OrgMembers(db).Create(ctx, org.ID, member.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	orgOwned := &types.ExternalService{
+		Kind:           extsvc.KindGitHub,
+		DisplayName:    "GITHUB #1",
+		Config:         `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc"}`,
+		NamespaceOrgID: org.ID,
+	}
+	if err := ExternalServices(db).Create(ctx, confGet, orgOwned); err != nil {
+		t.Fatal(err)
+	}
+
+	failure := "org failure"
+	_, err = db.Exec(`
+INSERT INTO external_service_sync_jobs (external_service_id, state, finished_at, failure_message)
+VALUES ($1,'errored', now(), $2)
+`, orgOwned.ID, failure)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The org member sees the failure of the org's service.
+	results, err := ExternalServices(db).GetAffiliatedSyncErrors(ctx, member)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := results[orgOwned.ID]; got != failure {
+		t.Fatalf("Want %q, got %q", failure, got)
+	}
+
+	// A user outside the org sees nothing.
+	results, err = ExternalServices(db).GetAffiliatedSyncErrors(ctx, nonMember)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 0 {
+		t.Fatalf("Expected no results for a non-member, got %v", results)
+	}
+
+	// Site admins still see services without a user namespace.
+	results, err = ExternalServices(db).GetAffiliatedSyncErrors(ctx, admin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := results[orgOwned.ID]; got != failure {
+		t.Fatalf("Want %q for site admin, got %q", failure, got)
+	}
+}
+
+func TestGetAffiliatedSyncErrorDetails(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	db := dbtest.NewDB(t)
+	ctx := context.Background()
+
+	confGet := func() *conf.Unified {
+		return &conf.Unified{}
+	}
+
+	// Initial user always gets created as an admin
+	admin, err := Users(db).Create(ctx, NewUser{
+		Email:                 "a1@example.com",
+		Username:              "u1",
+		Password:              "p",
+		EmailVerificationCode: "c",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	user2, err := Users(db).Create(ctx, NewUser{
+		Email:                 "u2@example.com",
+		Username:              "u2",
+		Password:              "p",
+		EmailVerificationCode: "c",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	createService := func(u *types.User, name string) *types.ExternalService {
+		svc := &types.ExternalService{
+			Kind:        extsvc.KindGitHub,
+			DisplayName: name,
+			Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc"}`,
+		}
+		if u != nil {
+			svc.NamespaceUserID = u.ID
+		}
+		err = ExternalServices(db).Create(ctx, confGet, svc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return svc
+	}
+
+	addSyncJob := func(svc *types.ExternalService, failure string) {
+		_, err := db.Exec(`
+INSERT INTO external_service_sync_jobs (external_service_id, state, finished_at, failure_message)
+VALUES ($1,'errored', now(), NULLIF($2, ''))
+`, svc.ID, failure)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	siteLevel := createService(nil, "GITHUB #1")
+	adminOwned := createService(admin, "GITHUB #2")
+	userOwned := createService(user2, "GITHUB #3")
+
+	// Nothing failed yet, so there's nothing to render.
+	details, err := ExternalServices(db).GetAffiliatedSyncErrorDetails(ctx, admin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(details) != 0 {
+		t.Fatalf("Expected no details, got %+v", details)
+	}
+
+	// Only the latest failure of a service should be reported, and services
+	// whose latest run succeeded are omitted.
+	addSyncJob(siteLevel, "oops")
+	addSyncJob(siteLevel, "oops again")
+	addSyncJob(adminOwned, "")
+	addSyncJob(userOwned, "user failure")
+
+	details, err = ExternalServices(db).GetAffiliatedSyncErrorDetails(ctx, admin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(details) != 1 {
+		t.Fatalf("Expected 1 detail, got %+v", details)
+	}
+	d := details[0]
+	if d.ServiceID != siteLevel.ID || d.DisplayName != siteLevel.DisplayName || d.Kind != extsvc.KindGitHub {
+		t.Fatalf("Unexpected service metadata: %+v", d)
+	}
+	if d.Message != "oops again" {
+		t.Fatalf("Want %q, got %q", "oops again", d.Message)
+	}
+	if d.FailedAt.IsZero() {
+		t.Fatal("Expected a failure timestamp")
+	}
+
+	// The user only sees the failure of their own service.
+	details, err = ExternalServices(db).GetAffiliatedSyncErrorDetails(ctx, user2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(details) != 1 {
+		t.Fatalf("Expected 1 detail, got %+v", details)
+	}
+	if details[0].ServiceID != userOwned.ID || details[0].Message != "user failure" {
+		t.Fatalf("Unexpected detail: %+v", details[0])
+	}
+}
+
+func TestGetLastSyncError(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	db := dbtest.NewDB(t)
+	ctx := context.Background()
+
+	// Create a new external service
+	confGet := func() *conf.Unified {
+		return &conf.Unified{}
+	}
+	es := &types.ExternalService{
+		Kind:        extsvc.KindGitHub,
+		DisplayName: "GITHUB #1",
+		Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc"}`,
+	}
+	err := ExternalServices(db).Create(ctx, confGet, es)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Should be able to get back by its ID
+	_, err = ExternalServices(db).GetByID(ctx, es.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lastSyncError, err := ExternalServices(db).GetLastSyncError(ctx, es.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lastSyncError != "" {
+		t.Fatalf("Expected empty error, have %q", lastSyncError)
+	}
+
+	// Could have failure message
+	_, err = db.Exec(`
+INSERT INTO external_service_sync_jobs (external_service_id, state, finished_at)
+VALUES ($1,'errored', now())
+`, es.ID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lastSyncError, err = ExternalServices(db).GetLastSyncError(ctx, es.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lastSyncError != "" {
+		t.Fatalf("Expected empty error, have %q", lastSyncError)
+	}
+
+	// Add sync error
+	expectedError := "oops"
+	_, err = db.Exec(`
+INSERT INTO external_service_sync_jobs (external_service_id, failure_message, state, finished_at)
+VALUES ($1,$2,'errored', now())
+`, es.ID, expectedError)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lastSyncError, err = ExternalServices(db).GetLastSyncError(ctx, es.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lastSyncError != expectedError {
+		t.Fatalf("Expected %q, have %q", expectedError, lastSyncError)
+	}
+}
+
+func TestExternalServicesStore_LastSuccessfulSync(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	db := dbtest.NewDB(t)
+	ctx := context.Background()
+
+	confGet := func() *conf.Unified {
+		return &conf.Unified{}
+	}
+	es := &types.ExternalService{
+		Kind:        extsvc.KindGitHub,
+		DisplayName: "GITHUB #1",
+		Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc"}`,
+	}
+	err := ExternalServices(db).Create(ctx, confGet, es)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// No sync jobs yet: zero time.
+	lastSync, err := ExternalServices(db).LastSuccessfulSync(ctx, es.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !lastSync.IsZero() {
+		t.Fatalf("Expected zero time, have %v", lastSync)
+	}
+
+	// A failed sync alone doesn't count as a successful one.
+	_, err = db.Exec(`
+INSERT INTO external_service_sync_jobs (external_service_id, state, finished_at)
+VALUES ($1,'errored', now())
+`, es.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lastSync, err = ExternalServices(db).LastSuccessfulSync(ctx, es.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !lastSync.IsZero() {
+		t.Fatalf("Expected zero time, have %v", lastSync)
+	}
+
+	// A completed sync before the failed one is still the most recent success.
+	completedAt := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	_, err = db.Exec(`
+INSERT INTO external_service_sync_jobs (external_service_id, state, finished_at)
+VALUES ($1,'completed', $2)
+`, es.ID, completedAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lastSync, err = ExternalServices(db).LastSuccessfulSync(ctx, es.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !lastSync.Equal(completedAt) {
+		t.Fatalf("Expected %v, have %v", completedAt, lastSync)
+	}
+}
+
+func TestGetRepoLastSyncError(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	db := dbtest.NewDB(t)
+	ctx := context.Background()
+
+	// Create a new external service
+	confGet := func() *conf.Unified {
+		return &conf.Unified{}
+	}
+	es := &types.ExternalService{
+		Kind:        extsvc.KindGitHub,
+		DisplayName: "GITHUB #1",
+		Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc"}`,
+	}
+	err := ExternalServices(db).Create(ctx, confGet, es)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Create a repo owned by the external service
+	var repoID int
+	err = db.QueryRowContext(ctx, `INSERT INTO repo (name) VALUES ('github.com/org/foo') RETURNING id`).Scan(&repoID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.ExecContext(ctx, `
+INSERT INTO external_service_repos (external_service_id, repo_id, clone_url)
+VALUES ($1, $2, 'cloneurl')
+`, es.ID, repoID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lastSyncError, err := ExternalServices(db).GetRepoLastSyncError(ctx, "github.com/org/foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lastSyncError != "" {
+		t.Fatalf("Expected empty error, have %q", lastSyncError)
+	}
+
+	// Add sync error
+	expectedError := "oops"
+	_, err = db.Exec(`
+INSERT INTO external_service_sync_jobs (external_service_id, failure_message, state, finished_at)
+VALUES ($1,$2,'errored', now())
+`, es.ID, expectedError)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A later successful sync without a failure message doesn't hide the error
+	_, err = db.Exec(`
+INSERT INTO external_service_sync_jobs (external_service_id, state, finished_at)
+VALUES ($1,'completed', now() + interval '1 minute')
+`, es.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lastSyncError, err = ExternalServices(db).GetRepoLastSyncError(ctx, "github.com/org/foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lastSyncError != expectedError {
+		t.Fatalf("Expected %q, have %q", expectedError, lastSyncError)
+	}
+
+	// A repo without an owning external service has no error
+	lastSyncError, err = ExternalServices(db).GetRepoLastSyncError(ctx, "github.com/org/unknown")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lastSyncError != "" {
+		t.Fatalf("Expected empty error, have %q", lastSyncError)
+	}
+}
+
+func TestExternalServicesStore_EffectiveRateLimit(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	db := dbtest.NewDB(t)
+	ctx := context.Background()
+
+	confGet := func() *conf.Unified {
+		return &conf.Unified{}
+	}
+
+	// A service with an explicit rate limit in its config.
+	withLimit := &types.ExternalService{
+		Kind:        extsvc.KindGitHub,
+		DisplayName: "GITHUB #1",
+		Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc", "rateLimit": {"enabled": true, "requestsPerHour": 10000}}`,
+	}
+	if err := ExternalServices(db).Create(ctx, confGet, withLimit); err != nil {
+		t.Fatal(err)
+	}
+
+	// A service relying on the default rate limit for its kind.
+	withDefault := &types.ExternalService{
+		Kind:        extsvc.KindGitLab,
+		DisplayName: "GITLAB #1",
+		Config:      `{"url": "https://gitlab.com", "projectQuery": ["none"], "token": "abc"}`,
+	}
+	if err := ExternalServices(db).Create(ctx, confGet, withDefault); err != nil {
+		t.Fatal(err)
+	}
+
+	rph, source, err := ExternalServices(db).EffectiveRateLimit(ctx, withLimit.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rph != 10000 {
+		t.Errorf("requestsPerHour: want 10000 but got %v", rph)
+	}
+	if source != RateLimitSourceConfig {
+		t.Errorf("source: want %q but got %q", RateLimitSourceConfig, source)
+	}
+
+	rph, source, err = ExternalServices(db).EffectiveRateLimit(ctx, withDefault.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The GitLab default is 10 requests per second.
+	if rph != 36000 {
+		t.Errorf("requestsPerHour: want 36000 but got %v", rph)
+	}
+	if source != RateLimitSourceDefault {
+		t.Errorf("source: want %q but got %q", RateLimitSourceDefault, source)
+	}
+}
+
+func TestExternalServicesStore_List(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	db := dbtest.NewDB(t)
+	ctx := context.Background()
+
+	// Create test user
+	user, err := Users(db).Create(ctx, NewUser{
+		Email:           "alice@example.com",
+		Username:        "alice",
+		Password:        "password",
+		EmailIsVerified: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Create test org
+	displayName := "Acme Org"
+	org, err := Orgs(db).Create(ctx, "acme", &displayName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Create new external services
+	confGet := func() *conf.Unified {
+		return &conf.Unified{}
+	}
+	ess := []*types.ExternalService{
+		{
+			Kind:            extsvc.KindGitHub,
+			DisplayName:     "GITHUB #1",
+			Config:          `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc", "authorization": {}}`,
+			NamespaceUserID: user.ID,
+		},
+		{
+			Kind:        extsvc.KindGitHub,
+			DisplayName: "GITHUB #2",
+			Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "def"}`,
+		},
+		{
+			Kind:           extsvc.KindGitHub,
+			DisplayName:    "GITHUB #3",
+			Config:         `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "def", "authorization": {}}`,
+			NamespaceOrgID: org.ID,
+		},
+	}
+	for _, es := range ess {
+		err := ExternalServices(db).Create(ctx, confGet, es)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("list all external services", func(t *testing.T) {
+		got, err := ExternalServices(db).List(ctx, ExternalServicesListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		sort.Slice(got, func(i, j int) bool { return got[i].ID < got[j].ID })
+
+		if diff := cmp.Diff(ess, got); diff != "" {
+			t.Fatalf("Mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("list all external services in ascending order", func(t *testing.T) {
+		got, err := ExternalServices(db).List(ctx, ExternalServicesListOptions{OrderByDirection: "ASC"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []*types.ExternalService(types.ExternalServices(ess).Clone())
+		sort.Slice(want, func(i, j int) bool { return want[i].ID < want[j].ID })
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("Mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("list all external services in descending order", func(t *testing.T) {
+		got, err := ExternalServices(db).List(ctx, ExternalServicesListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []*types.ExternalService(types.ExternalServices(ess).Clone())
+		sort.Slice(want, func(i, j int) bool { return want[i].ID > want[j].ID })
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("Mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("list external services with certain IDs", func(t *testing.T) {
+		got, err := ExternalServices(db).List(ctx, ExternalServicesListOptions{
+			IDs: []int64{ess[1].ID},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		sort.Slice(got, func(i, j int) bool { return got[i].ID < got[j].ID })
+
+		if diff := cmp.Diff(ess[1:2], got); diff != "" {
+			t.Fatalf("Mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("list external services with no namespace", func(t *testing.T) {
+		got, err := ExternalServices(db).List(ctx, ExternalServicesListOptions{
+			NoNamespace: true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(got) != 1 {
+			t.Fatalf("Want 1 external service but got %d", len(ess))
+		} else if diff := cmp.Diff(ess[1], got[0]); diff != "" {
+			t.Fatalf("Mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("list only test user's external services", func(t *testing.T) {
+		got, err := ExternalServices(db).List(ctx, ExternalServicesListOptions{
+			NamespaceUserID: user.ID,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(got) != 1 {
+			t.Fatalf("Want 1 external service but got %d", len(ess))
+		} else if diff := cmp.Diff(ess[0], got[0]); diff != "" {
+			t.Fatalf("Mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("list non-exist user's external services", func(t *testing.T) {
+		ess, err := ExternalServices(db).List(ctx, ExternalServicesListOptions{
+			NamespaceUserID: 404,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(ess) != 0 {
+			t.Fatalf("Want 0 external service but got %d", len(ess))
+		}
+	})
+
+	t.Run("list only test org's external services", func(t *testing.T) {
+		got, err := ExternalServices(db).List(ctx, ExternalServicesListOptions{
+			NamespaceOrgID: org.ID,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(got) != 1 {
+			t.Fatalf("Want 1 external service but got %d", len(ess))
+		} else if diff := cmp.Diff(ess[2], got[0]); diff != "" {
+			t.Fatalf("Mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("list non-existing org external services", func(t *testing.T) {
+		ess, err := ExternalServices(db).List(ctx, ExternalServicesListOptions{
+			NamespaceOrgID: 404,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(ess) != 0 {
+			t.Fatalf("Want 0 external service but got %d", len(ess))
+		}
+	})
+
+	t.Run("exclude cloud-default external services", func(t *testing.T) {
+		if _, err := db.ExecContext(ctx, "UPDATE external_services SET cloud_default = TRUE WHERE id = $1", ess[1].ID); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := ExternalServices(db).List(ctx, ExternalServicesListOptions{
+			ExcludeCloudDefault: true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(got) != len(ess)-1 {
+			t.Fatalf("Want %d external services but got %d", len(ess)-1, len(got))
+		}
+		for _, es := range got {
+			if es.ID == ess[1].ID {
+				t.Fatalf("Want cloud-default service %d excluded, got %v", ess[1].ID, got)
+			}
+		}
+	})
+}
+
+func TestExternalServicesStore_DistinctKinds(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	db := dbtest.NewDB(t)
+	ctx := context.Background()
+
+	t.Run("no external service won't blow up", func(t *testing.T) {
+		kinds, err := ExternalServices(db).DistinctKinds(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(kinds) != 0 {
+			t.Fatalf("Kinds: want 0 but got %d", len(kinds))
+		}
+	})
+
+	// Create new external services in different kinds
+	confGet := func() *conf.Unified {
+		return &conf.Unified{}
+	}
+	ess := []*types.ExternalService{
+		{
+			Kind:        extsvc.KindGitHub,
+			DisplayName: "GITHUB #1",
+			Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc"}`,
+		},
+		{
+			Kind:        extsvc.KindGitHub,
+			DisplayName: "GITHUB #2",
+			Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "def"}`,
+		},
+		{
+			Kind:        extsvc.KindGitLab,
+			DisplayName: "GITLAB #1",
+			Config:      `{"url": "https://github.com", "projectQuery": ["none"], "token": "abc"}`,
+		},
+		{
+			Kind:        extsvc.KindOther,
+			DisplayName: "OTHER #1",
+			Config:      `{"repos": []}`,
+		},
+	}
+	for _, es := range ess {
+		err := ExternalServices(db).Create(ctx, confGet, es)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Delete the last external service which should be excluded from the result
+	err := ExternalServices(db).Delete(ctx, ess[3].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kinds, err := ExternalServices(db).DistinctKinds(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(kinds)
+	wantKinds := []string{extsvc.KindGitHub, extsvc.KindGitLab}
+	if diff := cmp.Diff(wantKinds, kinds); diff != "" {
+		t.Fatalf("Kinds mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestExternalServicesStore_Count(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	db := dbtest.NewDB(t)
+	ctx := context.Background()
+
+	// Create a new external service
+	confGet := func() *conf.Unified {
+		return &conf.Unified{}
+	}
+	es := &types.ExternalService{
+		Kind:        extsvc.KindGitHub,
+		DisplayName: "GITHUB #1",
+		Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc"}`,
+	}
+	err := ExternalServices(db).Create(ctx, confGet, es)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count, err := ExternalServices(db).Count(ctx, ExternalServicesListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 1 {
+		t.Fatalf("Want 1 external service but got %d", count)
+	}
+}
+
+func TestExternalServicesStore_Upsert(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	db := dbtest.NewDB(t)
+	ctx := context.Background()
+
+	clock := timeutil.NewFakeClock(time.Now(), 0)
+
+	svcs := typestest.MakeExternalServices()
+
+	t.Run("no external services", func(t *testing.T) {
+		if err := ExternalServices(db).Upsert(ctx); err != nil {
+			t.Fatalf("Upsert error: %s", err)
+		}
+	})
+
+	t.Run("one external service", func(t *testing.T) {
+		tx, err := ExternalServices(db).Transact(ctx)
+		if err != nil {
+			t.Fatalf("Transact error: %s", err)
+		}
+		defer func() {
+			err = tx.Done(err)
+			if err != nil {
+				t.Fatalf("Done error: %s", err)
+			}
+		}()
+
+		svc := svcs[1]
+		if svc.Kind != extsvc.KindGitLab {
+			t.Fatalf("expected external service at [1] to be GitLab; got %s", svc.Kind)
+		}
+
+		if err := tx.Upsert(ctx, svc); err != nil {
+			t.Fatalf("upsert error: %v", err)
+		}
+		if *svc.HasWebhooks != false {
+			t.Fatalf("unexpected HasWebhooks: %v", svc.HasWebhooks)
+		}
 
 		// Add webhooks to the config and upsert.
 		svc.Config = `{"webhooks":[{"secret": "secret"}],` + svc.Config[1:]
@@ -1710,115 +2729,360 @@ func TestExternalServicesStore_Upsert(t *testing.T) {
 			}
 		}
 
-		have, err = tx.List(ctx, ExternalServicesListOptions{})
+		have, err = tx.List(ctx, ExternalServicesListOptions{})
+		if err != nil {
+			t.Errorf("List error: %s", err)
+		}
+
+		sort.Sort(types.ExternalServices(have))
+
+		if diff := cmp.Diff(have, []*types.ExternalService(nil), cmpopts.EquateEmpty()); diff != "" {
+			t.Errorf("List:\n%s", diff)
+		}
+	})
+
+	t.Run("with encryption key", func(t *testing.T) {
+		tx, err := ExternalServices(db).WithEncryptionKey(et.TestKey{}).Transact(ctx)
+		if err != nil {
+			t.Fatalf("Transact error: %s", err)
+		}
+		defer func() {
+			err = tx.Done(err)
+			if err != nil {
+				t.Fatalf("Done error: %s", err)
+			}
+		}()
+
+		want := typestest.GenerateExternalServices(7, svcs...)
+
+		if err := tx.Upsert(ctx, want...); err != nil {
+			t.Fatalf("Upsert error: %s", err)
+		}
+
+		// create a store with a NoopKey to read the raw encrypted value
+		noopStore := ExternalServicesWith(tx).WithEncryptionKey(&encryption.NoopKey{})
+
+		for _, e := range want {
+			if e.Kind != strings.ToUpper(e.Kind) {
+				t.Errorf("external service kind didn't get upper-cased: %q", e.Kind)
+				break
+			}
+			encrypted, err := noopStore.GetByID(ctx, e.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// if the TestKey worked, the config should just be a base64 encoded version
+			if encrypted.Config != base64.StdEncoding.EncodeToString([]byte(e.Config)) {
+				t.Fatalf("expected base64 encoded config, got %s", encrypted.Config)
+			}
+		}
+
+		sort.Sort(want)
+
+		have, err := tx.List(ctx, ExternalServicesListOptions{
+			Kinds: svcs.Kinds(),
+		})
+		if err != nil {
+			t.Fatalf("List error: %s", err)
+		}
+
+		sort.Sort(types.ExternalServices(have))
+
+		if diff := cmp.Diff(have, []*types.ExternalService(want), cmpopts.EquateEmpty()); diff != "" {
+			t.Fatalf("List:\n%s", diff)
+		}
+
+		// We'll update the external services, but being careful to keep the
+		// config valid as we go.
+		now := clock.Now()
+		suffix := "-updated"
+		for _, r := range want {
+			r.DisplayName += suffix
+			r.Config = `{"wanted":true,` + r.Config[1:]
+			r.UpdatedAt = now
+			r.CreatedAt = now
+		}
+
+		if err = tx.Upsert(ctx, want...); err != nil {
+			t.Errorf("Upsert error: %s", err)
+		}
+		have, err = tx.List(ctx, ExternalServicesListOptions{})
+		if err != nil {
+			t.Fatalf("List error: %s", err)
+		}
+
+		sort.Sort(types.ExternalServices(have))
+
+		if diff := cmp.Diff(have, []*types.ExternalService(want), cmpopts.EquateEmpty()); diff != "" {
+			t.Errorf("List:\n%s", diff)
+		}
+
+		// Delete external services
+		for _, es := range want {
+			if err := tx.Delete(ctx, es.ID); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		have, err = tx.List(ctx, ExternalServicesListOptions{})
+		if err != nil {
+			t.Errorf("List error: %s", err)
+		}
+
+		sort.Sort(types.ExternalServices(have))
+
+		if diff := cmp.Diff(have, []*types.ExternalService(nil), cmpopts.EquateEmpty()); diff != "" {
+			t.Errorf("List:\n%s", diff)
+		}
+	})
+}
+
+func TestExternalServicesStore_SetUnrestricted(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	db := dbtest.NewDB(t)
+	ctx := context.Background()
+
+	confGet := func() *conf.Unified {
+		return &conf.Unified{}
+	}
+	es := &types.ExternalService{
+		Kind:        extsvc.KindGitHub,
+		DisplayName: "GITHUB #1",
+		Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc", "authorization": {}}`,
+	}
+	if err := ExternalServices(db).Create(ctx, confGet, es); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, unrestricted := range []bool{true, false} {
+		if err := ExternalServices(db).SetUnrestricted(ctx, es.ID, unrestricted); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := ExternalServices(db).GetByID(ctx, es.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Unrestricted != unrestricted {
+			t.Fatalf("want unrestricted = %v, got %v", unrestricted, got.Unrestricted)
+		}
+	}
+
+	if err := ExternalServices(db).SetUnrestricted(ctx, es.ID+1, true); err == nil {
+		t.Fatal("want a not-found error for a missing service")
+	}
+}
+
+func TestExternalServiceStore_GetExternalServiceSyncJobs(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	db := dbtest.NewDB(t)
+	ctx := context.Background()
+
+	// Create a new external service
+	confGet := func() *conf.Unified {
+		return &conf.Unified{}
+	}
+	es := &types.ExternalService{
+		Kind:        extsvc.KindGitHub,
+		DisplayName: "GITHUB #1",
+		Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc"}`,
+	}
+	err := ExternalServices(db).Create(ctx, confGet, es)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = db.ExecContext(ctx, "INSERT INTO external_service_sync_jobs (external_service_id) VALUES ($1)", es.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	have, err := ExternalServices(db).GetSyncJobs(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(have) != 1 {
+		t.Fatalf("Expected 1 job, got %d", len(have))
+	}
+
+	want := &types.ExternalServiceSyncJob{
+		ID:                1,
+		State:             "queued",
+		ExternalServiceID: es.ID,
+	}
+	if diff := cmp.Diff(want, have[0], cmpopts.IgnoreFields(types.ExternalServiceSyncJob{}, "ID")); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestExternalServiceStore_GetSyncJobsPaginated(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	db := dbtest.NewDB(t)
+	ctx := context.Background()
+
+	// Create two external services
+	confGet := func() *conf.Unified {
+		return &conf.Unified{}
+	}
+	es1 := &types.ExternalService{
+		Kind:        extsvc.KindGitHub,
+		DisplayName: "GITHUB #1",
+		Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc"}`,
+	}
+	es2 := &types.ExternalService{
+		Kind:        extsvc.KindGitLab,
+		DisplayName: "GITLAB #1",
+		Config:      `{"url": "https://gitlab.com", "projectQuery": ["none"], "token": "abc"}`,
+	}
+	for _, es := range []*types.ExternalService{es1, es2} {
+		if err := ExternalServices(db).Create(ctx, confGet, es); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	now := time.Now()
+	for i, job := range []struct {
+		externalServiceID int64
+		state             string
+	}{
+		{es1.ID, "completed"},
+		{es1.ID, "completed"},
+		{es1.ID, "errored"},
+		{es2.ID, "completed"},
+	} {
+		_, err := db.ExecContext(ctx,
+			"INSERT INTO external_service_sync_jobs (external_service_id, state, finished_at) VALUES ($1, $2, $3)",
+			job.externalServiceID, job.state, now.Add(-time.Duration(i)*time.Minute),
+		)
 		if err != nil {
-			t.Errorf("List error: %s", err)
+			t.Fatal(err)
 		}
+	}
 
-		sort.Sort(types.ExternalServices(have))
-
-		if diff := cmp.Diff(have, []*types.ExternalService(nil), cmpopts.EquateEmpty()); diff != "" {
-			t.Errorf("List:\n%s", diff)
+	t.Run("filter by external service", func(t *testing.T) {
+		jobs, total, err := ExternalServices(db).GetSyncJobsPaginated(ctx, SyncJobsListOptions{
+			ExternalServiceID: es1.ID,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if total != 3 {
+			t.Fatalf("want total 3, got %d", total)
+		}
+		if len(jobs) != 3 {
+			t.Fatalf("want 3 jobs, got %d", len(jobs))
 		}
 	})
 
-	t.Run("with encryption key", func(t *testing.T) {
-		tx, err := ExternalServices(db).WithEncryptionKey(et.TestKey{}).Transact(ctx)
+	t.Run("filter by state", func(t *testing.T) {
+		jobs, total, err := ExternalServices(db).GetSyncJobsPaginated(ctx, SyncJobsListOptions{
+			State: "errored",
+		})
 		if err != nil {
-			t.Fatalf("Transact error: %s", err)
+			t.Fatal(err)
 		}
-		defer func() {
-			err = tx.Done(err)
-			if err != nil {
-				t.Fatalf("Done error: %s", err)
-			}
-		}()
-
-		want := typestest.GenerateExternalServices(7, svcs...)
-
-		if err := tx.Upsert(ctx, want...); err != nil {
-			t.Fatalf("Upsert error: %s", err)
+		if total != 1 {
+			t.Fatalf("want total 1, got %d", total)
 		}
-
-		// create a store with a NoopKey to read the raw encrypted value
-		noopStore := ExternalServicesWith(tx).WithEncryptionKey(&encryption.NoopKey{})
-
-		for _, e := range want {
-			if e.Kind != strings.ToUpper(e.Kind) {
-				t.Errorf("external service kind didn't get upper-cased: %q", e.Kind)
-				break
-			}
-			encrypted, err := noopStore.GetByID(ctx, e.ID)
-			if err != nil {
-				t.Fatal(err)
-			}
-			// if the TestKey worked, the config should just be a base64 encoded version
-			if encrypted.Config != base64.StdEncoding.EncodeToString([]byte(e.Config)) {
-				t.Fatalf("expected base64 encoded config, got %s", encrypted.Config)
-			}
+		if len(jobs) != 1 || jobs[0].ExternalServiceID != es1.ID {
+			t.Fatalf("unexpected jobs: %+v", jobs)
 		}
+	})
 
-		sort.Sort(want)
-
-		have, err := tx.List(ctx, ExternalServicesListOptions{
-			Kinds: svcs.Kinds(),
+	t.Run("paging", func(t *testing.T) {
+		jobs, total, err := ExternalServices(db).GetSyncJobsPaginated(ctx, SyncJobsListOptions{
+			LimitOffset: &LimitOffset{Limit: 2, Offset: 1},
 		})
 		if err != nil {
-			t.Fatalf("List error: %s", err)
+			t.Fatal(err)
 		}
-
-		sort.Sort(types.ExternalServices(have))
-
-		if diff := cmp.Diff(have, []*types.ExternalService(want), cmpopts.EquateEmpty()); diff != "" {
-			t.Fatalf("List:\n%s", diff)
+		if total != 4 {
+			t.Fatalf("want total 4, got %d", total)
 		}
-
-		// We'll update the external services, but being careful to keep the
-		// config valid as we go.
-		now := clock.Now()
-		suffix := "-updated"
-		for _, r := range want {
-			r.DisplayName += suffix
-			r.Config = `{"wanted":true,` + r.Config[1:]
-			r.UpdatedAt = now
-			r.CreatedAt = now
+		if len(jobs) != 2 {
+			t.Fatalf("want 2 jobs, got %d", len(jobs))
 		}
-
-		if err = tx.Upsert(ctx, want...); err != nil {
-			t.Errorf("Upsert error: %s", err)
+		// Jobs are ordered by finished_at descending, so the page starting at
+		// offset 1 skips the most recently finished job.
+		if !jobs[0].FinishedAt.After(jobs[1].FinishedAt) {
+			t.Fatalf("jobs not ordered by finished_at desc: %+v", jobs)
 		}
-		have, err = tx.List(ctx, ExternalServicesListOptions{})
+	})
+
+	t.Run("since", func(t *testing.T) {
+		jobs, total, err := ExternalServices(db).GetSyncJobsPaginated(ctx, SyncJobsListOptions{
+			Since: now.Add(-90 * time.Second),
+		})
 		if err != nil {
-			t.Fatalf("List error: %s", err)
+			t.Fatal(err)
 		}
-
-		sort.Sort(types.ExternalServices(have))
-
-		if diff := cmp.Diff(have, []*types.ExternalService(want), cmpopts.EquateEmpty()); diff != "" {
-			t.Errorf("List:\n%s", diff)
+		if total != 2 {
+			t.Fatalf("want total 2, got %d", total)
 		}
-
-		// Delete external services
-		for _, es := range want {
-			if err := tx.Delete(ctx, es.ID); err != nil {
-				t.Fatal(err)
-			}
+		if len(jobs) != 2 {
+			t.Fatalf("want 2 jobs, got %d", len(jobs))
 		}
+		// Incremental polls see the oldest matching job first.
+		if !jobs[0].FinishedAt.Before(jobs[1].FinishedAt) {
+			t.Fatalf("jobs not ordered by finished_at asc: %+v", jobs)
+		}
+	})
+}
 
-		have, err = tx.List(ctx, ExternalServicesListOptions{})
+func TestExternalServiceStore_CountSyncJobsByState(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	db := dbtest.NewDB(t)
+	ctx := context.Background()
+
+	// Create a new external service
+	confGet := func() *conf.Unified {
+		return &conf.Unified{}
+	}
+	es := &types.ExternalService{
+		Kind:        extsvc.KindGitHub,
+		DisplayName: "GITHUB #1",
+		Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc"}`,
+	}
+	err := ExternalServices(db).Create(ctx, confGet, es)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, state := range []string{"queued", "queued", "processing", "errored"} {
+		_, err = db.ExecContext(ctx, "INSERT INTO external_service_sync_jobs (external_service_id, state) VALUES ($1, $2)", es.ID, state)
 		if err != nil {
-			t.Errorf("List error: %s", err)
+			t.Fatal(err)
 		}
+	}
 
-		sort.Sort(types.ExternalServices(have))
+	have, err := ExternalServices(db).CountSyncJobsByState(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
 
-		if diff := cmp.Diff(have, []*types.ExternalService(nil), cmpopts.EquateEmpty()); diff != "" {
-			t.Errorf("List:\n%s", diff)
-		}
-	})
+	want := map[string]int{
+		"queued":     2,
+		"processing": 1,
+		"errored":    1,
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatal(diff)
+	}
 }
 
-func TestExternalServiceStore_GetExternalServiceSyncJobs(t *testing.T) {
+func TestExternalServiceStore_RequeueStuckSyncJobs(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
 	}
@@ -1840,27 +3104,212 @@ func TestExternalServiceStore_GetExternalServiceSyncJobs(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	_, err = db.ExecContext(ctx, "INSERT INTO external_service_sync_jobs (external_service_id) VALUES ($1)", es.ID)
+	// A stale processing job, a fresh one, and one from a worker that died
+	// before its first heartbeat.
+	var staleID, freshID, noHeartbeatID int64
+	err = db.QueryRowContext(ctx, `
+INSERT INTO external_service_sync_jobs (external_service_id, state, started_at, last_heartbeat_at)
+VALUES ($1, 'processing', now() - interval '1 hour', now() - interval '1 hour')
+RETURNING id
+`, es.ID).Scan(&staleID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = db.QueryRowContext(ctx, `
+INSERT INTO external_service_sync_jobs (external_service_id, state, started_at, last_heartbeat_at)
+VALUES ($1, 'processing', now() - interval '1 hour', now())
+RETURNING id
+`, es.ID).Scan(&freshID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = db.QueryRowContext(ctx, `
+INSERT INTO external_service_sync_jobs (external_service_id, state, started_at)
+VALUES ($1, 'processing', now() - interval '1 hour')
+RETURNING id
+`, es.ID).Scan(&noHeartbeatID)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	have, err := ExternalServices(db).GetSyncJobs(ctx)
+	requeued, err := ExternalServices(db).RequeueStuckSyncJobs(ctx, 30*time.Minute)
 	if err != nil {
 		t.Fatal(err)
 	}
-	if len(have) != 1 {
-		t.Fatalf("Expected 1 job, got %d", len(have))
+	if requeued != 2 {
+		t.Fatalf("want 2 jobs requeued, got %d", requeued)
 	}
 
-	want := &types.ExternalServiceSyncJob{
-		ID:                1,
-		State:             "queued",
-		ExternalServiceID: es.ID,
+	assertState := func(id int64, want string) {
+		t.Helper()
+		var state string
+		if err := db.QueryRowContext(ctx, "SELECT state FROM external_service_sync_jobs WHERE id = $1", id).Scan(&state); err != nil {
+			t.Fatal(err)
+		}
+		if state != want {
+			t.Fatalf("want job %d in state %q, got %q", id, want, state)
+		}
 	}
-	if diff := cmp.Diff(want, have[0], cmpopts.IgnoreFields(types.ExternalServiceSyncJob{}, "ID")); diff != "" {
-		t.Fatal(diff)
+	assertState(staleID, "queued")
+	assertState(freshID, "processing")
+	assertState(noHeartbeatID, "queued")
+
+	// A second run finds nothing left to requeue.
+	requeued, err = ExternalServices(db).RequeueStuckSyncJobs(ctx, 30*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requeued != 0 {
+		t.Fatalf("want 0 jobs requeued, got %d", requeued)
+	}
+}
+
+func TestExternalServiceStore_ReconcileExternalServiceRepos(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	db := dbtest.NewDB(t)
+	ctx := actor.WithInternalActor(context.Background())
+
+	confGet := func() *conf.Unified {
+		return &conf.Unified{}
+	}
+	es1 := &types.ExternalService{
+		Kind:        extsvc.KindGitHub,
+		DisplayName: "GITHUB #1",
+		Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc"}`,
+	}
+	es2 := &types.ExternalService{
+		Kind:        extsvc.KindGitHub,
+		DisplayName: "GITHUB #2",
+		Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "def"}`,
+	}
+	for _, es := range []*types.ExternalService{es1, es2} {
+		if err := ExternalServices(db).Create(ctx, confGet, es); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Repo 2 and service 2 are soft-deleted before their join rows are
+	// inserted, so the delete triggers never see the rows: the orphans this
+	// method exists to catch.
+	_, err := db.ExecContext(ctx, `
+INSERT INTO repo (id, name, description, fork)
+VALUES
+	(1, 'github.com/user/repo1', '', FALSE),
+	(2, 'github.com/user/repo2', '', FALSE)
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.ExecContext(ctx, "UPDATE repo SET deleted_at = now() WHERE id = 2"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.ExecContext(ctx, "UPDATE external_services SET deleted_at = now() WHERE id = $1", es2.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	q := sqlf.Sprintf(`
+INSERT INTO external_service_repos (external_service_id, repo_id, clone_url)
+VALUES (%d, 1, ''), (%d, 2, ''), (%d, 1, '')
+`, es1.ID, es1.ID, es2.ID)
+	if _, err := db.ExecContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...); err != nil {
+		t.Fatal(err)
+	}
+
+	removed, err := ExternalServices(db).ReconcileExternalServiceRepos(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed != 2 {
+		t.Fatalf("want 2 rows removed, got %d", removed)
+	}
+
+	// Only the valid row of the live service and repo remains.
+	var count int
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM external_service_repos").Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Fatalf("want 1 row left, got %d", count)
+	}
+
+	// A second run finds nothing left to remove.
+	removed, err = ExternalServices(db).ReconcileExternalServiceRepos(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed != 0 {
+		t.Fatalf("want 0 rows removed, got %d", removed)
+	}
+}
+
+func TestExternalServiceStore_SyncJobLogs(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	db := dbtest.NewDB(t)
+	ctx := context.Background()
+
+	// Create a new external service
+	confGet := func() *conf.Unified {
+		return &conf.Unified{}
+	}
+	es := &types.ExternalService{
+		Kind:        extsvc.KindGitHub,
+		DisplayName: "GITHUB #1",
+		Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc"}`,
+	}
+	err := ExternalServices(db).Create(ctx, confGet, es)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var jobID int64
+	err = db.QueryRowContext(ctx, "INSERT INTO external_service_sync_jobs (external_service_id) VALUES ($1) RETURNING id", es.ID).
+		Scan(&jobID)
+	if err != nil {
+		t.Fatal(err)
 	}
+
+	t.Run("append and retrieve", func(t *testing.T) {
+		for _, line := range []string{"sync started", "deleted 2 repos that were not seen", "sync finished"} {
+			if err := ExternalServices(db).AppendSyncJobLog(ctx, jobID, line); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		have, err := ExternalServices(db).GetSyncJobLogs(ctx, jobID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []string{"sync started", "deleted 2 repos that were not seen", "sync finished"}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("bound", func(t *testing.T) {
+		for i := 0; i < maxSyncJobLogLines+10; i++ {
+			if err := ExternalServices(db).AppendSyncJobLog(ctx, jobID, fmt.Sprintf("line %d", i)); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		have, err := ExternalServices(db).GetSyncJobLogs(ctx, jobID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != maxSyncJobLogLines {
+			t.Fatalf("want %d log lines, got %d", maxSyncJobLogLines, len(have))
+		}
+		// The oldest lines must have been discarded.
+		if want := fmt.Sprintf("line %d", 10); have[0] != want {
+			t.Fatalf("want first line %q, got %q", want, have[0])
+		}
+	})
 }
 
 func TestExternalServicesStore_OneCloudDefaultPerKind(t *testing.T) {
@@ -10658,9 +10658,21 @@ func (c EventLogStoreWithFuncCall) Results() []interface{} {
 // github.com/sourcegraph/sourcegraph/internal/database) used for unit
 // testing.
 type MockExternalServiceStore struct {
+	// AppendSyncJobLogFunc is an instance of a mock function object
+	// controlling the behavior of the method AppendSyncJobLog.
+	AppendSyncJobLogFunc *ExternalServiceStoreAppendSyncJobLogFunc
+	// BackfillHasWebhooksFunc is an instance of a mock function object
+	// controlling the behavior of the method BackfillHasWebhooks.
+	BackfillHasWebhooksFunc *ExternalServiceStoreBackfillHasWebhooksFunc
 	// CountFunc is an instance of a mock function object controlling the
 	// behavior of the method Count.
 	CountFunc *ExternalServiceStoreCountFunc
+	// CountReposByNamespaceFunc is an instance of a mock function object
+	// controlling the behavior of the method CountReposByNamespace.
+	CountReposByNamespaceFunc *ExternalServiceStoreCountReposByNamespaceFunc
+	// CountSyncJobsByStateFunc is an instance of a mock function object
+	// controlling the behavior of the method CountSyncJobsByState.
+	CountSyncJobsByStateFunc *ExternalServiceStoreCountSyncJobsByStateFunc
 	// CreateFunc is an instance of a mock function object controlling the
 	// behavior of the method Create.
 	CreateFunc *ExternalServiceStoreCreateFunc
@@ -10673,27 +10685,80 @@ type MockExternalServiceStore struct {
 	// DoneFunc is an instance of a mock function object controlling the
 	// behavior of the method Done.
 	DoneFunc *ExternalServiceStoreDoneFunc
+	// EffectiveRateLimitFunc is an instance of a mock function object
+	// controlling the behavior of the method EffectiveRateLimit.
+	EffectiveRateLimitFunc *ExternalServiceStoreEffectiveRateLimitFunc
+	// ExportConfigsFunc is an instance of a mock function object
+	// controlling the behavior of the method ExportConfigs.
+	ExportConfigsFunc *ExternalServiceStoreExportConfigsFunc
+	// FindDuplicatesFunc is an instance of a mock function object
+	// controlling the behavior of the method FindDuplicates.
+	FindDuplicatesFunc *ExternalServiceStoreFindDuplicatesFunc
+	// GetAffiliatedSyncErrorDetailsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// GetAffiliatedSyncErrorDetails.
+	GetAffiliatedSyncErrorDetailsFunc *ExternalServiceStoreGetAffiliatedSyncErrorDetailsFunc
 	// GetAffiliatedSyncErrorsFunc is an instance of a mock function object
 	// controlling the behavior of the method GetAffiliatedSyncErrors.
 	GetAffiliatedSyncErrorsFunc *ExternalServiceStoreGetAffiliatedSyncErrorsFunc
 	// GetByIDFunc is an instance of a mock function object controlling the
 	// behavior of the method GetByID.
 	GetByIDFunc *ExternalServiceStoreGetByIDFunc
+	// GetByIDsFunc is an instance of a mock function object controlling the
+	// behavior of the method GetByIDs.
+	GetByIDsFunc *ExternalServiceStoreGetByIDsFunc
+	// GetCloudDefaultFunc is an instance of a mock function object
+	// controlling the behavior of the method GetCloudDefault.
+	GetCloudDefaultFunc *ExternalServiceStoreGetCloudDefaultFunc
 	// GetLastSyncErrorFunc is an instance of a mock function object
 	// controlling the behavior of the method GetLastSyncError.
 	GetLastSyncErrorFunc *ExternalServiceStoreGetLastSyncErrorFunc
+	// GetRepoLastSyncErrorFunc is an instance of a mock function object
+	// controlling the behavior of the method GetRepoLastSyncError.
+	GetRepoLastSyncErrorFunc *ExternalServiceStoreGetRepoLastSyncErrorFunc
+	// GetSyncJobLogsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetSyncJobLogs.
+	GetSyncJobLogsFunc *ExternalServiceStoreGetSyncJobLogsFunc
 	// GetSyncJobsFunc is an instance of a mock function object controlling
 	// the behavior of the method GetSyncJobs.
 	GetSyncJobsFunc *ExternalServiceStoreGetSyncJobsFunc
+	// GetSyncJobsPaginatedFunc is an instance of a mock function object
+	// controlling the behavior of the method GetSyncJobsPaginated.
+	GetSyncJobsPaginatedFunc *ExternalServiceStoreGetSyncJobsPaginatedFunc
 	// HandleFunc is an instance of a mock function object controlling the
 	// behavior of the method Handle.
 	HandleFunc *ExternalServiceStoreHandleFunc
+	// LastSuccessfulSyncFunc is an instance of a mock function object
+	// controlling the behavior of the method LastSuccessfulSync.
+	LastSuccessfulSyncFunc *ExternalServiceStoreLastSuccessfulSyncFunc
 	// ListFunc is an instance of a mock function object controlling the
 	// behavior of the method List.
 	ListFunc *ExternalServiceStoreListFunc
+	// ListByNamespaceFunc is an instance of a mock function object
+	// controlling the behavior of the method ListByNamespace.
+	ListByNamespaceFunc *ExternalServiceStoreListByNamespaceFunc
+	// ListReposFunc is an instance of a mock function object controlling
+	// the behavior of the method ListRepos.
+	ListReposFunc *ExternalServiceStoreListReposFunc
+	// PauseFunc is an instance of a mock function object controlling the
+	// behavior of the method Pause.
+	PauseFunc *ExternalServiceStorePauseFunc
+	// ReconcileExternalServiceReposFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// ReconcileExternalServiceRepos.
+	ReconcileExternalServiceReposFunc *ExternalServiceStoreReconcileExternalServiceReposFunc
 	// RepoCountFunc is an instance of a mock function object controlling
 	// the behavior of the method RepoCount.
 	RepoCountFunc *ExternalServiceStoreRepoCountFunc
+	// RequeueStuckSyncJobsFunc is an instance of a mock function object
+	// controlling the behavior of the method RequeueStuckSyncJobs.
+	RequeueStuckSyncJobsFunc *ExternalServiceStoreRequeueStuckSyncJobsFunc
+	// ResumeFunc is an instance of a mock function object controlling the
+	// behavior of the method Resume.
+	ResumeFunc *ExternalServiceStoreResumeFunc
+	// SetUnrestrictedFunc is an instance of a mock function object
+	// controlling the behavior of the method SetUnrestricted.
+	SetUnrestrictedFunc *ExternalServiceStoreSetUnrestrictedFunc
 	// SyncDueFunc is an instance of a mock function object controlling the
 	// behavior of the method SyncDue.
 	SyncDueFunc *ExternalServiceStoreSyncDueFunc
@@ -10722,11 +10787,31 @@ type MockExternalServiceStore struct {
 // results, unless overwritten.
 func NewMockExternalServiceStore() *MockExternalServiceStore {
 	return &MockExternalServiceStore{
+		AppendSyncJobLogFunc: &ExternalServiceStoreAppendSyncJobLogFunc{
+			defaultHook: func(context.Context, int64, string) error {
+				return nil
+			},
+		},
+		BackfillHasWebhooksFunc: &ExternalServiceStoreBackfillHasWebhooksFunc{
+			defaultHook: func(context.Context, int) (int, error) {
+				return 0, nil
+			},
+		},
 		CountFunc: &ExternalServiceStoreCountFunc{
 			defaultHook: func(context.Context, ExternalServicesListOptions) (int, error) {
 				return 0, nil
 			},
 		},
+		CountReposByNamespaceFunc: &ExternalServiceStoreCountReposByNamespaceFunc{
+			defaultHook: func(context.Context, int32, int32) (int32, error) {
+				return 0, nil
+			},
+		},
+		CountSyncJobsByStateFunc: &ExternalServiceStoreCountSyncJobsByStateFunc{
+			defaultHook: func(context.Context) (map[string]int, error) {
+				return nil, nil
+			},
+		},
 		CreateFunc: &ExternalServiceStoreCreateFunc{
 			defaultHook: func(context.Context, func() *conf.Unified, *types.ExternalService) error {
 				return nil
@@ -10747,6 +10832,26 @@ func NewMockExternalServiceStore() *MockExternalServiceStore {
 				return nil
 			},
 		},
+		EffectiveRateLimitFunc: &ExternalServiceStoreEffectiveRateLimitFunc{
+			defaultHook: func(context.Context, int64) (float64, string, error) {
+				return 0, "", nil
+			},
+		},
+		ExportConfigsFunc: &ExternalServiceStoreExportConfigsFunc{
+			defaultHook: func(context.Context) ([]RedactedExternalService, error) {
+				return nil, nil
+			},
+		},
+		FindDuplicatesFunc: &ExternalServiceStoreFindDuplicatesFunc{
+			defaultHook: func(context.Context) ([][]*types.ExternalService, error) {
+				return nil, nil
+			},
+		},
+		GetAffiliatedSyncErrorDetailsFunc: &ExternalServiceStoreGetAffiliatedSyncErrorDetailsFunc{
+			defaultHook: func(context.Context, *types.User) ([]SyncErrorDetail, error) {
+				return nil, nil
+			},
+		},
 		GetAffiliatedSyncErrorsFunc: &ExternalServiceStoreGetAffiliatedSyncErrorsFunc{
 			defaultHook: func(context.Context, *types.User) (map[int64]string, error) {
 				return nil, nil
@@ -10757,31 +10862,96 @@ func NewMockExternalServiceStore() *MockExternalServiceStore {
 				return nil, nil
 			},
 		},
+		GetByIDsFunc: &ExternalServiceStoreGetByIDsFunc{
+			defaultHook: func(context.Context, []int64) ([]*types.ExternalService, error) {
+				return nil, nil
+			},
+		},
+		GetCloudDefaultFunc: &ExternalServiceStoreGetCloudDefaultFunc{
+			defaultHook: func(context.Context, string) (*types.ExternalService, error) {
+				return nil, nil
+			},
+		},
 		GetLastSyncErrorFunc: &ExternalServiceStoreGetLastSyncErrorFunc{
 			defaultHook: func(context.Context, int64) (string, error) {
 				return "", nil
 			},
 		},
+		GetRepoLastSyncErrorFunc: &ExternalServiceStoreGetRepoLastSyncErrorFunc{
+			defaultHook: func(context.Context, api.RepoName) (string, error) {
+				return "", nil
+			},
+		},
+		GetSyncJobLogsFunc: &ExternalServiceStoreGetSyncJobLogsFunc{
+			defaultHook: func(context.Context, int64) ([]string, error) {
+				return nil, nil
+			},
+		},
 		GetSyncJobsFunc: &ExternalServiceStoreGetSyncJobsFunc{
 			defaultHook: func(context.Context) ([]*types.ExternalServiceSyncJob, error) {
 				return nil, nil
 			},
 		},
+		GetSyncJobsPaginatedFunc: &ExternalServiceStoreGetSyncJobsPaginatedFunc{
+			defaultHook: func(context.Context, SyncJobsListOptions) ([]*types.ExternalServiceSyncJob, int, error) {
+				return nil, 0, nil
+			},
+		},
 		HandleFunc: &ExternalServiceStoreHandleFunc{
 			defaultHook: func() *basestore.TransactableHandle {
 				return nil
 			},
 		},
+		LastSuccessfulSyncFunc: &ExternalServiceStoreLastSuccessfulSyncFunc{
+			defaultHook: func(context.Context, int64) (time.Time, error) {
+				return time.Time{}, nil
+			},
+		},
 		ListFunc: &ExternalServiceStoreListFunc{
 			defaultHook: func(context.Context, ExternalServicesListOptions) ([]*types.ExternalService, error) {
 				return nil, nil
 			},
 		},
+		ListByNamespaceFunc: &ExternalServiceStoreListByNamespaceFunc{
+			defaultHook: func(context.Context, int32, int32) ([]*types.ExternalService, error) {
+				return nil, nil
+			},
+		},
+		ListReposFunc: &ExternalServiceStoreListReposFunc{
+			defaultHook: func(context.Context, int64, LimitOffset) ([]*types.Repo, error) {
+				return nil, nil
+			},
+		},
+		PauseFunc: &ExternalServiceStorePauseFunc{
+			defaultHook: func(context.Context, int64) error {
+				return nil
+			},
+		},
+		ReconcileExternalServiceReposFunc: &ExternalServiceStoreReconcileExternalServiceReposFunc{
+			defaultHook: func(context.Context) (int, error) {
+				return 0, nil
+			},
+		},
 		RepoCountFunc: &ExternalServiceStoreRepoCountFunc{
 			defaultHook: func(context.Context, int64) (int32, error) {
 				return 0, nil
 			},
 		},
+		RequeueStuckSyncJobsFunc: &ExternalServiceStoreRequeueStuckSyncJobsFunc{
+			defaultHook: func(context.Context, time.Duration) (int, error) {
+				return 0, nil
+			},
+		},
+		ResumeFunc: &ExternalServiceStoreResumeFunc{
+			defaultHook: func(context.Context, int64) error {
+				return nil
+			},
+		},
+		SetUnrestrictedFunc: &ExternalServiceStoreSetUnrestrictedFunc{
+			defaultHook: func(context.Context, int64, bool) error {
+				return nil
+			},
+		},
 		SyncDueFunc: &ExternalServiceStoreSyncDueFunc{
 			defaultHook: func(context.Context, []int64, time.Duration) (bool, error) {
 				return false, nil
@@ -10825,11 +10995,31 @@ func NewMockExternalServiceStore() *MockExternalServiceStore {
 // overwritten.
 func NewStrictMockExternalServiceStore() *MockExternalServiceStore {
 	return &MockExternalServiceStore{
+		AppendSyncJobLogFunc: &ExternalServiceStoreAppendSyncJobLogFunc{
+			defaultHook: func(context.Context, int64, string) error {
+				panic("unexpected invocation of MockExternalServiceStore.AppendSyncJobLog")
+			},
+		},
+		BackfillHasWebhooksFunc: &ExternalServiceStoreBackfillHasWebhooksFunc{
+			defaultHook: func(context.Context, int) (int, error) {
+				panic("unexpected invocation of MockExternalServiceStore.BackfillHasWebhooks")
+			},
+		},
 		CountFunc: &ExternalServiceStoreCountFunc{
 			defaultHook: func(context.Context, ExternalServicesListOptions) (int, error) {
 				panic("unexpected invocation of MockExternalServiceStore.Count")
 			},
 		},
+		CountReposByNamespaceFunc: &ExternalServiceStoreCountReposByNamespaceFunc{
+			defaultHook: func(context.Context, int32, int32) (int32, error) {
+				panic("unexpected invocation of MockExternalServiceStore.CountReposByNamespace")
+			},
+		},
+		CountSyncJobsByStateFunc: &ExternalServiceStoreCountSyncJobsByStateFunc{
+			defaultHook: func(context.Context) (map[string]int, error) {
+				panic("unexpected invocation of MockExternalServiceStore.CountSyncJobsByState")
+			},
+		},
 		CreateFunc: &ExternalServiceStoreCreateFunc{
 			defaultHook: func(context.Context, func() *conf.Unified, *types.ExternalService) error {
 				panic("unexpected invocation of MockExternalServiceStore.Create")
@@ -10850,6 +11040,26 @@ func NewStrictMockExternalServiceStore() *MockExternalServiceStore {
 				panic("unexpected invocation of MockExternalServiceStore.Done")
 			},
 		},
+		EffectiveRateLimitFunc: &ExternalServiceStoreEffectiveRateLimitFunc{
+			defaultHook: func(context.Context, int64) (float64, string, error) {
+				panic("unexpected invocation of MockExternalServiceStore.EffectiveRateLimit")
+			},
+		},
+		ExportConfigsFunc: &ExternalServiceStoreExportConfigsFunc{
+			defaultHook: func(context.Context) ([]RedactedExternalService, error) {
+				panic("unexpected invocation of MockExternalServiceStore.ExportConfigs")
+			},
+		},
+		FindDuplicatesFunc: &ExternalServiceStoreFindDuplicatesFunc{
+			defaultHook: func(context.Context) ([][]*types.ExternalService, error) {
+				panic("unexpected invocation of MockExternalServiceStore.FindDuplicates")
+			},
+		},
+		GetAffiliatedSyncErrorDetailsFunc: &ExternalServiceStoreGetAffiliatedSyncErrorDetailsFunc{
+			defaultHook: func(context.Context, *types.User) ([]SyncErrorDetail, error) {
+				panic("unexpected invocation of MockExternalServiceStore.GetAffiliatedSyncErrorDetails")
+			},
+		},
 		GetAffiliatedSyncErrorsFunc: &ExternalServiceStoreGetAffiliatedSyncErrorsFunc{
 			defaultHook: func(context.Context, *types.User) (map[int64]string, error) {
 				panic("unexpected invocation of MockExternalServiceStore.GetAffiliatedSyncErrors")
@@ -10860,31 +11070,96 @@ func NewStrictMockExternalServiceStore() *MockExternalServiceStore {
 				panic("unexpected invocation of MockExternalServiceStore.GetByID")
 			},
 		},
+		GetByIDsFunc: &ExternalServiceStoreGetByIDsFunc{
+			defaultHook: func(context.Context, []int64) ([]*types.ExternalService, error) {
+				panic("unexpected invocation of MockExternalServiceStore.GetByIDs")
+			},
+		},
+		GetCloudDefaultFunc: &ExternalServiceStoreGetCloudDefaultFunc{
+			defaultHook: func(context.Context, string) (*types.ExternalService, error) {
+				panic("unexpected invocation of MockExternalServiceStore.GetCloudDefault")
+			},
+		},
 		GetLastSyncErrorFunc: &ExternalServiceStoreGetLastSyncErrorFunc{
 			defaultHook: func(context.Context, int64) (string, error) {
 				panic("unexpected invocation of MockExternalServiceStore.GetLastSyncError")
 			},
 		},
+		GetRepoLastSyncErrorFunc: &ExternalServiceStoreGetRepoLastSyncErrorFunc{
+			defaultHook: func(context.Context, api.RepoName) (string, error) {
+				panic("unexpected invocation of MockExternalServiceStore.GetRepoLastSyncError")
+			},
+		},
+		GetSyncJobLogsFunc: &ExternalServiceStoreGetSyncJobLogsFunc{
+			defaultHook: func(context.Context, int64) ([]string, error) {
+				panic("unexpected invocation of MockExternalServiceStore.GetSyncJobLogs")
+			},
+		},
 		GetSyncJobsFunc: &ExternalServiceStoreGetSyncJobsFunc{
 			defaultHook: func(context.Context) ([]*types.ExternalServiceSyncJob, error) {
 				panic("unexpected invocation of MockExternalServiceStore.GetSyncJobs")
 			},
 		},
+		GetSyncJobsPaginatedFunc: &ExternalServiceStoreGetSyncJobsPaginatedFunc{
+			defaultHook: func(context.Context, SyncJobsListOptions) ([]*types.ExternalServiceSyncJob, int, error) {
+				panic("unexpected invocation of MockExternalServiceStore.GetSyncJobsPaginated")
+			},
+		},
 		HandleFunc: &ExternalServiceStoreHandleFunc{
 			defaultHook: func() *basestore.TransactableHandle {
 				panic("unexpected invocation of MockExternalServiceStore.Handle")
 			},
 		},
+		LastSuccessfulSyncFunc: &ExternalServiceStoreLastSuccessfulSyncFunc{
+			defaultHook: func(context.Context, int64) (time.Time, error) {
+				panic("unexpected invocation of MockExternalServiceStore.LastSuccessfulSync")
+			},
+		},
 		ListFunc: &ExternalServiceStoreListFunc{
 			defaultHook: func(context.Context, ExternalServicesListOptions) ([]*types.ExternalService, error) {
 				panic("unexpected invocation of MockExternalServiceStore.List")
 			},
 		},
+		ListByNamespaceFunc: &ExternalServiceStoreListByNamespaceFunc{
+			defaultHook: func(context.Context, int32, int32) ([]*types.ExternalService, error) {
+				panic("unexpected invocation of MockExternalServiceStore.ListByNamespace")
+			},
+		},
+		ListReposFunc: &ExternalServiceStoreListReposFunc{
+			defaultHook: func(context.Context, int64, LimitOffset) ([]*types.Repo, error) {
+				panic("unexpected invocation of MockExternalServiceStore.ListRepos")
+			},
+		},
+		PauseFunc: &ExternalServiceStorePauseFunc{
+			defaultHook: func(context.Context, int64) error {
+				panic("unexpected invocation of MockExternalServiceStore.Pause")
+			},
+		},
+		ReconcileExternalServiceReposFunc: &ExternalServiceStoreReconcileExternalServiceReposFunc{
+			defaultHook: func(context.Context) (int, error) {
+				panic("unexpected invocation of MockExternalServiceStore.ReconcileExternalServiceRepos")
+			},
+		},
 		RepoCountFunc: &ExternalServiceStoreRepoCountFunc{
 			defaultHook: func(context.Context, int64) (int32, error) {
 				panic("unexpected invocation of MockExternalServiceStore.RepoCount")
 			},
 		},
+		RequeueStuckSyncJobsFunc: &ExternalServiceStoreRequeueStuckSyncJobsFunc{
+			defaultHook: func(context.Context, time.Duration) (int, error) {
+				panic("unexpected invocation of MockExternalServiceStore.RequeueStuckSyncJobs")
+			},
+		},
+		ResumeFunc: &ExternalServiceStoreResumeFunc{
+			defaultHook: func(context.Context, int64) error {
+				panic("unexpected invocation of MockExternalServiceStore.Resume")
+			},
+		},
+		SetUnrestrictedFunc: &ExternalServiceStoreSetUnrestrictedFunc{
+			defaultHook: func(context.Context, int64, bool) error {
+				panic("unexpected invocation of MockExternalServiceStore.SetUnrestricted")
+			},
+		},
 		SyncDueFunc: &ExternalServiceStoreSyncDueFunc{
 			defaultHook: func(context.Context, []int64, time.Duration) (bool, error) {
 				panic("unexpected invocation of MockExternalServiceStore.SyncDue")
@@ -10928,9 +11203,21 @@ func NewStrictMockExternalServiceStore() *MockExternalServiceStore {
 // implementation, unless overwritten.
 func NewMockExternalServiceStoreFrom(i ExternalServiceStore) *MockExternalServiceStore {
 	return &MockExternalServiceStore{
+		AppendSyncJobLogFunc: &ExternalServiceStoreAppendSyncJobLogFunc{
+			defaultHook: i.AppendSyncJobLog,
+		},
+		BackfillHasWebhooksFunc: &ExternalServiceStoreBackfillHasWebhooksFunc{
+			defaultHook: i.BackfillHasWebhooks,
+		},
 		CountFunc: &ExternalServiceStoreCountFunc{
 			defaultHook: i.Count,
 		},
+		CountReposByNamespaceFunc: &ExternalServiceStoreCountReposByNamespaceFunc{
+			defaultHook: i.CountReposByNamespace,
+		},
+		CountSyncJobsByStateFunc: &ExternalServiceStoreCountSyncJobsByStateFunc{
+			defaultHook: i.CountSyncJobsByState,
+		},
 		CreateFunc: &ExternalServiceStoreCreateFunc{
 			defaultHook: i.Create,
 		},
@@ -10943,27 +11230,78 @@ func NewMockExternalServiceStoreFrom(i ExternalServiceStore) *MockExternalServic
 		DoneFunc: &ExternalServiceStoreDoneFunc{
 			defaultHook: i.Done,
 		},
+		EffectiveRateLimitFunc: &ExternalServiceStoreEffectiveRateLimitFunc{
+			defaultHook: i.EffectiveRateLimit,
+		},
+		ExportConfigsFunc: &ExternalServiceStoreExportConfigsFunc{
+			defaultHook: i.ExportConfigs,
+		},
+		FindDuplicatesFunc: &ExternalServiceStoreFindDuplicatesFunc{
+			defaultHook: i.FindDuplicates,
+		},
+		GetAffiliatedSyncErrorDetailsFunc: &ExternalServiceStoreGetAffiliatedSyncErrorDetailsFunc{
+			defaultHook: i.GetAffiliatedSyncErrorDetails,
+		},
 		GetAffiliatedSyncErrorsFunc: &ExternalServiceStoreGetAffiliatedSyncErrorsFunc{
 			defaultHook: i.GetAffiliatedSyncErrors,
 		},
 		GetByIDFunc: &ExternalServiceStoreGetByIDFunc{
 			defaultHook: i.GetByID,
 		},
+		GetByIDsFunc: &ExternalServiceStoreGetByIDsFunc{
+			defaultHook: i.GetByIDs,
+		},
+		GetCloudDefaultFunc: &ExternalServiceStoreGetCloudDefaultFunc{
+			defaultHook: i.GetCloudDefault,
+		},
 		GetLastSyncErrorFunc: &ExternalServiceStoreGetLastSyncErrorFunc{
 			defaultHook: i.GetLastSyncError,
 		},
+		GetRepoLastSyncErrorFunc: &ExternalServiceStoreGetRepoLastSyncErrorFunc{
+			defaultHook: i.GetRepoLastSyncError,
+		},
+		GetSyncJobLogsFunc: &ExternalServiceStoreGetSyncJobLogsFunc{
+			defaultHook: i.GetSyncJobLogs,
+		},
 		GetSyncJobsFunc: &ExternalServiceStoreGetSyncJobsFunc{
 			defaultHook: i.GetSyncJobs,
 		},
+		GetSyncJobsPaginatedFunc: &ExternalServiceStoreGetSyncJobsPaginatedFunc{
+			defaultHook: i.GetSyncJobsPaginated,
+		},
 		HandleFunc: &ExternalServiceStoreHandleFunc{
 			defaultHook: i.Handle,
 		},
+		LastSuccessfulSyncFunc: &ExternalServiceStoreLastSuccessfulSyncFunc{
+			defaultHook: i.LastSuccessfulSync,
+		},
 		ListFunc: &ExternalServiceStoreListFunc{
 			defaultHook: i.List,
 		},
+		ListByNamespaceFunc: &ExternalServiceStoreListByNamespaceFunc{
+			defaultHook: i.ListByNamespace,
+		},
+		ListReposFunc: &ExternalServiceStoreListReposFunc{
+			defaultHook: i.ListRepos,
+		},
+		PauseFunc: &ExternalServiceStorePauseFunc{
+			defaultHook: i.Pause,
+		},
+		ReconcileExternalServiceReposFunc: &ExternalServiceStoreReconcileExternalServiceReposFunc{
+			defaultHook: i.ReconcileExternalServiceRepos,
+		},
 		RepoCountFunc: &ExternalServiceStoreRepoCountFunc{
 			defaultHook: i.RepoCount,
 		},
+		RequeueStuckSyncJobsFunc: &ExternalServiceStoreRequeueStuckSyncJobsFunc{
+			defaultHook: i.RequeueStuckSyncJobs,
+		},
+		ResumeFunc: &ExternalServiceStoreResumeFunc{
+			defaultHook: i.Resume,
+		},
+		SetUnrestrictedFunc: &ExternalServiceStoreSetUnrestrictedFunc{
+			defaultHook: i.SetUnrestricted,
+		},
 		SyncDueFunc: &ExternalServiceStoreSyncDueFunc{
 			defaultHook: i.SyncDue,
 		},
@@ -10988,35 +11326,37 @@ func NewMockExternalServiceStoreFrom(i ExternalServiceStore) *MockExternalServic
 	}
 }
 
-// ExternalServiceStoreCountFunc describes the behavior when the Count
-// method of the parent MockExternalServiceStore instance is invoked.
-type ExternalServiceStoreCountFunc struct {
-	defaultHook func(context.Context, ExternalServicesListOptions) (int, error)
-	hooks       []func(context.Context, ExternalServicesListOptions) (int, error)
-	history     []ExternalServiceStoreCountFuncCall
+// ExternalServiceStoreAppendSyncJobLogFunc describes the behavior when the
+// AppendSyncJobLog method of the parent MockExternalServiceStore instance
+// is invoked.
+type ExternalServiceStoreAppendSyncJobLogFunc struct {
+	defaultHook func(context.Context, int64, string) error
+	hooks       []func(context.Context, int64, string) error
+	history     []ExternalServiceStoreAppendSyncJobLogFuncCall
 	mutex       sync.Mutex
 }
 
-// Count delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockExternalServiceStore) Count(v0 context.Context, v1 ExternalServicesListOptions) (int, error) {
-	r0, r1 := m.CountFunc.nextHook()(v0, v1)
-	m.CountFunc.appendCall(ExternalServiceStoreCountFuncCall{v0, v1, r0, r1})
-	return r0, r1
+// AppendSyncJobLog delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockExternalServiceStore) AppendSyncJobLog(v0 context.Context, v1 int64, v2 string) error {
+	r0 := m.AppendSyncJobLogFunc.nextHook()(v0, v1, v2)
+	m.AppendSyncJobLogFunc.appendCall(ExternalServiceStoreAppendSyncJobLogFuncCall{v0, v1, v2, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the Count method of the
-// parent MockExternalServiceStore instance is invoked and the hook queue is
-// empty.
-func (f *ExternalServiceStoreCountFunc) SetDefaultHook(hook func(context.Context, ExternalServicesListOptions) (int, error)) {
+// SetDefaultHook sets function that is called when the AppendSyncJobLog
+// method of the parent MockExternalServiceStore instance is invoked and the
+// hook queue is empty.
+func (f *ExternalServiceStoreAppendSyncJobLogFunc) SetDefaultHook(hook func(context.Context, int64, string) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Count method of the parent MockExternalServiceStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *ExternalServiceStoreCountFunc) PushHook(hook func(context.Context, ExternalServicesListOptions) (int, error)) {
+// AppendSyncJobLog method of the parent MockExternalServiceStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *ExternalServiceStoreAppendSyncJobLogFunc) PushHook(hook func(context.Context, int64, string) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -11024,20 +11364,20 @@ func (f *ExternalServiceStoreCountFunc) PushHook(hook func(context.Context, Exte
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *ExternalServiceStoreCountFunc) SetDefaultReturn(r0 int, r1 error) {
-	f.SetDefaultHook(func(context.Context, ExternalServicesListOptions) (int, error) {
-		return r0, r1
+func (f *ExternalServiceStoreAppendSyncJobLogFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, string) error {
+		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *ExternalServiceStoreCountFunc) PushReturn(r0 int, r1 error) {
-	f.PushHook(func(context.Context, ExternalServicesListOptions) (int, error) {
-		return r0, r1
+func (f *ExternalServiceStoreAppendSyncJobLogFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, string) error {
+		return r0
 	})
 }
 
-func (f *ExternalServiceStoreCountFunc) nextHook() func(context.Context, ExternalServicesListOptions) (int, error) {
+func (f *ExternalServiceStoreAppendSyncJobLogFunc) nextHook() func(context.Context, int64, string) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -11050,81 +11390,85 @@ func (f *ExternalServiceStoreCountFunc) nextHook() func(context.Context, Externa
 	return hook
 }
 
-func (f *ExternalServiceStoreCountFunc) appendCall(r0 ExternalServiceStoreCountFuncCall) {
+func (f *ExternalServiceStoreAppendSyncJobLogFunc) appendCall(r0 ExternalServiceStoreAppendSyncJobLogFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of ExternalServiceStoreCountFuncCall objects
-// describing the invocations of this function.
-func (f *ExternalServiceStoreCountFunc) History() []ExternalServiceStoreCountFuncCall {
+// History returns a sequence of
+// ExternalServiceStoreAppendSyncJobLogFuncCall objects describing the
+// invocations of this function.
+func (f *ExternalServiceStoreAppendSyncJobLogFunc) History() []ExternalServiceStoreAppendSyncJobLogFuncCall {
 	f.mutex.Lock()
-	history := make([]ExternalServiceStoreCountFuncCall, len(f.history))
+	history := make([]ExternalServiceStoreAppendSyncJobLogFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ExternalServiceStoreCountFuncCall is an object that describes an
-// invocation of method Count on an instance of MockExternalServiceStore.
-type ExternalServiceStoreCountFuncCall struct {
+// ExternalServiceStoreAppendSyncJobLogFuncCall is an object that describes
+// an invocation of method AppendSyncJobLog on an instance of
+// MockExternalServiceStore.
+type ExternalServiceStoreAppendSyncJobLogFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 ExternalServicesListOptions
-	// Result0 is the value of the 1st result returned from this method
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Result0 int
-	// Result1 is the value of the 2nd result returned from this method
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result1 error
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c ExternalServiceStoreCountFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c ExternalServiceStoreAppendSyncJobLogFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ExternalServiceStoreCountFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c ExternalServiceStoreAppendSyncJobLogFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
-// ExternalServiceStoreCreateFunc describes the behavior when the Create
-// method of the parent MockExternalServiceStore instance is invoked.
-type ExternalServiceStoreCreateFunc struct {
-	defaultHook func(context.Context, func() *conf.Unified, *types.ExternalService) error
-	hooks       []func(context.Context, func() *conf.Unified, *types.ExternalService) error
-	history     []ExternalServiceStoreCreateFuncCall
+// ExternalServiceStoreBackfillHasWebhooksFunc describes the behavior when
+// the BackfillHasWebhooks method of the parent MockExternalServiceStore
+// instance is invoked.
+type ExternalServiceStoreBackfillHasWebhooksFunc struct {
+	defaultHook func(context.Context, int) (int, error)
+	hooks       []func(context.Context, int) (int, error)
+	history     []ExternalServiceStoreBackfillHasWebhooksFuncCall
 	mutex       sync.Mutex
 }
 
-// Create delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockExternalServiceStore) Create(v0 context.Context, v1 func() *conf.Unified, v2 *types.ExternalService) error {
-	r0 := m.CreateFunc.nextHook()(v0, v1, v2)
-	m.CreateFunc.appendCall(ExternalServiceStoreCreateFuncCall{v0, v1, v2, r0})
-	return r0
+// BackfillHasWebhooks delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockExternalServiceStore) BackfillHasWebhooks(v0 context.Context, v1 int) (int, error) {
+	r0, r1 := m.BackfillHasWebhooksFunc.nextHook()(v0, v1)
+	m.BackfillHasWebhooksFunc.appendCall(ExternalServiceStoreBackfillHasWebhooksFuncCall{v0, v1, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Create method of the
-// parent MockExternalServiceStore instance is invoked and the hook queue is
-// empty.
-func (f *ExternalServiceStoreCreateFunc) SetDefaultHook(hook func(context.Context, func() *conf.Unified, *types.ExternalService) error) {
+// SetDefaultHook sets function that is called when the BackfillHasWebhooks
+// method of the parent MockExternalServiceStore instance is invoked and the
+// hook queue is empty.
+func (f *ExternalServiceStoreBackfillHasWebhooksFunc) SetDefaultHook(hook func(context.Context, int) (int, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Create method of the parent MockExternalServiceStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *ExternalServiceStoreCreateFunc) PushHook(hook func(context.Context, func() *conf.Unified, *types.ExternalService) error) {
+// BackfillHasWebhooks method of the parent MockExternalServiceStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *ExternalServiceStoreBackfillHasWebhooksFunc) PushHook(hook func(context.Context, int) (int, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -11132,20 +11476,20 @@ func (f *ExternalServiceStoreCreateFunc) PushHook(hook func(context.Context, fun
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *ExternalServiceStoreCreateFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, func() *conf.Unified, *types.ExternalService) error {
-		return r0
+func (f *ExternalServiceStoreBackfillHasWebhooksFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) (int, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *ExternalServiceStoreCreateFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, func() *conf.Unified, *types.ExternalService) error {
-		return r0
+func (f *ExternalServiceStoreBackfillHasWebhooksFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, int) (int, error) {
+		return r0, r1
 	})
 }
 
-func (f *ExternalServiceStoreCreateFunc) nextHook() func(context.Context, func() *conf.Unified, *types.ExternalService) error {
+func (f *ExternalServiceStoreBackfillHasWebhooksFunc) nextHook() func(context.Context, int) (int, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -11158,81 +11502,2278 @@ func (f *ExternalServiceStoreCreateFunc) nextHook() func(context.Context, func()
 	return hook
 }
 
-func (f *ExternalServiceStoreCreateFunc) appendCall(r0 ExternalServiceStoreCreateFuncCall) {
+func (f *ExternalServiceStoreBackfillHasWebhooksFunc) appendCall(r0 ExternalServiceStoreBackfillHasWebhooksFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of ExternalServiceStoreCreateFuncCall objects
-// describing the invocations of this function.
-func (f *ExternalServiceStoreCreateFunc) History() []ExternalServiceStoreCreateFuncCall {
+// History returns a sequence of
+// ExternalServiceStoreBackfillHasWebhooksFuncCall objects describing the
+// invocations of this function.
+func (f *ExternalServiceStoreBackfillHasWebhooksFunc) History() []ExternalServiceStoreBackfillHasWebhooksFuncCall {
 	f.mutex.Lock()
-	history := make([]ExternalServiceStoreCreateFuncCall, len(f.history))
+	history := make([]ExternalServiceStoreBackfillHasWebhooksFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ExternalServiceStoreCreateFuncCall is an object that describes an
-// invocation of method Create on an instance of MockExternalServiceStore.
-type ExternalServiceStoreCreateFuncCall struct {
+// ExternalServiceStoreBackfillHasWebhooksFuncCall is an object that
+// describes an invocation of method BackfillHasWebhooks on an instance of
+// MockExternalServiceStore.
+type ExternalServiceStoreBackfillHasWebhooksFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 func() *conf.Unified
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 *types.ExternalService
+	Arg1 int
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
-}
-
-// Args returns an interface slice containing the arguments of this
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ExternalServiceStoreBackfillHasWebhooksFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ExternalServiceStoreBackfillHasWebhooksFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ExternalServiceStoreCountFunc describes the behavior when the Count
+// method of the parent MockExternalServiceStore instance is invoked.
+type ExternalServiceStoreCountFunc struct {
+	defaultHook func(context.Context, ExternalServicesListOptions) (int, error)
+	hooks       []func(context.Context, ExternalServicesListOptions) (int, error)
+	history     []ExternalServiceStoreCountFuncCall
+	mutex       sync.Mutex
+}
+
+// Count delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockExternalServiceStore) Count(v0 context.Context, v1 ExternalServicesListOptions) (int, error) {
+	r0, r1 := m.CountFunc.nextHook()(v0, v1)
+	m.CountFunc.appendCall(ExternalServiceStoreCountFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Count method of the
+// parent MockExternalServiceStore instance is invoked and the hook queue is
+// empty.
+func (f *ExternalServiceStoreCountFunc) SetDefaultHook(hook func(context.Context, ExternalServicesListOptions) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Count method of the parent MockExternalServiceStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *ExternalServiceStoreCountFunc) PushHook(hook func(context.Context, ExternalServicesListOptions) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ExternalServiceStoreCountFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, ExternalServicesListOptions) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ExternalServiceStoreCountFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, ExternalServicesListOptions) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *ExternalServiceStoreCountFunc) nextHook() func(context.Context, ExternalServicesListOptions) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ExternalServiceStoreCountFunc) appendCall(r0 ExternalServiceStoreCountFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ExternalServiceStoreCountFuncCall objects
+// describing the invocations of this function.
+func (f *ExternalServiceStoreCountFunc) History() []ExternalServiceStoreCountFuncCall {
+	f.mutex.Lock()
+	history := make([]ExternalServiceStoreCountFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ExternalServiceStoreCountFuncCall is an object that describes an
+// invocation of method Count on an instance of MockExternalServiceStore.
+type ExternalServiceStoreCountFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 ExternalServicesListOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ExternalServiceStoreCountFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ExternalServiceStoreCountFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ExternalServiceStoreCountReposByNamespaceFunc describes the behavior when
+// the CountReposByNamespace method of the parent MockExternalServiceStore
+// instance is invoked.
+type ExternalServiceStoreCountReposByNamespaceFunc struct {
+	defaultHook func(context.Context, int32, int32) (int32, error)
+	hooks       []func(context.Context, int32, int32) (int32, error)
+	history     []ExternalServiceStoreCountReposByNamespaceFuncCall
+	mutex       sync.Mutex
+}
+
+// CountReposByNamespace delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockExternalServiceStore) CountReposByNamespace(v0 context.Context, v1 int32, v2 int32) (int32, error) {
+	r0, r1 := m.CountReposByNamespaceFunc.nextHook()(v0, v1, v2)
+	m.CountReposByNamespaceFunc.appendCall(ExternalServiceStoreCountReposByNamespaceFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// CountReposByNamespace method of the parent MockExternalServiceStore
+// instance is invoked and the hook queue is empty.
+func (f *ExternalServiceStoreCountReposByNamespaceFunc) SetDefaultHook(hook func(context.Context, int32, int32) (int32, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CountReposByNamespace method of the parent MockExternalServiceStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *ExternalServiceStoreCountReposByNamespaceFunc) PushHook(hook func(context.Context, int32, int32) (int32, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ExternalServiceStoreCountReposByNamespaceFunc) SetDefaultReturn(r0 int32, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32, int32) (int32, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ExternalServiceStoreCountReposByNamespaceFunc) PushReturn(r0 int32, r1 error) {
+	f.PushHook(func(context.Context, int32, int32) (int32, error) {
+		return r0, r1
+	})
+}
+
+func (f *ExternalServiceStoreCountReposByNamespaceFunc) nextHook() func(context.Context, int32, int32) (int32, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ExternalServiceStoreCountReposByNamespaceFunc) appendCall(r0 ExternalServiceStoreCountReposByNamespaceFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// ExternalServiceStoreCountReposByNamespaceFuncCall objects describing the
+// invocations of this function.
+func (f *ExternalServiceStoreCountReposByNamespaceFunc) History() []ExternalServiceStoreCountReposByNamespaceFuncCall {
+	f.mutex.Lock()
+	history := make([]ExternalServiceStoreCountReposByNamespaceFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ExternalServiceStoreCountReposByNamespaceFuncCall is an object that
+// describes an invocation of method CountReposByNamespace on an instance of
+// MockExternalServiceStore.
+type ExternalServiceStoreCountReposByNamespaceFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int32
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ExternalServiceStoreCountReposByNamespaceFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ExternalServiceStoreCountReposByNamespaceFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ExternalServiceStoreCountSyncJobsByStateFunc describes the behavior when
+// the CountSyncJobsByState method of the parent MockExternalServiceStore
+// instance is invoked.
+type ExternalServiceStoreCountSyncJobsByStateFunc struct {
+	defaultHook func(context.Context) (map[string]int, error)
+	hooks       []func(context.Context) (map[string]int, error)
+	history     []ExternalServiceStoreCountSyncJobsByStateFuncCall
+	mutex       sync.Mutex
+}
+
+// CountSyncJobsByState delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockExternalServiceStore) CountSyncJobsByState(v0 context.Context) (map[string]int, error) {
+	r0, r1 := m.CountSyncJobsByStateFunc.nextHook()(v0)
+	m.CountSyncJobsByStateFunc.appendCall(ExternalServiceStoreCountSyncJobsByStateFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CountSyncJobsByState
+// method of the parent MockExternalServiceStore instance is invoked and the
+// hook queue is empty.
+func (f *ExternalServiceStoreCountSyncJobsByStateFunc) SetDefaultHook(hook func(context.Context) (map[string]int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CountSyncJobsByState method of the parent MockExternalServiceStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *ExternalServiceStoreCountSyncJobsByStateFunc) PushHook(hook func(context.Context) (map[string]int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ExternalServiceStoreCountSyncJobsByStateFunc) SetDefaultReturn(r0 map[string]int, r1 error) {
+	f.SetDefaultHook(func(context.Context) (map[string]int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ExternalServiceStoreCountSyncJobsByStateFunc) PushReturn(r0 map[string]int, r1 error) {
+	f.PushHook(func(context.Context) (map[string]int, error) {
+		return r0, r1
+	})
+}
+
+func (f *ExternalServiceStoreCountSyncJobsByStateFunc) nextHook() func(context.Context) (map[string]int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ExternalServiceStoreCountSyncJobsByStateFunc) appendCall(r0 ExternalServiceStoreCountSyncJobsByStateFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// ExternalServiceStoreCountSyncJobsByStateFuncCall objects describing the
+// invocations of this function.
+func (f *ExternalServiceStoreCountSyncJobsByStateFunc) History() []ExternalServiceStoreCountSyncJobsByStateFuncCall {
+	f.mutex.Lock()
+	history := make([]ExternalServiceStoreCountSyncJobsByStateFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ExternalServiceStoreCountSyncJobsByStateFuncCall is an object that
+// describes an invocation of method CountSyncJobsByState on an instance of
+// MockExternalServiceStore.
+type ExternalServiceStoreCountSyncJobsByStateFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[string]int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ExternalServiceStoreCountSyncJobsByStateFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ExternalServiceStoreCountSyncJobsByStateFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ExternalServiceStoreCreateFunc describes the behavior when the Create
+// method of the parent MockExternalServiceStore instance is invoked.
+type ExternalServiceStoreCreateFunc struct {
+	defaultHook func(context.Context, func() *conf.Unified, *types.ExternalService) error
+	hooks       []func(context.Context, func() *conf.Unified, *types.ExternalService) error
+	history     []ExternalServiceStoreCreateFuncCall
+	mutex       sync.Mutex
+}
+
+// Create delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockExternalServiceStore) Create(v0 context.Context, v1 func() *conf.Unified, v2 *types.ExternalService) error {
+	r0 := m.CreateFunc.nextHook()(v0, v1, v2)
+	m.CreateFunc.appendCall(ExternalServiceStoreCreateFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Create method of the
+// parent MockExternalServiceStore instance is invoked and the hook queue is
+// empty.
+func (f *ExternalServiceStoreCreateFunc) SetDefaultHook(hook func(context.Context, func() *conf.Unified, *types.ExternalService) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Create method of the parent MockExternalServiceStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *ExternalServiceStoreCreateFunc) PushHook(hook func(context.Context, func() *conf.Unified, *types.ExternalService) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ExternalServiceStoreCreateFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, func() *conf.Unified, *types.ExternalService) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ExternalServiceStoreCreateFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, func() *conf.Unified, *types.ExternalService) error {
+		return r0
+	})
+}
+
+func (f *ExternalServiceStoreCreateFunc) nextHook() func(context.Context, func() *conf.Unified, *types.ExternalService) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ExternalServiceStoreCreateFunc) appendCall(r0 ExternalServiceStoreCreateFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ExternalServiceStoreCreateFuncCall objects
+// describing the invocations of this function.
+func (f *ExternalServiceStoreCreateFunc) History() []ExternalServiceStoreCreateFuncCall {
+	f.mutex.Lock()
+	history := make([]ExternalServiceStoreCreateFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ExternalServiceStoreCreateFuncCall is an object that describes an
+// invocation of method Create on an instance of MockExternalServiceStore.
+type ExternalServiceStoreCreateFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 func() *conf.Unified
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 *types.ExternalService
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ExternalServiceStoreCreateFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ExternalServiceStoreCreateFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ExternalServiceStoreDeleteFunc describes the behavior when the Delete
+// method of the parent MockExternalServiceStore instance is invoked.
+type ExternalServiceStoreDeleteFunc struct {
+	defaultHook func(context.Context, int64) error
+	hooks       []func(context.Context, int64) error
+	history     []ExternalServiceStoreDeleteFuncCall
+	mutex       sync.Mutex
+}
+
+// Delete delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockExternalServiceStore) Delete(v0 context.Context, v1 int64) error {
+	r0 := m.DeleteFunc.nextHook()(v0, v1)
+	m.DeleteFunc.appendCall(ExternalServiceStoreDeleteFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Delete method of the
+// parent MockExternalServiceStore instance is invoked and the hook queue is
+// empty.
+func (f *ExternalServiceStoreDeleteFunc) SetDefaultHook(hook func(context.Context, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Delete method of the parent MockExternalServiceStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *ExternalServiceStoreDeleteFunc) PushHook(hook func(context.Context, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ExternalServiceStoreDeleteFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ExternalServiceStoreDeleteFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+func (f *ExternalServiceStoreDeleteFunc) nextHook() func(context.Context, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ExternalServiceStoreDeleteFunc) appendCall(r0 ExternalServiceStoreDeleteFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ExternalServiceStoreDeleteFuncCall objects
+// describing the invocations of this function.
+func (f *ExternalServiceStoreDeleteFunc) History() []ExternalServiceStoreDeleteFuncCall {
+	f.mutex.Lock()
+	history := make([]ExternalServiceStoreDeleteFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ExternalServiceStoreDeleteFuncCall is an object that describes an
+// invocation of method Delete on an instance of MockExternalServiceStore.
+type ExternalServiceStoreDeleteFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ExternalServiceStoreDeleteFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ExternalServiceStoreDeleteFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ExternalServiceStoreDistinctKindsFunc describes the behavior when the
+// DistinctKinds method of the parent MockExternalServiceStore instance is
+// invoked.
+type ExternalServiceStoreDistinctKindsFunc struct {
+	defaultHook func(context.Context) ([]string, error)
+	hooks       []func(context.Context) ([]string, error)
+	history     []ExternalServiceStoreDistinctKindsFuncCall
+	mutex       sync.Mutex
+}
+
+// DistinctKinds delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockExternalServiceStore) DistinctKinds(v0 context.Context) ([]string, error) {
+	r0, r1 := m.DistinctKindsFunc.nextHook()(v0)
+	m.DistinctKindsFunc.appendCall(ExternalServiceStoreDistinctKindsFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the DistinctKinds method
+// of the parent MockExternalServiceStore instance is invoked and the hook
+// queue is empty.
+func (f *ExternalServiceStoreDistinctKindsFunc) SetDefaultHook(hook func(context.Context) ([]string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DistinctKinds method of the parent MockExternalServiceStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *ExternalServiceStoreDistinctKindsFunc) PushHook(hook func(context.Context) ([]string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ExternalServiceStoreDistinctKindsFunc) SetDefaultReturn(r0 []string, r1 error) {
+	f.SetDefaultHook(func(context.Context) ([]string, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ExternalServiceStoreDistinctKindsFunc) PushReturn(r0 []string, r1 error) {
+	f.PushHook(func(context.Context) ([]string, error) {
+		return r0, r1
+	})
+}
+
+func (f *ExternalServiceStoreDistinctKindsFunc) nextHook() func(context.Context) ([]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ExternalServiceStoreDistinctKindsFunc) appendCall(r0 ExternalServiceStoreDistinctKindsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ExternalServiceStoreDistinctKindsFuncCall
+// objects describing the invocations of this function.
+func (f *ExternalServiceStoreDistinctKindsFunc) History() []ExternalServiceStoreDistinctKindsFuncCall {
+	f.mutex.Lock()
+	history := make([]ExternalServiceStoreDistinctKindsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ExternalServiceStoreDistinctKindsFuncCall is an object that describes an
+// invocation of method DistinctKinds on an instance of
+// MockExternalServiceStore.
+type ExternalServiceStoreDistinctKindsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ExternalServiceStoreDistinctKindsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ExternalServiceStoreDistinctKindsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ExternalServiceStoreDoneFunc describes the behavior when the Done method
+// of the parent MockExternalServiceStore instance is invoked.
+type ExternalServiceStoreDoneFunc struct {
+	defaultHook func(error) error
+	hooks       []func(error) error
+	history     []ExternalServiceStoreDoneFuncCall
+	mutex       sync.Mutex
+}
+
+// Done delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockExternalServiceStore) Done(v0 error) error {
+	r0 := m.DoneFunc.nextHook()(v0)
+	m.DoneFunc.appendCall(ExternalServiceStoreDoneFuncCall{v0, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Done method of the
+// parent MockExternalServiceStore instance is invoked and the hook queue is
+// empty.
+func (f *ExternalServiceStoreDoneFunc) SetDefaultHook(hook func(error) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Done method of the parent MockExternalServiceStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *ExternalServiceStoreDoneFunc) PushHook(hook func(error) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ExternalServiceStoreDoneFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(error) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ExternalServiceStoreDoneFunc) PushReturn(r0 error) {
+	f.PushHook(func(error) error {
+		return r0
+	})
+}
+
+func (f *ExternalServiceStoreDoneFunc) nextHook() func(error) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ExternalServiceStoreDoneFunc) appendCall(r0 ExternalServiceStoreDoneFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ExternalServiceStoreDoneFuncCall objects
+// describing the invocations of this function.
+func (f *ExternalServiceStoreDoneFunc) History() []ExternalServiceStoreDoneFuncCall {
+	f.mutex.Lock()
+	history := make([]ExternalServiceStoreDoneFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ExternalServiceStoreDoneFuncCall is an object that describes an
+// invocation of method Done on an instance of MockExternalServiceStore.
+type ExternalServiceStoreDoneFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 error
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ExternalServiceStoreDoneFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ExternalServiceStoreDoneFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ExternalServiceStoreEffectiveRateLimitFunc describes the behavior when
+// the EffectiveRateLimit method of the parent MockExternalServiceStore
+// instance is invoked.
+type ExternalServiceStoreEffectiveRateLimitFunc struct {
+	defaultHook func(context.Context, int64) (float64, string, error)
+	hooks       []func(context.Context, int64) (float64, string, error)
+	history     []ExternalServiceStoreEffectiveRateLimitFuncCall
+	mutex       sync.Mutex
+}
+
+// EffectiveRateLimit delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockExternalServiceStore) EffectiveRateLimit(v0 context.Context, v1 int64) (float64, string, error) {
+	r0, r1, r2 := m.EffectiveRateLimitFunc.nextHook()(v0, v1)
+	m.EffectiveRateLimitFunc.appendCall(ExternalServiceStoreEffectiveRateLimitFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the EffectiveRateLimit
+// method of the parent MockExternalServiceStore instance is invoked and the
+// hook queue is empty.
+func (f *ExternalServiceStoreEffectiveRateLimitFunc) SetDefaultHook(hook func(context.Context, int64) (float64, string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// EffectiveRateLimit method of the parent MockExternalServiceStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *ExternalServiceStoreEffectiveRateLimitFunc) PushHook(hook func(context.Context, int64) (float64, string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ExternalServiceStoreEffectiveRateLimitFunc) SetDefaultReturn(r0 float64, r1 string, r2 error) {
+	f.SetDefaultHook(func(context.Context, int64) (float64, string, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ExternalServiceStoreEffectiveRateLimitFunc) PushReturn(r0 float64, r1 string, r2 error) {
+	f.PushHook(func(context.Context, int64) (float64, string, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *ExternalServiceStoreEffectiveRateLimitFunc) nextHook() func(context.Context, int64) (float64, string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ExternalServiceStoreEffectiveRateLimitFunc) appendCall(r0 ExternalServiceStoreEffectiveRateLimitFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// ExternalServiceStoreEffectiveRateLimitFuncCall objects describing the
+// invocations of this function.
+func (f *ExternalServiceStoreEffectiveRateLimitFunc) History() []ExternalServiceStoreEffectiveRateLimitFuncCall {
+	f.mutex.Lock()
+	history := make([]ExternalServiceStoreEffectiveRateLimitFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ExternalServiceStoreEffectiveRateLimitFuncCall is an object that
+// describes an invocation of method EffectiveRateLimit on an instance of
+// MockExternalServiceStore.
+type ExternalServiceStoreEffectiveRateLimitFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 float64
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 string
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ExternalServiceStoreEffectiveRateLimitFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ExternalServiceStoreEffectiveRateLimitFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// ExternalServiceStoreExportConfigsFunc describes the behavior when the
+// ExportConfigs method of the parent MockExternalServiceStore instance is
+// invoked.
+type ExternalServiceStoreExportConfigsFunc struct {
+	defaultHook func(context.Context) ([]RedactedExternalService, error)
+	hooks       []func(context.Context) ([]RedactedExternalService, error)
+	history     []ExternalServiceStoreExportConfigsFuncCall
+	mutex       sync.Mutex
+}
+
+// ExportConfigs delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockExternalServiceStore) ExportConfigs(v0 context.Context) ([]RedactedExternalService, error) {
+	r0, r1 := m.ExportConfigsFunc.nextHook()(v0)
+	m.ExportConfigsFunc.appendCall(ExternalServiceStoreExportConfigsFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ExportConfigs method
+// of the parent MockExternalServiceStore instance is invoked and the hook
+// queue is empty.
+func (f *ExternalServiceStoreExportConfigsFunc) SetDefaultHook(hook func(context.Context) ([]RedactedExternalService, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ExportConfigs method of the parent MockExternalServiceStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *ExternalServiceStoreExportConfigsFunc) PushHook(hook func(context.Context) ([]RedactedExternalService, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ExternalServiceStoreExportConfigsFunc) SetDefaultReturn(r0 []RedactedExternalService, r1 error) {
+	f.SetDefaultHook(func(context.Context) ([]RedactedExternalService, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ExternalServiceStoreExportConfigsFunc) PushReturn(r0 []RedactedExternalService, r1 error) {
+	f.PushHook(func(context.Context) ([]RedactedExternalService, error) {
+		return r0, r1
+	})
+}
+
+func (f *ExternalServiceStoreExportConfigsFunc) nextHook() func(context.Context) ([]RedactedExternalService, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ExternalServiceStoreExportConfigsFunc) appendCall(r0 ExternalServiceStoreExportConfigsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ExternalServiceStoreExportConfigsFuncCall
+// objects describing the invocations of this function.
+func (f *ExternalServiceStoreExportConfigsFunc) History() []ExternalServiceStoreExportConfigsFuncCall {
+	f.mutex.Lock()
+	history := make([]ExternalServiceStoreExportConfigsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ExternalServiceStoreExportConfigsFuncCall is an object that describes an
+// invocation of method ExportConfigs on an instance of
+// MockExternalServiceStore.
+type ExternalServiceStoreExportConfigsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []RedactedExternalService
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ExternalServiceStoreExportConfigsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ExternalServiceStoreExportConfigsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ExternalServiceStoreFindDuplicatesFunc describes the behavior when the
+// FindDuplicates method of the parent MockExternalServiceStore instance is
+// invoked.
+type ExternalServiceStoreFindDuplicatesFunc struct {
+	defaultHook func(context.Context) ([][]*types.ExternalService, error)
+	hooks       []func(context.Context) ([][]*types.ExternalService, error)
+	history     []ExternalServiceStoreFindDuplicatesFuncCall
+	mutex       sync.Mutex
+}
+
+// FindDuplicates delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockExternalServiceStore) FindDuplicates(v0 context.Context) ([][]*types.ExternalService, error) {
+	r0, r1 := m.FindDuplicatesFunc.nextHook()(v0)
+	m.FindDuplicatesFunc.appendCall(ExternalServiceStoreFindDuplicatesFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the FindDuplicates
+// method of the parent MockExternalServiceStore instance is invoked and the
+// hook queue is empty.
+func (f *ExternalServiceStoreFindDuplicatesFunc) SetDefaultHook(hook func(context.Context) ([][]*types.ExternalService, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// FindDuplicates method of the parent MockExternalServiceStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *ExternalServiceStoreFindDuplicatesFunc) PushHook(hook func(context.Context) ([][]*types.ExternalService, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ExternalServiceStoreFindDuplicatesFunc) SetDefaultReturn(r0 [][]*types.ExternalService, r1 error) {
+	f.SetDefaultHook(func(context.Context) ([][]*types.ExternalService, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ExternalServiceStoreFindDuplicatesFunc) PushReturn(r0 [][]*types.ExternalService, r1 error) {
+	f.PushHook(func(context.Context) ([][]*types.ExternalService, error) {
+		return r0, r1
+	})
+}
+
+func (f *ExternalServiceStoreFindDuplicatesFunc) nextHook() func(context.Context) ([][]*types.ExternalService, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ExternalServiceStoreFindDuplicatesFunc) appendCall(r0 ExternalServiceStoreFindDuplicatesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ExternalServiceStoreFindDuplicatesFuncCall
+// objects describing the invocations of this function.
+func (f *ExternalServiceStoreFindDuplicatesFunc) History() []ExternalServiceStoreFindDuplicatesFuncCall {
+	f.mutex.Lock()
+	history := make([]ExternalServiceStoreFindDuplicatesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ExternalServiceStoreFindDuplicatesFuncCall is an object that describes an
+// invocation of method FindDuplicates on an instance of
+// MockExternalServiceStore.
+type ExternalServiceStoreFindDuplicatesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 [][]*types.ExternalService
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ExternalServiceStoreFindDuplicatesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ExternalServiceStoreFindDuplicatesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ExternalServiceStoreGetAffiliatedSyncErrorDetailsFunc describes the
+// behavior when the GetAffiliatedSyncErrorDetails method of the parent
+// MockExternalServiceStore instance is invoked.
+type ExternalServiceStoreGetAffiliatedSyncErrorDetailsFunc struct {
+	defaultHook func(context.Context, *types.User) ([]SyncErrorDetail, error)
+	hooks       []func(context.Context, *types.User) ([]SyncErrorDetail, error)
+	history     []ExternalServiceStoreGetAffiliatedSyncErrorDetailsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetAffiliatedSyncErrorDetails delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockExternalServiceStore) GetAffiliatedSyncErrorDetails(v0 context.Context, v1 *types.User) ([]SyncErrorDetail, error) {
+	r0, r1 := m.GetAffiliatedSyncErrorDetailsFunc.nextHook()(v0, v1)
+	m.GetAffiliatedSyncErrorDetailsFunc.appendCall(ExternalServiceStoreGetAffiliatedSyncErrorDetailsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetAffiliatedSyncErrorDetails method of the parent
+// MockExternalServiceStore instance is invoked and the hook queue is empty.
+func (f *ExternalServiceStoreGetAffiliatedSyncErrorDetailsFunc) SetDefaultHook(hook func(context.Context, *types.User) ([]SyncErrorDetail, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetAffiliatedSyncErrorDetails method of the parent
+// MockExternalServiceStore instance invokes the hook at the front of the
+// queue and discards it. After the queue is empty, the default hook
+// function is invoked for any future action.
+func (f *ExternalServiceStoreGetAffiliatedSyncErrorDetailsFunc) PushHook(hook func(context.Context, *types.User) ([]SyncErrorDetail, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ExternalServiceStoreGetAffiliatedSyncErrorDetailsFunc) SetDefaultReturn(r0 []SyncErrorDetail, r1 error) {
+	f.SetDefaultHook(func(context.Context, *types.User) ([]SyncErrorDetail, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ExternalServiceStoreGetAffiliatedSyncErrorDetailsFunc) PushReturn(r0 []SyncErrorDetail, r1 error) {
+	f.PushHook(func(context.Context, *types.User) ([]SyncErrorDetail, error) {
+		return r0, r1
+	})
+}
+
+func (f *ExternalServiceStoreGetAffiliatedSyncErrorDetailsFunc) nextHook() func(context.Context, *types.User) ([]SyncErrorDetail, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ExternalServiceStoreGetAffiliatedSyncErrorDetailsFunc) appendCall(r0 ExternalServiceStoreGetAffiliatedSyncErrorDetailsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// ExternalServiceStoreGetAffiliatedSyncErrorDetailsFuncCall objects
+// describing the invocations of this function.
+func (f *ExternalServiceStoreGetAffiliatedSyncErrorDetailsFunc) History() []ExternalServiceStoreGetAffiliatedSyncErrorDetailsFuncCall {
+	f.mutex.Lock()
+	history := make([]ExternalServiceStoreGetAffiliatedSyncErrorDetailsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ExternalServiceStoreGetAffiliatedSyncErrorDetailsFuncCall is an object
+// that describes an invocation of method GetAffiliatedSyncErrorDetails on
+// an instance of MockExternalServiceStore.
+type ExternalServiceStoreGetAffiliatedSyncErrorDetailsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *types.User
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []SyncErrorDetail
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ExternalServiceStoreGetAffiliatedSyncErrorDetailsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ExternalServiceStoreGetAffiliatedSyncErrorDetailsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ExternalServiceStoreGetAffiliatedSyncErrorsFunc describes the behavior
+// when the GetAffiliatedSyncErrors method of the parent
+// MockExternalServiceStore instance is invoked.
+type ExternalServiceStoreGetAffiliatedSyncErrorsFunc struct {
+	defaultHook func(context.Context, *types.User) (map[int64]string, error)
+	hooks       []func(context.Context, *types.User) (map[int64]string, error)
+	history     []ExternalServiceStoreGetAffiliatedSyncErrorsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetAffiliatedSyncErrors delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockExternalServiceStore) GetAffiliatedSyncErrors(v0 context.Context, v1 *types.User) (map[int64]string, error) {
+	r0, r1 := m.GetAffiliatedSyncErrorsFunc.nextHook()(v0, v1)
+	m.GetAffiliatedSyncErrorsFunc.appendCall(ExternalServiceStoreGetAffiliatedSyncErrorsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetAffiliatedSyncErrors method of the parent MockExternalServiceStore
+// instance is invoked and the hook queue is empty.
+func (f *ExternalServiceStoreGetAffiliatedSyncErrorsFunc) SetDefaultHook(hook func(context.Context, *types.User) (map[int64]string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetAffiliatedSyncErrors method of the parent MockExternalServiceStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *ExternalServiceStoreGetAffiliatedSyncErrorsFunc) PushHook(hook func(context.Context, *types.User) (map[int64]string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ExternalServiceStoreGetAffiliatedSyncErrorsFunc) SetDefaultReturn(r0 map[int64]string, r1 error) {
+	f.SetDefaultHook(func(context.Context, *types.User) (map[int64]string, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ExternalServiceStoreGetAffiliatedSyncErrorsFunc) PushReturn(r0 map[int64]string, r1 error) {
+	f.PushHook(func(context.Context, *types.User) (map[int64]string, error) {
+		return r0, r1
+	})
+}
+
+func (f *ExternalServiceStoreGetAffiliatedSyncErrorsFunc) nextHook() func(context.Context, *types.User) (map[int64]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ExternalServiceStoreGetAffiliatedSyncErrorsFunc) appendCall(r0 ExternalServiceStoreGetAffiliatedSyncErrorsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// ExternalServiceStoreGetAffiliatedSyncErrorsFuncCall objects describing
+// the invocations of this function.
+func (f *ExternalServiceStoreGetAffiliatedSyncErrorsFunc) History() []ExternalServiceStoreGetAffiliatedSyncErrorsFuncCall {
+	f.mutex.Lock()
+	history := make([]ExternalServiceStoreGetAffiliatedSyncErrorsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ExternalServiceStoreGetAffiliatedSyncErrorsFuncCall is an object that
+// describes an invocation of method GetAffiliatedSyncErrors on an instance
+// of MockExternalServiceStore.
+type ExternalServiceStoreGetAffiliatedSyncErrorsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *types.User
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[int64]string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ExternalServiceStoreGetAffiliatedSyncErrorsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ExternalServiceStoreGetAffiliatedSyncErrorsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ExternalServiceStoreGetByIDFunc describes the behavior when the GetByID
+// method of the parent MockExternalServiceStore instance is invoked.
+type ExternalServiceStoreGetByIDFunc struct {
+	defaultHook func(context.Context, int64) (*types.ExternalService, error)
+	hooks       []func(context.Context, int64) (*types.ExternalService, error)
+	history     []ExternalServiceStoreGetByIDFuncCall
+	mutex       sync.Mutex
+}
+
+// GetByID delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockExternalServiceStore) GetByID(v0 context.Context, v1 int64) (*types.ExternalService, error) {
+	r0, r1 := m.GetByIDFunc.nextHook()(v0, v1)
+	m.GetByIDFunc.appendCall(ExternalServiceStoreGetByIDFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetByID method of
+// the parent MockExternalServiceStore instance is invoked and the hook
+// queue is empty.
+func (f *ExternalServiceStoreGetByIDFunc) SetDefaultHook(hook func(context.Context, int64) (*types.ExternalService, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetByID method of the parent MockExternalServiceStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *ExternalServiceStoreGetByIDFunc) PushHook(hook func(context.Context, int64) (*types.ExternalService, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ExternalServiceStoreGetByIDFunc) SetDefaultReturn(r0 *types.ExternalService, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (*types.ExternalService, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ExternalServiceStoreGetByIDFunc) PushReturn(r0 *types.ExternalService, r1 error) {
+	f.PushHook(func(context.Context, int64) (*types.ExternalService, error) {
+		return r0, r1
+	})
+}
+
+func (f *ExternalServiceStoreGetByIDFunc) nextHook() func(context.Context, int64) (*types.ExternalService, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ExternalServiceStoreGetByIDFunc) appendCall(r0 ExternalServiceStoreGetByIDFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ExternalServiceStoreGetByIDFuncCall objects
+// describing the invocations of this function.
+func (f *ExternalServiceStoreGetByIDFunc) History() []ExternalServiceStoreGetByIDFuncCall {
+	f.mutex.Lock()
+	history := make([]ExternalServiceStoreGetByIDFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ExternalServiceStoreGetByIDFuncCall is an object that describes an
+// invocation of method GetByID on an instance of MockExternalServiceStore.
+type ExternalServiceStoreGetByIDFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *types.ExternalService
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ExternalServiceStoreGetByIDFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ExternalServiceStoreGetByIDFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ExternalServiceStoreGetByIDsFunc describes the behavior when the GetByIDs
+// method of the parent MockExternalServiceStore instance is invoked.
+type ExternalServiceStoreGetByIDsFunc struct {
+	defaultHook func(context.Context, []int64) ([]*types.ExternalService, error)
+	hooks       []func(context.Context, []int64) ([]*types.ExternalService, error)
+	history     []ExternalServiceStoreGetByIDsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetByIDs delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockExternalServiceStore) GetByIDs(v0 context.Context, v1 []int64) ([]*types.ExternalService, error) {
+	r0, r1 := m.GetByIDsFunc.nextHook()(v0, v1)
+	m.GetByIDsFunc.appendCall(ExternalServiceStoreGetByIDsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetByIDs method of
+// the parent MockExternalServiceStore instance is invoked and the hook
+// queue is empty.
+func (f *ExternalServiceStoreGetByIDsFunc) SetDefaultHook(hook func(context.Context, []int64) ([]*types.ExternalService, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetByIDs method of the parent MockExternalServiceStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *ExternalServiceStoreGetByIDsFunc) PushHook(hook func(context.Context, []int64) ([]*types.ExternalService, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ExternalServiceStoreGetByIDsFunc) SetDefaultReturn(r0 []*types.ExternalService, r1 error) {
+	f.SetDefaultHook(func(context.Context, []int64) ([]*types.ExternalService, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ExternalServiceStoreGetByIDsFunc) PushReturn(r0 []*types.ExternalService, r1 error) {
+	f.PushHook(func(context.Context, []int64) ([]*types.ExternalService, error) {
+		return r0, r1
+	})
+}
+
+func (f *ExternalServiceStoreGetByIDsFunc) nextHook() func(context.Context, []int64) ([]*types.ExternalService, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ExternalServiceStoreGetByIDsFunc) appendCall(r0 ExternalServiceStoreGetByIDsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ExternalServiceStoreGetByIDsFuncCall
+// objects describing the invocations of this function.
+func (f *ExternalServiceStoreGetByIDsFunc) History() []ExternalServiceStoreGetByIDsFuncCall {
+	f.mutex.Lock()
+	history := make([]ExternalServiceStoreGetByIDsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ExternalServiceStoreGetByIDsFuncCall is an object that describes an
+// invocation of method GetByIDs on an instance of MockExternalServiceStore.
+type ExternalServiceStoreGetByIDsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*types.ExternalService
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ExternalServiceStoreGetByIDsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ExternalServiceStoreGetByIDsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ExternalServiceStoreGetCloudDefaultFunc describes the behavior when the
+// GetCloudDefault method of the parent MockExternalServiceStore instance is
+// invoked.
+type ExternalServiceStoreGetCloudDefaultFunc struct {
+	defaultHook func(context.Context, string) (*types.ExternalService, error)
+	hooks       []func(context.Context, string) (*types.ExternalService, error)
+	history     []ExternalServiceStoreGetCloudDefaultFuncCall
+	mutex       sync.Mutex
+}
+
+// GetCloudDefault delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockExternalServiceStore) GetCloudDefault(v0 context.Context, v1 string) (*types.ExternalService, error) {
+	r0, r1 := m.GetCloudDefaultFunc.nextHook()(v0, v1)
+	m.GetCloudDefaultFunc.appendCall(ExternalServiceStoreGetCloudDefaultFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetCloudDefault
+// method of the parent MockExternalServiceStore instance is invoked and the
+// hook queue is empty.
+func (f *ExternalServiceStoreGetCloudDefaultFunc) SetDefaultHook(hook func(context.Context, string) (*types.ExternalService, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetCloudDefault method of the parent MockExternalServiceStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *ExternalServiceStoreGetCloudDefaultFunc) PushHook(hook func(context.Context, string) (*types.ExternalService, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ExternalServiceStoreGetCloudDefaultFunc) SetDefaultReturn(r0 *types.ExternalService, r1 error) {
+	f.SetDefaultHook(func(context.Context, string) (*types.ExternalService, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ExternalServiceStoreGetCloudDefaultFunc) PushReturn(r0 *types.ExternalService, r1 error) {
+	f.PushHook(func(context.Context, string) (*types.ExternalService, error) {
+		return r0, r1
+	})
+}
+
+func (f *ExternalServiceStoreGetCloudDefaultFunc) nextHook() func(context.Context, string) (*types.ExternalService, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ExternalServiceStoreGetCloudDefaultFunc) appendCall(r0 ExternalServiceStoreGetCloudDefaultFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ExternalServiceStoreGetCloudDefaultFuncCall
+// objects describing the invocations of this function.
+func (f *ExternalServiceStoreGetCloudDefaultFunc) History() []ExternalServiceStoreGetCloudDefaultFuncCall {
+	f.mutex.Lock()
+	history := make([]ExternalServiceStoreGetCloudDefaultFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ExternalServiceStoreGetCloudDefaultFuncCall is an object that describes
+// an invocation of method GetCloudDefault on an instance of
+// MockExternalServiceStore.
+type ExternalServiceStoreGetCloudDefaultFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *types.ExternalService
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ExternalServiceStoreGetCloudDefaultFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ExternalServiceStoreGetCloudDefaultFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ExternalServiceStoreGetLastSyncErrorFunc describes the behavior when the
+// GetLastSyncError method of the parent MockExternalServiceStore instance
+// is invoked.
+type ExternalServiceStoreGetLastSyncErrorFunc struct {
+	defaultHook func(context.Context, int64) (string, error)
+	hooks       []func(context.Context, int64) (string, error)
+	history     []ExternalServiceStoreGetLastSyncErrorFuncCall
+	mutex       sync.Mutex
+}
+
+// GetLastSyncError delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockExternalServiceStore) GetLastSyncError(v0 context.Context, v1 int64) (string, error) {
+	r0, r1 := m.GetLastSyncErrorFunc.nextHook()(v0, v1)
+	m.GetLastSyncErrorFunc.appendCall(ExternalServiceStoreGetLastSyncErrorFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetLastSyncError
+// method of the parent MockExternalServiceStore instance is invoked and the
+// hook queue is empty.
+func (f *ExternalServiceStoreGetLastSyncErrorFunc) SetDefaultHook(hook func(context.Context, int64) (string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetLastSyncError method of the parent MockExternalServiceStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *ExternalServiceStoreGetLastSyncErrorFunc) PushHook(hook func(context.Context, int64) (string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ExternalServiceStoreGetLastSyncErrorFunc) SetDefaultReturn(r0 string, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (string, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ExternalServiceStoreGetLastSyncErrorFunc) PushReturn(r0 string, r1 error) {
+	f.PushHook(func(context.Context, int64) (string, error) {
+		return r0, r1
+	})
+}
+
+func (f *ExternalServiceStoreGetLastSyncErrorFunc) nextHook() func(context.Context, int64) (string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ExternalServiceStoreGetLastSyncErrorFunc) appendCall(r0 ExternalServiceStoreGetLastSyncErrorFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// ExternalServiceStoreGetLastSyncErrorFuncCall objects describing the
+// invocations of this function.
+func (f *ExternalServiceStoreGetLastSyncErrorFunc) History() []ExternalServiceStoreGetLastSyncErrorFuncCall {
+	f.mutex.Lock()
+	history := make([]ExternalServiceStoreGetLastSyncErrorFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ExternalServiceStoreGetLastSyncErrorFuncCall is an object that describes
+// an invocation of method GetLastSyncError on an instance of
+// MockExternalServiceStore.
+type ExternalServiceStoreGetLastSyncErrorFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ExternalServiceStoreGetLastSyncErrorFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ExternalServiceStoreGetLastSyncErrorFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ExternalServiceStoreGetRepoLastSyncErrorFunc describes the behavior when
+// the GetRepoLastSyncError method of the parent MockExternalServiceStore
+// instance is invoked.
+type ExternalServiceStoreGetRepoLastSyncErrorFunc struct {
+	defaultHook func(context.Context, api.RepoName) (string, error)
+	hooks       []func(context.Context, api.RepoName) (string, error)
+	history     []ExternalServiceStoreGetRepoLastSyncErrorFuncCall
+	mutex       sync.Mutex
+}
+
+// GetRepoLastSyncError delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockExternalServiceStore) GetRepoLastSyncError(v0 context.Context, v1 api.RepoName) (string, error) {
+	r0, r1 := m.GetRepoLastSyncErrorFunc.nextHook()(v0, v1)
+	m.GetRepoLastSyncErrorFunc.appendCall(ExternalServiceStoreGetRepoLastSyncErrorFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetRepoLastSyncError
+// method of the parent MockExternalServiceStore instance is invoked and the
+// hook queue is empty.
+func (f *ExternalServiceStoreGetRepoLastSyncErrorFunc) SetDefaultHook(hook func(context.Context, api.RepoName) (string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetRepoLastSyncError method of the parent MockExternalServiceStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *ExternalServiceStoreGetRepoLastSyncErrorFunc) PushHook(hook func(context.Context, api.RepoName) (string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ExternalServiceStoreGetRepoLastSyncErrorFunc) SetDefaultReturn(r0 string, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName) (string, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ExternalServiceStoreGetRepoLastSyncErrorFunc) PushReturn(r0 string, r1 error) {
+	f.PushHook(func(context.Context, api.RepoName) (string, error) {
+		return r0, r1
+	})
+}
+
+func (f *ExternalServiceStoreGetRepoLastSyncErrorFunc) nextHook() func(context.Context, api.RepoName) (string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ExternalServiceStoreGetRepoLastSyncErrorFunc) appendCall(r0 ExternalServiceStoreGetRepoLastSyncErrorFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// ExternalServiceStoreGetRepoLastSyncErrorFuncCall objects describing the
+// invocations of this function.
+func (f *ExternalServiceStoreGetRepoLastSyncErrorFunc) History() []ExternalServiceStoreGetRepoLastSyncErrorFuncCall {
+	f.mutex.Lock()
+	history := make([]ExternalServiceStoreGetRepoLastSyncErrorFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ExternalServiceStoreGetRepoLastSyncErrorFuncCall is an object that
+// describes an invocation of method GetRepoLastSyncError on an instance of
+// MockExternalServiceStore.
+type ExternalServiceStoreGetRepoLastSyncErrorFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoName
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ExternalServiceStoreGetRepoLastSyncErrorFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ExternalServiceStoreGetRepoLastSyncErrorFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ExternalServiceStoreGetSyncJobLogsFunc describes the behavior when the
+// GetSyncJobLogs method of the parent MockExternalServiceStore instance is
+// invoked.
+type ExternalServiceStoreGetSyncJobLogsFunc struct {
+	defaultHook func(context.Context, int64) ([]string, error)
+	hooks       []func(context.Context, int64) ([]string, error)
+	history     []ExternalServiceStoreGetSyncJobLogsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetSyncJobLogs delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockExternalServiceStore) GetSyncJobLogs(v0 context.Context, v1 int64) ([]string, error) {
+	r0, r1 := m.GetSyncJobLogsFunc.nextHook()(v0, v1)
+	m.GetSyncJobLogsFunc.appendCall(ExternalServiceStoreGetSyncJobLogsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetSyncJobLogs
+// method of the parent MockExternalServiceStore instance is invoked and the
+// hook queue is empty.
+func (f *ExternalServiceStoreGetSyncJobLogsFunc) SetDefaultHook(hook func(context.Context, int64) ([]string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetSyncJobLogs method of the parent MockExternalServiceStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *ExternalServiceStoreGetSyncJobLogsFunc) PushHook(hook func(context.Context, int64) ([]string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ExternalServiceStoreGetSyncJobLogsFunc) SetDefaultReturn(r0 []string, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) ([]string, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ExternalServiceStoreGetSyncJobLogsFunc) PushReturn(r0 []string, r1 error) {
+	f.PushHook(func(context.Context, int64) ([]string, error) {
+		return r0, r1
+	})
+}
+
+func (f *ExternalServiceStoreGetSyncJobLogsFunc) nextHook() func(context.Context, int64) ([]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ExternalServiceStoreGetSyncJobLogsFunc) appendCall(r0 ExternalServiceStoreGetSyncJobLogsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ExternalServiceStoreGetSyncJobLogsFuncCall
+// objects describing the invocations of this function.
+func (f *ExternalServiceStoreGetSyncJobLogsFunc) History() []ExternalServiceStoreGetSyncJobLogsFuncCall {
+	f.mutex.Lock()
+	history := make([]ExternalServiceStoreGetSyncJobLogsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ExternalServiceStoreGetSyncJobLogsFuncCall is an object that describes an
+// invocation of method GetSyncJobLogs on an instance of
+// MockExternalServiceStore.
+type ExternalServiceStoreGetSyncJobLogsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ExternalServiceStoreGetSyncJobLogsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ExternalServiceStoreGetSyncJobLogsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ExternalServiceStoreGetSyncJobsFunc describes the behavior when the
+// GetSyncJobs method of the parent MockExternalServiceStore instance is
+// invoked.
+type ExternalServiceStoreGetSyncJobsFunc struct {
+	defaultHook func(context.Context) ([]*types.ExternalServiceSyncJob, error)
+	hooks       []func(context.Context) ([]*types.ExternalServiceSyncJob, error)
+	history     []ExternalServiceStoreGetSyncJobsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetSyncJobs delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockExternalServiceStore) GetSyncJobs(v0 context.Context) ([]*types.ExternalServiceSyncJob, error) {
+	r0, r1 := m.GetSyncJobsFunc.nextHook()(v0)
+	m.GetSyncJobsFunc.appendCall(ExternalServiceStoreGetSyncJobsFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetSyncJobs method
+// of the parent MockExternalServiceStore instance is invoked and the hook
+// queue is empty.
+func (f *ExternalServiceStoreGetSyncJobsFunc) SetDefaultHook(hook func(context.Context) ([]*types.ExternalServiceSyncJob, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetSyncJobs method of the parent MockExternalServiceStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *ExternalServiceStoreGetSyncJobsFunc) PushHook(hook func(context.Context) ([]*types.ExternalServiceSyncJob, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ExternalServiceStoreGetSyncJobsFunc) SetDefaultReturn(r0 []*types.ExternalServiceSyncJob, r1 error) {
+	f.SetDefaultHook(func(context.Context) ([]*types.ExternalServiceSyncJob, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ExternalServiceStoreGetSyncJobsFunc) PushReturn(r0 []*types.ExternalServiceSyncJob, r1 error) {
+	f.PushHook(func(context.Context) ([]*types.ExternalServiceSyncJob, error) {
+		return r0, r1
+	})
+}
+
+func (f *ExternalServiceStoreGetSyncJobsFunc) nextHook() func(context.Context) ([]*types.ExternalServiceSyncJob, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ExternalServiceStoreGetSyncJobsFunc) appendCall(r0 ExternalServiceStoreGetSyncJobsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ExternalServiceStoreGetSyncJobsFuncCall
+// objects describing the invocations of this function.
+func (f *ExternalServiceStoreGetSyncJobsFunc) History() []ExternalServiceStoreGetSyncJobsFuncCall {
+	f.mutex.Lock()
+	history := make([]ExternalServiceStoreGetSyncJobsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ExternalServiceStoreGetSyncJobsFuncCall is an object that describes an
+// invocation of method GetSyncJobs on an instance of
+// MockExternalServiceStore.
+type ExternalServiceStoreGetSyncJobsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*types.ExternalServiceSyncJob
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ExternalServiceStoreGetSyncJobsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ExternalServiceStoreGetSyncJobsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ExternalServiceStoreGetSyncJobsPaginatedFunc describes the behavior when
+// the GetSyncJobsPaginated method of the parent MockExternalServiceStore
+// instance is invoked.
+type ExternalServiceStoreGetSyncJobsPaginatedFunc struct {
+	defaultHook func(context.Context, SyncJobsListOptions) ([]*types.ExternalServiceSyncJob, int, error)
+	hooks       []func(context.Context, SyncJobsListOptions) ([]*types.ExternalServiceSyncJob, int, error)
+	history     []ExternalServiceStoreGetSyncJobsPaginatedFuncCall
+	mutex       sync.Mutex
+}
+
+// GetSyncJobsPaginated delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockExternalServiceStore) GetSyncJobsPaginated(v0 context.Context, v1 SyncJobsListOptions) ([]*types.ExternalServiceSyncJob, int, error) {
+	r0, r1, r2 := m.GetSyncJobsPaginatedFunc.nextHook()(v0, v1)
+	m.GetSyncJobsPaginatedFunc.appendCall(ExternalServiceStoreGetSyncJobsPaginatedFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the GetSyncJobsPaginated
+// method of the parent MockExternalServiceStore instance is invoked and the
+// hook queue is empty.
+func (f *ExternalServiceStoreGetSyncJobsPaginatedFunc) SetDefaultHook(hook func(context.Context, SyncJobsListOptions) ([]*types.ExternalServiceSyncJob, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetSyncJobsPaginated method of the parent MockExternalServiceStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *ExternalServiceStoreGetSyncJobsPaginatedFunc) PushHook(hook func(context.Context, SyncJobsListOptions) ([]*types.ExternalServiceSyncJob, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ExternalServiceStoreGetSyncJobsPaginatedFunc) SetDefaultReturn(r0 []*types.ExternalServiceSyncJob, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, SyncJobsListOptions) ([]*types.ExternalServiceSyncJob, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ExternalServiceStoreGetSyncJobsPaginatedFunc) PushReturn(r0 []*types.ExternalServiceSyncJob, r1 int, r2 error) {
+	f.PushHook(func(context.Context, SyncJobsListOptions) ([]*types.ExternalServiceSyncJob, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *ExternalServiceStoreGetSyncJobsPaginatedFunc) nextHook() func(context.Context, SyncJobsListOptions) ([]*types.ExternalServiceSyncJob, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ExternalServiceStoreGetSyncJobsPaginatedFunc) appendCall(r0 ExternalServiceStoreGetSyncJobsPaginatedFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// ExternalServiceStoreGetSyncJobsPaginatedFuncCall objects describing the
+// invocations of this function.
+func (f *ExternalServiceStoreGetSyncJobsPaginatedFunc) History() []ExternalServiceStoreGetSyncJobsPaginatedFuncCall {
+	f.mutex.Lock()
+	history := make([]ExternalServiceStoreGetSyncJobsPaginatedFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ExternalServiceStoreGetSyncJobsPaginatedFuncCall is an object that
+// describes an invocation of method GetSyncJobsPaginated on an instance of
+// MockExternalServiceStore.
+type ExternalServiceStoreGetSyncJobsPaginatedFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 SyncJobsListOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*types.ExternalServiceSyncJob
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
 // invocation.
-func (c ExternalServiceStoreCreateFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+func (c ExternalServiceStoreGetSyncJobsPaginatedFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ExternalServiceStoreCreateFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c ExternalServiceStoreGetSyncJobsPaginatedFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
-// ExternalServiceStoreDeleteFunc describes the behavior when the Delete
+// ExternalServiceStoreHandleFunc describes the behavior when the Handle
 // method of the parent MockExternalServiceStore instance is invoked.
-type ExternalServiceStoreDeleteFunc struct {
-	defaultHook func(context.Context, int64) error
-	hooks       []func(context.Context, int64) error
-	history     []ExternalServiceStoreDeleteFuncCall
+type ExternalServiceStoreHandleFunc struct {
+	defaultHook func() *basestore.TransactableHandle
+	hooks       []func() *basestore.TransactableHandle
+	history     []ExternalServiceStoreHandleFuncCall
 	mutex       sync.Mutex
 }
 
-// Delete delegates to the next hook function in the queue and stores the
+// Handle delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockExternalServiceStore) Delete(v0 context.Context, v1 int64) error {
-	r0 := m.DeleteFunc.nextHook()(v0, v1)
-	m.DeleteFunc.appendCall(ExternalServiceStoreDeleteFuncCall{v0, v1, r0})
+func (m *MockExternalServiceStore) Handle() *basestore.TransactableHandle {
+	r0 := m.HandleFunc.nextHook()()
+	m.HandleFunc.appendCall(ExternalServiceStoreHandleFuncCall{r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the Delete method of the
+// SetDefaultHook sets function that is called when the Handle method of the
 // parent MockExternalServiceStore instance is invoked and the hook queue is
 // empty.
-func (f *ExternalServiceStoreDeleteFunc) SetDefaultHook(hook func(context.Context, int64) error) {
+func (f *ExternalServiceStoreHandleFunc) SetDefaultHook(hook func() *basestore.TransactableHandle) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Delete method of the parent MockExternalServiceStore instance invokes the
+// Handle method of the parent MockExternalServiceStore instance invokes the
 // hook at the front of the queue and discards it. After the queue is empty,
 // the default hook function is invoked for any future action.
-func (f *ExternalServiceStoreDeleteFunc) PushHook(hook func(context.Context, int64) error) {
+func (f *ExternalServiceStoreHandleFunc) PushHook(hook func() *basestore.TransactableHandle) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -11240,20 +13781,20 @@ func (f *ExternalServiceStoreDeleteFunc) PushHook(hook func(context.Context, int
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *ExternalServiceStoreDeleteFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int64) error {
+func (f *ExternalServiceStoreHandleFunc) SetDefaultReturn(r0 *basestore.TransactableHandle) {
+	f.SetDefaultHook(func() *basestore.TransactableHandle {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *ExternalServiceStoreDeleteFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int64) error {
+func (f *ExternalServiceStoreHandleFunc) PushReturn(r0 *basestore.TransactableHandle) {
+	f.PushHook(func() *basestore.TransactableHandle {
 		return r0
 	})
 }
 
-func (f *ExternalServiceStoreDeleteFunc) nextHook() func(context.Context, int64) error {
+func (f *ExternalServiceStoreHandleFunc) nextHook() func() *basestore.TransactableHandle {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -11266,26 +13807,129 @@ func (f *ExternalServiceStoreDeleteFunc) nextHook() func(context.Context, int64)
 	return hook
 }
 
-func (f *ExternalServiceStoreDeleteFunc) appendCall(r0 ExternalServiceStoreDeleteFuncCall) {
+func (f *ExternalServiceStoreHandleFunc) appendCall(r0 ExternalServiceStoreHandleFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of ExternalServiceStoreDeleteFuncCall objects
+// History returns a sequence of ExternalServiceStoreHandleFuncCall objects
 // describing the invocations of this function.
-func (f *ExternalServiceStoreDeleteFunc) History() []ExternalServiceStoreDeleteFuncCall {
+func (f *ExternalServiceStoreHandleFunc) History() []ExternalServiceStoreHandleFuncCall {
 	f.mutex.Lock()
-	history := make([]ExternalServiceStoreDeleteFuncCall, len(f.history))
+	history := make([]ExternalServiceStoreHandleFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ExternalServiceStoreDeleteFuncCall is an object that describes an
-// invocation of method Delete on an instance of MockExternalServiceStore.
-type ExternalServiceStoreDeleteFuncCall struct {
+// ExternalServiceStoreHandleFuncCall is an object that describes an
+// invocation of method Handle on an instance of MockExternalServiceStore.
+type ExternalServiceStoreHandleFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *basestore.TransactableHandle
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ExternalServiceStoreHandleFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ExternalServiceStoreHandleFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ExternalServiceStoreLastSuccessfulSyncFunc describes the behavior when
+// the LastSuccessfulSync method of the parent MockExternalServiceStore
+// instance is invoked.
+type ExternalServiceStoreLastSuccessfulSyncFunc struct {
+	defaultHook func(context.Context, int64) (time.Time, error)
+	hooks       []func(context.Context, int64) (time.Time, error)
+	history     []ExternalServiceStoreLastSuccessfulSyncFuncCall
+	mutex       sync.Mutex
+}
+
+// LastSuccessfulSync delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockExternalServiceStore) LastSuccessfulSync(v0 context.Context, v1 int64) (time.Time, error) {
+	r0, r1 := m.LastSuccessfulSyncFunc.nextHook()(v0, v1)
+	m.LastSuccessfulSyncFunc.appendCall(ExternalServiceStoreLastSuccessfulSyncFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the LastSuccessfulSync
+// method of the parent MockExternalServiceStore instance is invoked and the
+// hook queue is empty.
+func (f *ExternalServiceStoreLastSuccessfulSyncFunc) SetDefaultHook(hook func(context.Context, int64) (time.Time, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// LastSuccessfulSync method of the parent MockExternalServiceStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *ExternalServiceStoreLastSuccessfulSyncFunc) PushHook(hook func(context.Context, int64) (time.Time, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ExternalServiceStoreLastSuccessfulSyncFunc) SetDefaultReturn(r0 time.Time, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (time.Time, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ExternalServiceStoreLastSuccessfulSyncFunc) PushReturn(r0 time.Time, r1 error) {
+	f.PushHook(func(context.Context, int64) (time.Time, error) {
+		return r0, r1
+	})
+}
+
+func (f *ExternalServiceStoreLastSuccessfulSyncFunc) nextHook() func(context.Context, int64) (time.Time, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ExternalServiceStoreLastSuccessfulSyncFunc) appendCall(r0 ExternalServiceStoreLastSuccessfulSyncFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// ExternalServiceStoreLastSuccessfulSyncFuncCall objects describing the
+// invocations of this function.
+func (f *ExternalServiceStoreLastSuccessfulSyncFunc) History() []ExternalServiceStoreLastSuccessfulSyncFuncCall {
+	f.mutex.Lock()
+	history := make([]ExternalServiceStoreLastSuccessfulSyncFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ExternalServiceStoreLastSuccessfulSyncFuncCall is an object that
+// describes an invocation of method LastSuccessfulSync on an instance of
+// MockExternalServiceStore.
+type ExternalServiceStoreLastSuccessfulSyncFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -11294,52 +13938,53 @@ type ExternalServiceStoreDeleteFuncCall struct {
 	Arg1 int64
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 time.Time
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c ExternalServiceStoreDeleteFuncCall) Args() []interface{} {
+func (c ExternalServiceStoreLastSuccessfulSyncFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ExternalServiceStoreDeleteFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c ExternalServiceStoreLastSuccessfulSyncFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// ExternalServiceStoreDistinctKindsFunc describes the behavior when the
-// DistinctKinds method of the parent MockExternalServiceStore instance is
-// invoked.
-type ExternalServiceStoreDistinctKindsFunc struct {
-	defaultHook func(context.Context) ([]string, error)
-	hooks       []func(context.Context) ([]string, error)
-	history     []ExternalServiceStoreDistinctKindsFuncCall
+// ExternalServiceStoreListFunc describes the behavior when the List method
+// of the parent MockExternalServiceStore instance is invoked.
+type ExternalServiceStoreListFunc struct {
+	defaultHook func(context.Context, ExternalServicesListOptions) ([]*types.ExternalService, error)
+	hooks       []func(context.Context, ExternalServicesListOptions) ([]*types.ExternalService, error)
+	history     []ExternalServiceStoreListFuncCall
 	mutex       sync.Mutex
 }
 
-// DistinctKinds delegates to the next hook function in the queue and stores
-// the parameter and result values of this invocation.
-func (m *MockExternalServiceStore) DistinctKinds(v0 context.Context) ([]string, error) {
-	r0, r1 := m.DistinctKindsFunc.nextHook()(v0)
-	m.DistinctKindsFunc.appendCall(ExternalServiceStoreDistinctKindsFuncCall{v0, r0, r1})
+// List delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockExternalServiceStore) List(v0 context.Context, v1 ExternalServicesListOptions) ([]*types.ExternalService, error) {
+	r0, r1 := m.ListFunc.nextHook()(v0, v1)
+	m.ListFunc.appendCall(ExternalServiceStoreListFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the DistinctKinds method
-// of the parent MockExternalServiceStore instance is invoked and the hook
-// queue is empty.
-func (f *ExternalServiceStoreDistinctKindsFunc) SetDefaultHook(hook func(context.Context) ([]string, error)) {
+// SetDefaultHook sets function that is called when the List method of the
+// parent MockExternalServiceStore instance is invoked and the hook queue is
+// empty.
+func (f *ExternalServiceStoreListFunc) SetDefaultHook(hook func(context.Context, ExternalServicesListOptions) ([]*types.ExternalService, error)) {
 	f.defaultHook = hook
 }
-
-// PushHook adds a function to the end of hook queue. Each invocation of the
-// DistinctKinds method of the parent MockExternalServiceStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *ExternalServiceStoreDistinctKindsFunc) PushHook(hook func(context.Context) ([]string, error)) {
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// List method of the parent MockExternalServiceStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *ExternalServiceStoreListFunc) PushHook(hook func(context.Context, ExternalServicesListOptions) ([]*types.ExternalService, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -11347,20 +13992,20 @@ func (f *ExternalServiceStoreDistinctKindsFunc) PushHook(hook func(context.Conte
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *ExternalServiceStoreDistinctKindsFunc) SetDefaultReturn(r0 []string, r1 error) {
-	f.SetDefaultHook(func(context.Context) ([]string, error) {
+func (f *ExternalServiceStoreListFunc) SetDefaultReturn(r0 []*types.ExternalService, r1 error) {
+	f.SetDefaultHook(func(context.Context, ExternalServicesListOptions) ([]*types.ExternalService, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *ExternalServiceStoreDistinctKindsFunc) PushReturn(r0 []string, r1 error) {
-	f.PushHook(func(context.Context) ([]string, error) {
+func (f *ExternalServiceStoreListFunc) PushReturn(r0 []*types.ExternalService, r1 error) {
+	f.PushHook(func(context.Context, ExternalServicesListOptions) ([]*types.ExternalService, error) {
 		return r0, r1
 	})
 }
 
-func (f *ExternalServiceStoreDistinctKindsFunc) nextHook() func(context.Context) ([]string, error) {
+func (f *ExternalServiceStoreListFunc) nextHook() func(context.Context, ExternalServicesListOptions) ([]*types.ExternalService, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -11373,33 +14018,35 @@ func (f *ExternalServiceStoreDistinctKindsFunc) nextHook() func(context.Context)
 	return hook
 }
 
-func (f *ExternalServiceStoreDistinctKindsFunc) appendCall(r0 ExternalServiceStoreDistinctKindsFuncCall) {
+func (f *ExternalServiceStoreListFunc) appendCall(r0 ExternalServiceStoreListFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of ExternalServiceStoreDistinctKindsFuncCall
-// objects describing the invocations of this function.
-func (f *ExternalServiceStoreDistinctKindsFunc) History() []ExternalServiceStoreDistinctKindsFuncCall {
+// History returns a sequence of ExternalServiceStoreListFuncCall objects
+// describing the invocations of this function.
+func (f *ExternalServiceStoreListFunc) History() []ExternalServiceStoreListFuncCall {
 	f.mutex.Lock()
-	history := make([]ExternalServiceStoreDistinctKindsFuncCall, len(f.history))
+	history := make([]ExternalServiceStoreListFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ExternalServiceStoreDistinctKindsFuncCall is an object that describes an
-// invocation of method DistinctKinds on an instance of
-// MockExternalServiceStore.
-type ExternalServiceStoreDistinctKindsFuncCall struct {
+// ExternalServiceStoreListFuncCall is an object that describes an
+// invocation of method List on an instance of MockExternalServiceStore.
+type ExternalServiceStoreListFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 ExternalServicesListOptions
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []string
+	Result0 []*types.ExternalService
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -11407,45 +14054,47 @@ type ExternalServiceStoreDistinctKindsFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c ExternalServiceStoreDistinctKindsFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c ExternalServiceStoreListFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ExternalServiceStoreDistinctKindsFuncCall) Results() []interface{} {
+func (c ExternalServiceStoreListFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// ExternalServiceStoreDoneFunc describes the behavior when the Done method
-// of the parent MockExternalServiceStore instance is invoked.
-type ExternalServiceStoreDoneFunc struct {
-	defaultHook func(error) error
-	hooks       []func(error) error
-	history     []ExternalServiceStoreDoneFuncCall
+// ExternalServiceStoreListByNamespaceFunc describes the behavior when the
+// ListByNamespace method of the parent MockExternalServiceStore instance is
+// invoked.
+type ExternalServiceStoreListByNamespaceFunc struct {
+	defaultHook func(context.Context, int32, int32) ([]*types.ExternalService, error)
+	hooks       []func(context.Context, int32, int32) ([]*types.ExternalService, error)
+	history     []ExternalServiceStoreListByNamespaceFuncCall
 	mutex       sync.Mutex
 }
 
-// Done delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockExternalServiceStore) Done(v0 error) error {
-	r0 := m.DoneFunc.nextHook()(v0)
-	m.DoneFunc.appendCall(ExternalServiceStoreDoneFuncCall{v0, r0})
-	return r0
+// ListByNamespace delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockExternalServiceStore) ListByNamespace(v0 context.Context, v1 int32, v2 int32) ([]*types.ExternalService, error) {
+	r0, r1 := m.ListByNamespaceFunc.nextHook()(v0, v1, v2)
+	m.ListByNamespaceFunc.appendCall(ExternalServiceStoreListByNamespaceFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Done method of the
-// parent MockExternalServiceStore instance is invoked and the hook queue is
-// empty.
-func (f *ExternalServiceStoreDoneFunc) SetDefaultHook(hook func(error) error) {
+// SetDefaultHook sets function that is called when the ListByNamespace
+// method of the parent MockExternalServiceStore instance is invoked and the
+// hook queue is empty.
+func (f *ExternalServiceStoreListByNamespaceFunc) SetDefaultHook(hook func(context.Context, int32, int32) ([]*types.ExternalService, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Done method of the parent MockExternalServiceStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *ExternalServiceStoreDoneFunc) PushHook(hook func(error) error) {
+// ListByNamespace method of the parent MockExternalServiceStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *ExternalServiceStoreListByNamespaceFunc) PushHook(hook func(context.Context, int32, int32) ([]*types.ExternalService, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -11453,20 +14102,20 @@ func (f *ExternalServiceStoreDoneFunc) PushHook(hook func(error) error) {
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *ExternalServiceStoreDoneFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(error) error {
-		return r0
+func (f *ExternalServiceStoreListByNamespaceFunc) SetDefaultReturn(r0 []*types.ExternalService, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32, int32) ([]*types.ExternalService, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *ExternalServiceStoreDoneFunc) PushReturn(r0 error) {
-	f.PushHook(func(error) error {
-		return r0
+func (f *ExternalServiceStoreListByNamespaceFunc) PushReturn(r0 []*types.ExternalService, r1 error) {
+	f.PushHook(func(context.Context, int32, int32) ([]*types.ExternalService, error) {
+		return r0, r1
 	})
 }
 
-func (f *ExternalServiceStoreDoneFunc) nextHook() func(error) error {
+func (f *ExternalServiceStoreListByNamespaceFunc) nextHook() func(context.Context, int32, int32) ([]*types.ExternalService, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -11479,77 +14128,86 @@ func (f *ExternalServiceStoreDoneFunc) nextHook() func(error) error {
 	return hook
 }
 
-func (f *ExternalServiceStoreDoneFunc) appendCall(r0 ExternalServiceStoreDoneFuncCall) {
+func (f *ExternalServiceStoreListByNamespaceFunc) appendCall(r0 ExternalServiceStoreListByNamespaceFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of ExternalServiceStoreDoneFuncCall objects
-// describing the invocations of this function.
-func (f *ExternalServiceStoreDoneFunc) History() []ExternalServiceStoreDoneFuncCall {
+// History returns a sequence of ExternalServiceStoreListByNamespaceFuncCall
+// objects describing the invocations of this function.
+func (f *ExternalServiceStoreListByNamespaceFunc) History() []ExternalServiceStoreListByNamespaceFuncCall {
 	f.mutex.Lock()
-	history := make([]ExternalServiceStoreDoneFuncCall, len(f.history))
+	history := make([]ExternalServiceStoreListByNamespaceFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ExternalServiceStoreDoneFuncCall is an object that describes an
-// invocation of method Done on an instance of MockExternalServiceStore.
-type ExternalServiceStoreDoneFuncCall struct {
+// ExternalServiceStoreListByNamespaceFuncCall is an object that describes
+// an invocation of method ListByNamespace on an instance of
+// MockExternalServiceStore.
+type ExternalServiceStoreListByNamespaceFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
-	Arg0 error
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int32
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 []*types.ExternalService
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c ExternalServiceStoreDoneFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c ExternalServiceStoreListByNamespaceFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ExternalServiceStoreDoneFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c ExternalServiceStoreListByNamespaceFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// ExternalServiceStoreGetAffiliatedSyncErrorsFunc describes the behavior
-// when the GetAffiliatedSyncErrors method of the parent
-// MockExternalServiceStore instance is invoked.
-type ExternalServiceStoreGetAffiliatedSyncErrorsFunc struct {
-	defaultHook func(context.Context, *types.User) (map[int64]string, error)
-	hooks       []func(context.Context, *types.User) (map[int64]string, error)
-	history     []ExternalServiceStoreGetAffiliatedSyncErrorsFuncCall
+// ExternalServiceStoreListReposFunc describes the behavior when the
+// ListRepos method of the parent MockExternalServiceStore instance is
+// invoked.
+type ExternalServiceStoreListReposFunc struct {
+	defaultHook func(context.Context, int64, LimitOffset) ([]*types.Repo, error)
+	hooks       []func(context.Context, int64, LimitOffset) ([]*types.Repo, error)
+	history     []ExternalServiceStoreListReposFuncCall
 	mutex       sync.Mutex
 }
 
-// GetAffiliatedSyncErrors delegates to the next hook function in the queue
-// and stores the parameter and result values of this invocation.
-func (m *MockExternalServiceStore) GetAffiliatedSyncErrors(v0 context.Context, v1 *types.User) (map[int64]string, error) {
-	r0, r1 := m.GetAffiliatedSyncErrorsFunc.nextHook()(v0, v1)
-	m.GetAffiliatedSyncErrorsFunc.appendCall(ExternalServiceStoreGetAffiliatedSyncErrorsFuncCall{v0, v1, r0, r1})
+// ListRepos delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockExternalServiceStore) ListRepos(v0 context.Context, v1 int64, v2 LimitOffset) ([]*types.Repo, error) {
+	r0, r1 := m.ListReposFunc.nextHook()(v0, v1, v2)
+	m.ListReposFunc.appendCall(ExternalServiceStoreListReposFuncCall{v0, v1, v2, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the
-// GetAffiliatedSyncErrors method of the parent MockExternalServiceStore
-// instance is invoked and the hook queue is empty.
-func (f *ExternalServiceStoreGetAffiliatedSyncErrorsFunc) SetDefaultHook(hook func(context.Context, *types.User) (map[int64]string, error)) {
+// SetDefaultHook sets function that is called when the ListRepos method of
+// the parent MockExternalServiceStore instance is invoked and the hook
+// queue is empty.
+func (f *ExternalServiceStoreListReposFunc) SetDefaultHook(hook func(context.Context, int64, LimitOffset) ([]*types.Repo, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetAffiliatedSyncErrors method of the parent MockExternalServiceStore
-// instance invokes the hook at the front of the queue and discards it.
-// After the queue is empty, the default hook function is invoked for any
-// future action.
-func (f *ExternalServiceStoreGetAffiliatedSyncErrorsFunc) PushHook(hook func(context.Context, *types.User) (map[int64]string, error)) {
+// ListRepos method of the parent MockExternalServiceStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *ExternalServiceStoreListReposFunc) PushHook(hook func(context.Context, int64, LimitOffset) ([]*types.Repo, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -11557,20 +14215,20 @@ func (f *ExternalServiceStoreGetAffiliatedSyncErrorsFunc) PushHook(hook func(con
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *ExternalServiceStoreGetAffiliatedSyncErrorsFunc) SetDefaultReturn(r0 map[int64]string, r1 error) {
-	f.SetDefaultHook(func(context.Context, *types.User) (map[int64]string, error) {
+func (f *ExternalServiceStoreListReposFunc) SetDefaultReturn(r0 []*types.Repo, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, LimitOffset) ([]*types.Repo, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *ExternalServiceStoreGetAffiliatedSyncErrorsFunc) PushReturn(r0 map[int64]string, r1 error) {
-	f.PushHook(func(context.Context, *types.User) (map[int64]string, error) {
+func (f *ExternalServiceStoreListReposFunc) PushReturn(r0 []*types.Repo, r1 error) {
+	f.PushHook(func(context.Context, int64, LimitOffset) ([]*types.Repo, error) {
 		return r0, r1
 	})
 }
 
-func (f *ExternalServiceStoreGetAffiliatedSyncErrorsFunc) nextHook() func(context.Context, *types.User) (map[int64]string, error) {
+func (f *ExternalServiceStoreListReposFunc) nextHook() func(context.Context, int64, LimitOffset) ([]*types.Repo, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -11583,37 +14241,39 @@ func (f *ExternalServiceStoreGetAffiliatedSyncErrorsFunc) nextHook() func(contex
 	return hook
 }
 
-func (f *ExternalServiceStoreGetAffiliatedSyncErrorsFunc) appendCall(r0 ExternalServiceStoreGetAffiliatedSyncErrorsFuncCall) {
+func (f *ExternalServiceStoreListReposFunc) appendCall(r0 ExternalServiceStoreListReposFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// ExternalServiceStoreGetAffiliatedSyncErrorsFuncCall objects describing
-// the invocations of this function.
-func (f *ExternalServiceStoreGetAffiliatedSyncErrorsFunc) History() []ExternalServiceStoreGetAffiliatedSyncErrorsFuncCall {
+// History returns a sequence of ExternalServiceStoreListReposFuncCall
+// objects describing the invocations of this function.
+func (f *ExternalServiceStoreListReposFunc) History() []ExternalServiceStoreListReposFuncCall {
 	f.mutex.Lock()
-	history := make([]ExternalServiceStoreGetAffiliatedSyncErrorsFuncCall, len(f.history))
+	history := make([]ExternalServiceStoreListReposFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ExternalServiceStoreGetAffiliatedSyncErrorsFuncCall is an object that
-// describes an invocation of method GetAffiliatedSyncErrors on an instance
-// of MockExternalServiceStore.
-type ExternalServiceStoreGetAffiliatedSyncErrorsFuncCall struct {
+// ExternalServiceStoreListReposFuncCall is an object that describes an
+// invocation of method ListRepos on an instance of
+// MockExternalServiceStore.
+type ExternalServiceStoreListReposFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 *types.User
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 LimitOffset
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 map[int64]string
+	Result0 []*types.Repo
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -11621,45 +14281,45 @@ type ExternalServiceStoreGetAffiliatedSyncErrorsFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c ExternalServiceStoreGetAffiliatedSyncErrorsFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c ExternalServiceStoreListReposFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ExternalServiceStoreGetAffiliatedSyncErrorsFuncCall) Results() []interface{} {
+func (c ExternalServiceStoreListReposFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// ExternalServiceStoreGetByIDFunc describes the behavior when the GetByID
+// ExternalServiceStorePauseFunc describes the behavior when the Pause
 // method of the parent MockExternalServiceStore instance is invoked.
-type ExternalServiceStoreGetByIDFunc struct {
-	defaultHook func(context.Context, int64) (*types.ExternalService, error)
-	hooks       []func(context.Context, int64) (*types.ExternalService, error)
-	history     []ExternalServiceStoreGetByIDFuncCall
+type ExternalServiceStorePauseFunc struct {
+	defaultHook func(context.Context, int64) error
+	hooks       []func(context.Context, int64) error
+	history     []ExternalServiceStorePauseFuncCall
 	mutex       sync.Mutex
 }
 
-// GetByID delegates to the next hook function in the queue and stores the
+// Pause delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockExternalServiceStore) GetByID(v0 context.Context, v1 int64) (*types.ExternalService, error) {
-	r0, r1 := m.GetByIDFunc.nextHook()(v0, v1)
-	m.GetByIDFunc.appendCall(ExternalServiceStoreGetByIDFuncCall{v0, v1, r0, r1})
-	return r0, r1
+func (m *MockExternalServiceStore) Pause(v0 context.Context, v1 int64) error {
+	r0 := m.PauseFunc.nextHook()(v0, v1)
+	m.PauseFunc.appendCall(ExternalServiceStorePauseFuncCall{v0, v1, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the GetByID method of
-// the parent MockExternalServiceStore instance is invoked and the hook
-// queue is empty.
-func (f *ExternalServiceStoreGetByIDFunc) SetDefaultHook(hook func(context.Context, int64) (*types.ExternalService, error)) {
+// SetDefaultHook sets function that is called when the Pause method of the
+// parent MockExternalServiceStore instance is invoked and the hook queue is
+// empty.
+func (f *ExternalServiceStorePauseFunc) SetDefaultHook(hook func(context.Context, int64) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetByID method of the parent MockExternalServiceStore instance invokes
-// the hook at the front of the queue and discards it. After the queue is
-// empty, the default hook function is invoked for any future action.
-func (f *ExternalServiceStoreGetByIDFunc) PushHook(hook func(context.Context, int64) (*types.ExternalService, error)) {
+// Pause method of the parent MockExternalServiceStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *ExternalServiceStorePauseFunc) PushHook(hook func(context.Context, int64) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -11667,20 +14327,20 @@ func (f *ExternalServiceStoreGetByIDFunc) PushHook(hook func(context.Context, in
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *ExternalServiceStoreGetByIDFunc) SetDefaultReturn(r0 *types.ExternalService, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64) (*types.ExternalService, error) {
-		return r0, r1
+func (f *ExternalServiceStorePauseFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64) error {
+		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *ExternalServiceStoreGetByIDFunc) PushReturn(r0 *types.ExternalService, r1 error) {
-	f.PushHook(func(context.Context, int64) (*types.ExternalService, error) {
-		return r0, r1
+func (f *ExternalServiceStorePauseFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64) error {
+		return r0
 	})
 }
 
-func (f *ExternalServiceStoreGetByIDFunc) nextHook() func(context.Context, int64) (*types.ExternalService, error) {
+func (f *ExternalServiceStorePauseFunc) nextHook() func(context.Context, int64) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -11693,26 +14353,26 @@ func (f *ExternalServiceStoreGetByIDFunc) nextHook() func(context.Context, int64
 	return hook
 }
 
-func (f *ExternalServiceStoreGetByIDFunc) appendCall(r0 ExternalServiceStoreGetByIDFuncCall) {
+func (f *ExternalServiceStorePauseFunc) appendCall(r0 ExternalServiceStorePauseFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of ExternalServiceStoreGetByIDFuncCall objects
+// History returns a sequence of ExternalServiceStorePauseFuncCall objects
 // describing the invocations of this function.
-func (f *ExternalServiceStoreGetByIDFunc) History() []ExternalServiceStoreGetByIDFuncCall {
+func (f *ExternalServiceStorePauseFunc) History() []ExternalServiceStorePauseFuncCall {
 	f.mutex.Lock()
-	history := make([]ExternalServiceStoreGetByIDFuncCall, len(f.history))
+	history := make([]ExternalServiceStorePauseFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ExternalServiceStoreGetByIDFuncCall is an object that describes an
-// invocation of method GetByID on an instance of MockExternalServiceStore.
-type ExternalServiceStoreGetByIDFuncCall struct {
+// ExternalServiceStorePauseFuncCall is an object that describes an
+// invocation of method Pause on an instance of MockExternalServiceStore.
+type ExternalServiceStorePauseFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -11721,55 +14381,52 @@ type ExternalServiceStoreGetByIDFuncCall struct {
 	Arg1 int64
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *types.ExternalService
-	// Result1 is the value of the 2nd result returned from this method
-	// invocation.
-	Result1 error
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c ExternalServiceStoreGetByIDFuncCall) Args() []interface{} {
+func (c ExternalServiceStorePauseFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ExternalServiceStoreGetByIDFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c ExternalServiceStorePauseFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
-// ExternalServiceStoreGetLastSyncErrorFunc describes the behavior when the
-// GetLastSyncError method of the parent MockExternalServiceStore instance
-// is invoked.
-type ExternalServiceStoreGetLastSyncErrorFunc struct {
-	defaultHook func(context.Context, int64) (string, error)
-	hooks       []func(context.Context, int64) (string, error)
-	history     []ExternalServiceStoreGetLastSyncErrorFuncCall
+// ExternalServiceStoreReconcileExternalServiceReposFunc describes the
+// behavior when the ReconcileExternalServiceRepos method of the parent
+// MockExternalServiceStore instance is invoked.
+type ExternalServiceStoreReconcileExternalServiceReposFunc struct {
+	defaultHook func(context.Context) (int, error)
+	hooks       []func(context.Context) (int, error)
+	history     []ExternalServiceStoreReconcileExternalServiceReposFuncCall
 	mutex       sync.Mutex
 }
 
-// GetLastSyncError delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockExternalServiceStore) GetLastSyncError(v0 context.Context, v1 int64) (string, error) {
-	r0, r1 := m.GetLastSyncErrorFunc.nextHook()(v0, v1)
-	m.GetLastSyncErrorFunc.appendCall(ExternalServiceStoreGetLastSyncErrorFuncCall{v0, v1, r0, r1})
+// ReconcileExternalServiceRepos delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockExternalServiceStore) ReconcileExternalServiceRepos(v0 context.Context) (int, error) {
+	r0, r1 := m.ReconcileExternalServiceReposFunc.nextHook()(v0)
+	m.ReconcileExternalServiceReposFunc.appendCall(ExternalServiceStoreReconcileExternalServiceReposFuncCall{v0, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetLastSyncError
-// method of the parent MockExternalServiceStore instance is invoked and the
-// hook queue is empty.
-func (f *ExternalServiceStoreGetLastSyncErrorFunc) SetDefaultHook(hook func(context.Context, int64) (string, error)) {
+// SetDefaultHook sets function that is called when the
+// ReconcileExternalServiceRepos method of the parent
+// MockExternalServiceStore instance is invoked and the hook queue is empty.
+func (f *ExternalServiceStoreReconcileExternalServiceReposFunc) SetDefaultHook(hook func(context.Context) (int, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetLastSyncError method of the parent MockExternalServiceStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *ExternalServiceStoreGetLastSyncErrorFunc) PushHook(hook func(context.Context, int64) (string, error)) {
+// ReconcileExternalServiceRepos method of the parent
+// MockExternalServiceStore instance invokes the hook at the front of the
+// queue and discards it. After the queue is empty, the default hook
+// function is invoked for any future action.
+func (f *ExternalServiceStoreReconcileExternalServiceReposFunc) PushHook(hook func(context.Context) (int, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -11777,20 +14434,20 @@ func (f *ExternalServiceStoreGetLastSyncErrorFunc) PushHook(hook func(context.Co
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *ExternalServiceStoreGetLastSyncErrorFunc) SetDefaultReturn(r0 string, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64) (string, error) {
+func (f *ExternalServiceStoreReconcileExternalServiceReposFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context) (int, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *ExternalServiceStoreGetLastSyncErrorFunc) PushReturn(r0 string, r1 error) {
-	f.PushHook(func(context.Context, int64) (string, error) {
+func (f *ExternalServiceStoreReconcileExternalServiceReposFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context) (int, error) {
 		return r0, r1
 	})
 }
 
-func (f *ExternalServiceStoreGetLastSyncErrorFunc) nextHook() func(context.Context, int64) (string, error) {
+func (f *ExternalServiceStoreReconcileExternalServiceReposFunc) nextHook() func(context.Context) (int, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -11803,37 +14460,34 @@ func (f *ExternalServiceStoreGetLastSyncErrorFunc) nextHook() func(context.Conte
 	return hook
 }
 
-func (f *ExternalServiceStoreGetLastSyncErrorFunc) appendCall(r0 ExternalServiceStoreGetLastSyncErrorFuncCall) {
+func (f *ExternalServiceStoreReconcileExternalServiceReposFunc) appendCall(r0 ExternalServiceStoreReconcileExternalServiceReposFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
 // History returns a sequence of
-// ExternalServiceStoreGetLastSyncErrorFuncCall objects describing the
-// invocations of this function.
-func (f *ExternalServiceStoreGetLastSyncErrorFunc) History() []ExternalServiceStoreGetLastSyncErrorFuncCall {
+// ExternalServiceStoreReconcileExternalServiceReposFuncCall objects
+// describing the invocations of this function.
+func (f *ExternalServiceStoreReconcileExternalServiceReposFunc) History() []ExternalServiceStoreReconcileExternalServiceReposFuncCall {
 	f.mutex.Lock()
-	history := make([]ExternalServiceStoreGetLastSyncErrorFuncCall, len(f.history))
+	history := make([]ExternalServiceStoreReconcileExternalServiceReposFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ExternalServiceStoreGetLastSyncErrorFuncCall is an object that describes
-// an invocation of method GetLastSyncError on an instance of
-// MockExternalServiceStore.
-type ExternalServiceStoreGetLastSyncErrorFuncCall struct {
+// ExternalServiceStoreReconcileExternalServiceReposFuncCall is an object
+// that describes an invocation of method ReconcileExternalServiceRepos on
+// an instance of MockExternalServiceStore.
+type ExternalServiceStoreReconcileExternalServiceReposFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
-	Arg1 int64
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 string
+	Result0 int
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -11841,47 +14495,46 @@ type ExternalServiceStoreGetLastSyncErrorFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c ExternalServiceStoreGetLastSyncErrorFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c ExternalServiceStoreReconcileExternalServiceReposFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ExternalServiceStoreGetLastSyncErrorFuncCall) Results() []interface{} {
+func (c ExternalServiceStoreReconcileExternalServiceReposFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// ExternalServiceStoreGetSyncJobsFunc describes the behavior when the
-// GetSyncJobs method of the parent MockExternalServiceStore instance is
+// ExternalServiceStoreRepoCountFunc describes the behavior when the
+// RepoCount method of the parent MockExternalServiceStore instance is
 // invoked.
-type ExternalServiceStoreGetSyncJobsFunc struct {
-	defaultHook func(context.Context) ([]*types.ExternalServiceSyncJob, error)
-	hooks       []func(context.Context) ([]*types.ExternalServiceSyncJob, error)
-	history     []ExternalServiceStoreGetSyncJobsFuncCall
+type ExternalServiceStoreRepoCountFunc struct {
+	defaultHook func(context.Context, int64) (int32, error)
+	hooks       []func(context.Context, int64) (int32, error)
+	history     []ExternalServiceStoreRepoCountFuncCall
 	mutex       sync.Mutex
 }
 
-// GetSyncJobs delegates to the next hook function in the queue and stores
-// the parameter and result values of this invocation.
-func (m *MockExternalServiceStore) GetSyncJobs(v0 context.Context) ([]*types.ExternalServiceSyncJob, error) {
-	r0, r1 := m.GetSyncJobsFunc.nextHook()(v0)
-	m.GetSyncJobsFunc.appendCall(ExternalServiceStoreGetSyncJobsFuncCall{v0, r0, r1})
+// RepoCount delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockExternalServiceStore) RepoCount(v0 context.Context, v1 int64) (int32, error) {
+	r0, r1 := m.RepoCountFunc.nextHook()(v0, v1)
+	m.RepoCountFunc.appendCall(ExternalServiceStoreRepoCountFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetSyncJobs method
-// of the parent MockExternalServiceStore instance is invoked and the hook
+// SetDefaultHook sets function that is called when the RepoCount method of
+// the parent MockExternalServiceStore instance is invoked and the hook
 // queue is empty.
-func (f *ExternalServiceStoreGetSyncJobsFunc) SetDefaultHook(hook func(context.Context) ([]*types.ExternalServiceSyncJob, error)) {
+func (f *ExternalServiceStoreRepoCountFunc) SetDefaultHook(hook func(context.Context, int64) (int32, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetSyncJobs method of the parent MockExternalServiceStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *ExternalServiceStoreGetSyncJobsFunc) PushHook(hook func(context.Context) ([]*types.ExternalServiceSyncJob, error)) {
+// RepoCount method of the parent MockExternalServiceStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *ExternalServiceStoreRepoCountFunc) PushHook(hook func(context.Context, int64) (int32, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -11889,20 +14542,20 @@ func (f *ExternalServiceStoreGetSyncJobsFunc) PushHook(hook func(context.Context
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *ExternalServiceStoreGetSyncJobsFunc) SetDefaultReturn(r0 []*types.ExternalServiceSyncJob, r1 error) {
-	f.SetDefaultHook(func(context.Context) ([]*types.ExternalServiceSyncJob, error) {
+func (f *ExternalServiceStoreRepoCountFunc) SetDefaultReturn(r0 int32, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (int32, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *ExternalServiceStoreGetSyncJobsFunc) PushReturn(r0 []*types.ExternalServiceSyncJob, r1 error) {
-	f.PushHook(func(context.Context) ([]*types.ExternalServiceSyncJob, error) {
+func (f *ExternalServiceStoreRepoCountFunc) PushReturn(r0 int32, r1 error) {
+	f.PushHook(func(context.Context, int64) (int32, error) {
 		return r0, r1
 	})
 }
 
-func (f *ExternalServiceStoreGetSyncJobsFunc) nextHook() func(context.Context) ([]*types.ExternalServiceSyncJob, error) {
+func (f *ExternalServiceStoreRepoCountFunc) nextHook() func(context.Context, int64) (int32, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -11915,33 +14568,36 @@ func (f *ExternalServiceStoreGetSyncJobsFunc) nextHook() func(context.Context) (
 	return hook
 }
 
-func (f *ExternalServiceStoreGetSyncJobsFunc) appendCall(r0 ExternalServiceStoreGetSyncJobsFuncCall) {
+func (f *ExternalServiceStoreRepoCountFunc) appendCall(r0 ExternalServiceStoreRepoCountFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of ExternalServiceStoreGetSyncJobsFuncCall
+// History returns a sequence of ExternalServiceStoreRepoCountFuncCall
 // objects describing the invocations of this function.
-func (f *ExternalServiceStoreGetSyncJobsFunc) History() []ExternalServiceStoreGetSyncJobsFuncCall {
+func (f *ExternalServiceStoreRepoCountFunc) History() []ExternalServiceStoreRepoCountFuncCall {
 	f.mutex.Lock()
-	history := make([]ExternalServiceStoreGetSyncJobsFuncCall, len(f.history))
+	history := make([]ExternalServiceStoreRepoCountFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ExternalServiceStoreGetSyncJobsFuncCall is an object that describes an
-// invocation of method GetSyncJobs on an instance of
+// ExternalServiceStoreRepoCountFuncCall is an object that describes an
+// invocation of method RepoCount on an instance of
 // MockExternalServiceStore.
-type ExternalServiceStoreGetSyncJobsFuncCall struct {
+type ExternalServiceStoreRepoCountFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []*types.ExternalServiceSyncJob
+	Result0 int32
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -11949,45 +14605,47 @@ type ExternalServiceStoreGetSyncJobsFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c ExternalServiceStoreGetSyncJobsFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c ExternalServiceStoreRepoCountFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ExternalServiceStoreGetSyncJobsFuncCall) Results() []interface{} {
+func (c ExternalServiceStoreRepoCountFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// ExternalServiceStoreHandleFunc describes the behavior when the Handle
-// method of the parent MockExternalServiceStore instance is invoked.
-type ExternalServiceStoreHandleFunc struct {
-	defaultHook func() *basestore.TransactableHandle
-	hooks       []func() *basestore.TransactableHandle
-	history     []ExternalServiceStoreHandleFuncCall
+// ExternalServiceStoreRequeueStuckSyncJobsFunc describes the behavior when
+// the RequeueStuckSyncJobs method of the parent MockExternalServiceStore
+// instance is invoked.
+type ExternalServiceStoreRequeueStuckSyncJobsFunc struct {
+	defaultHook func(context.Context, time.Duration) (int, error)
+	hooks       []func(context.Context, time.Duration) (int, error)
+	history     []ExternalServiceStoreRequeueStuckSyncJobsFuncCall
 	mutex       sync.Mutex
 }
 
-// Handle delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockExternalServiceStore) Handle() *basestore.TransactableHandle {
-	r0 := m.HandleFunc.nextHook()()
-	m.HandleFunc.appendCall(ExternalServiceStoreHandleFuncCall{r0})
-	return r0
+// RequeueStuckSyncJobs delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockExternalServiceStore) RequeueStuckSyncJobs(v0 context.Context, v1 time.Duration) (int, error) {
+	r0, r1 := m.RequeueStuckSyncJobsFunc.nextHook()(v0, v1)
+	m.RequeueStuckSyncJobsFunc.appendCall(ExternalServiceStoreRequeueStuckSyncJobsFuncCall{v0, v1, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Handle method of the
-// parent MockExternalServiceStore instance is invoked and the hook queue is
-// empty.
-func (f *ExternalServiceStoreHandleFunc) SetDefaultHook(hook func() *basestore.TransactableHandle) {
+// SetDefaultHook sets function that is called when the RequeueStuckSyncJobs
+// method of the parent MockExternalServiceStore instance is invoked and the
+// hook queue is empty.
+func (f *ExternalServiceStoreRequeueStuckSyncJobsFunc) SetDefaultHook(hook func(context.Context, time.Duration) (int, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Handle method of the parent MockExternalServiceStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *ExternalServiceStoreHandleFunc) PushHook(hook func() *basestore.TransactableHandle) {
+// RequeueStuckSyncJobs method of the parent MockExternalServiceStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *ExternalServiceStoreRequeueStuckSyncJobsFunc) PushHook(hook func(context.Context, time.Duration) (int, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -11995,20 +14653,20 @@ func (f *ExternalServiceStoreHandleFunc) PushHook(hook func() *basestore.Transac
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *ExternalServiceStoreHandleFunc) SetDefaultReturn(r0 *basestore.TransactableHandle) {
-	f.SetDefaultHook(func() *basestore.TransactableHandle {
-		return r0
+func (f *ExternalServiceStoreRequeueStuckSyncJobsFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, time.Duration) (int, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *ExternalServiceStoreHandleFunc) PushReturn(r0 *basestore.TransactableHandle) {
-	f.PushHook(func() *basestore.TransactableHandle {
-		return r0
+func (f *ExternalServiceStoreRequeueStuckSyncJobsFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, time.Duration) (int, error) {
+		return r0, r1
 	})
 }
 
-func (f *ExternalServiceStoreHandleFunc) nextHook() func() *basestore.TransactableHandle {
+func (f *ExternalServiceStoreRequeueStuckSyncJobsFunc) nextHook() func(context.Context, time.Duration) (int, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -12021,72 +14679,83 @@ func (f *ExternalServiceStoreHandleFunc) nextHook() func() *basestore.Transactab
 	return hook
 }
 
-func (f *ExternalServiceStoreHandleFunc) appendCall(r0 ExternalServiceStoreHandleFuncCall) {
+func (f *ExternalServiceStoreRequeueStuckSyncJobsFunc) appendCall(r0 ExternalServiceStoreRequeueStuckSyncJobsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of ExternalServiceStoreHandleFuncCall objects
-// describing the invocations of this function.
-func (f *ExternalServiceStoreHandleFunc) History() []ExternalServiceStoreHandleFuncCall {
+// History returns a sequence of
+// ExternalServiceStoreRequeueStuckSyncJobsFuncCall objects describing the
+// invocations of this function.
+func (f *ExternalServiceStoreRequeueStuckSyncJobsFunc) History() []ExternalServiceStoreRequeueStuckSyncJobsFuncCall {
 	f.mutex.Lock()
-	history := make([]ExternalServiceStoreHandleFuncCall, len(f.history))
+	history := make([]ExternalServiceStoreRequeueStuckSyncJobsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ExternalServiceStoreHandleFuncCall is an object that describes an
-// invocation of method Handle on an instance of MockExternalServiceStore.
-type ExternalServiceStoreHandleFuncCall struct {
+// ExternalServiceStoreRequeueStuckSyncJobsFuncCall is an object that
+// describes an invocation of method RequeueStuckSyncJobs on an instance of
+// MockExternalServiceStore.
+type ExternalServiceStoreRequeueStuckSyncJobsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 time.Duration
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *basestore.TransactableHandle
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c ExternalServiceStoreHandleFuncCall) Args() []interface{} {
-	return []interface{}{}
+func (c ExternalServiceStoreRequeueStuckSyncJobsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ExternalServiceStoreHandleFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c ExternalServiceStoreRequeueStuckSyncJobsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// ExternalServiceStoreListFunc describes the behavior when the List method
-// of the parent MockExternalServiceStore instance is invoked.
-type ExternalServiceStoreListFunc struct {
-	defaultHook func(context.Context, ExternalServicesListOptions) ([]*types.ExternalService, error)
-	hooks       []func(context.Context, ExternalServicesListOptions) ([]*types.ExternalService, error)
-	history     []ExternalServiceStoreListFuncCall
+// ExternalServiceStoreResumeFunc describes the behavior when the Resume
+// method of the parent MockExternalServiceStore instance is invoked.
+type ExternalServiceStoreResumeFunc struct {
+	defaultHook func(context.Context, int64) error
+	hooks       []func(context.Context, int64) error
+	history     []ExternalServiceStoreResumeFuncCall
 	mutex       sync.Mutex
 }
 
-// List delegates to the next hook function in the queue and stores the
+// Resume delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockExternalServiceStore) List(v0 context.Context, v1 ExternalServicesListOptions) ([]*types.ExternalService, error) {
-	r0, r1 := m.ListFunc.nextHook()(v0, v1)
-	m.ListFunc.appendCall(ExternalServiceStoreListFuncCall{v0, v1, r0, r1})
-	return r0, r1
+func (m *MockExternalServiceStore) Resume(v0 context.Context, v1 int64) error {
+	r0 := m.ResumeFunc.nextHook()(v0, v1)
+	m.ResumeFunc.appendCall(ExternalServiceStoreResumeFuncCall{v0, v1, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the List method of the
+// SetDefaultHook sets function that is called when the Resume method of the
 // parent MockExternalServiceStore instance is invoked and the hook queue is
 // empty.
-func (f *ExternalServiceStoreListFunc) SetDefaultHook(hook func(context.Context, ExternalServicesListOptions) ([]*types.ExternalService, error)) {
+func (f *ExternalServiceStoreResumeFunc) SetDefaultHook(hook func(context.Context, int64) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// List method of the parent MockExternalServiceStore instance invokes the
+// Resume method of the parent MockExternalServiceStore instance invokes the
 // hook at the front of the queue and discards it. After the queue is empty,
 // the default hook function is invoked for any future action.
-func (f *ExternalServiceStoreListFunc) PushHook(hook func(context.Context, ExternalServicesListOptions) ([]*types.ExternalService, error)) {
+func (f *ExternalServiceStoreResumeFunc) PushHook(hook func(context.Context, int64) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -12094,20 +14763,20 @@ func (f *ExternalServiceStoreListFunc) PushHook(hook func(context.Context, Exter
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *ExternalServiceStoreListFunc) SetDefaultReturn(r0 []*types.ExternalService, r1 error) {
-	f.SetDefaultHook(func(context.Context, ExternalServicesListOptions) ([]*types.ExternalService, error) {
-		return r0, r1
+func (f *ExternalServiceStoreResumeFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64) error {
+		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *ExternalServiceStoreListFunc) PushReturn(r0 []*types.ExternalService, r1 error) {
-	f.PushHook(func(context.Context, ExternalServicesListOptions) ([]*types.ExternalService, error) {
-		return r0, r1
+func (f *ExternalServiceStoreResumeFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64) error {
+		return r0
 	})
 }
 
-func (f *ExternalServiceStoreListFunc) nextHook() func(context.Context, ExternalServicesListOptions) ([]*types.ExternalService, error) {
+func (f *ExternalServiceStoreResumeFunc) nextHook() func(context.Context, int64) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -12120,82 +14789,80 @@ func (f *ExternalServiceStoreListFunc) nextHook() func(context.Context, External
 	return hook
 }
 
-func (f *ExternalServiceStoreListFunc) appendCall(r0 ExternalServiceStoreListFuncCall) {
+func (f *ExternalServiceStoreResumeFunc) appendCall(r0 ExternalServiceStoreResumeFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of ExternalServiceStoreListFuncCall objects
+// History returns a sequence of ExternalServiceStoreResumeFuncCall objects
 // describing the invocations of this function.
-func (f *ExternalServiceStoreListFunc) History() []ExternalServiceStoreListFuncCall {
+func (f *ExternalServiceStoreResumeFunc) History() []ExternalServiceStoreResumeFuncCall {
 	f.mutex.Lock()
-	history := make([]ExternalServiceStoreListFuncCall, len(f.history))
+	history := make([]ExternalServiceStoreResumeFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ExternalServiceStoreListFuncCall is an object that describes an
-// invocation of method List on an instance of MockExternalServiceStore.
-type ExternalServiceStoreListFuncCall struct {
+// ExternalServiceStoreResumeFuncCall is an object that describes an
+// invocation of method Resume on an instance of MockExternalServiceStore.
+type ExternalServiceStoreResumeFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 ExternalServicesListOptions
+	Arg1 int64
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []*types.ExternalService
-	// Result1 is the value of the 2nd result returned from this method
-	// invocation.
-	Result1 error
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c ExternalServiceStoreListFuncCall) Args() []interface{} {
+func (c ExternalServiceStoreResumeFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ExternalServiceStoreListFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c ExternalServiceStoreResumeFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
-// ExternalServiceStoreRepoCountFunc describes the behavior when the
-// RepoCount method of the parent MockExternalServiceStore instance is
+// ExternalServiceStoreSetUnrestrictedFunc describes the behavior when the
+// SetUnrestricted method of the parent MockExternalServiceStore instance is
 // invoked.
-type ExternalServiceStoreRepoCountFunc struct {
-	defaultHook func(context.Context, int64) (int32, error)
-	hooks       []func(context.Context, int64) (int32, error)
-	history     []ExternalServiceStoreRepoCountFuncCall
+type ExternalServiceStoreSetUnrestrictedFunc struct {
+	defaultHook func(context.Context, int64, bool) error
+	hooks       []func(context.Context, int64, bool) error
+	history     []ExternalServiceStoreSetUnrestrictedFuncCall
 	mutex       sync.Mutex
 }
 
-// RepoCount delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockExternalServiceStore) RepoCount(v0 context.Context, v1 int64) (int32, error) {
-	r0, r1 := m.RepoCountFunc.nextHook()(v0, v1)
-	m.RepoCountFunc.appendCall(ExternalServiceStoreRepoCountFuncCall{v0, v1, r0, r1})
-	return r0, r1
+// SetUnrestricted delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockExternalServiceStore) SetUnrestricted(v0 context.Context, v1 int64, v2 bool) error {
+	r0 := m.SetUnrestrictedFunc.nextHook()(v0, v1, v2)
+	m.SetUnrestrictedFunc.appendCall(ExternalServiceStoreSetUnrestrictedFuncCall{v0, v1, v2, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the RepoCount method of
-// the parent MockExternalServiceStore instance is invoked and the hook
-// queue is empty.
-func (f *ExternalServiceStoreRepoCountFunc) SetDefaultHook(hook func(context.Context, int64) (int32, error)) {
+// SetDefaultHook sets function that is called when the SetUnrestricted
+// method of the parent MockExternalServiceStore instance is invoked and the
+// hook queue is empty.
+func (f *ExternalServiceStoreSetUnrestrictedFunc) SetDefaultHook(hook func(context.Context, int64, bool) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// RepoCount method of the parent MockExternalServiceStore instance invokes
-// the hook at the front of the queue and discards it. After the queue is
-// empty, the default hook function is invoked for any future action.
-func (f *ExternalServiceStoreRepoCountFunc) PushHook(hook func(context.Context, int64) (int32, error)) {
+// SetUnrestricted method of the parent MockExternalServiceStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *ExternalServiceStoreSetUnrestrictedFunc) PushHook(hook func(context.Context, int64, bool) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -12203,20 +14870,20 @@ func (f *ExternalServiceStoreRepoCountFunc) PushHook(hook func(context.Context,
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *ExternalServiceStoreRepoCountFunc) SetDefaultReturn(r0 int32, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64) (int32, error) {
-		return r0, r1
+func (f *ExternalServiceStoreSetUnrestrictedFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, bool) error {
+		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *ExternalServiceStoreRepoCountFunc) PushReturn(r0 int32, r1 error) {
-	f.PushHook(func(context.Context, int64) (int32, error) {
-		return r0, r1
+func (f *ExternalServiceStoreSetUnrestrictedFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, bool) error {
+		return r0
 	})
 }
 
-func (f *ExternalServiceStoreRepoCountFunc) nextHook() func(context.Context, int64) (int32, error) {
+func (f *ExternalServiceStoreSetUnrestrictedFunc) nextHook() func(context.Context, int64, bool) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -12229,51 +14896,51 @@ func (f *ExternalServiceStoreRepoCountFunc) nextHook() func(context.Context, int
 	return hook
 }
 
-func (f *ExternalServiceStoreRepoCountFunc) appendCall(r0 ExternalServiceStoreRepoCountFuncCall) {
+func (f *ExternalServiceStoreSetUnrestrictedFunc) appendCall(r0 ExternalServiceStoreSetUnrestrictedFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of ExternalServiceStoreRepoCountFuncCall
+// History returns a sequence of ExternalServiceStoreSetUnrestrictedFuncCall
 // objects describing the invocations of this function.
-func (f *ExternalServiceStoreRepoCountFunc) History() []ExternalServiceStoreRepoCountFuncCall {
+func (f *ExternalServiceStoreSetUnrestrictedFunc) History() []ExternalServiceStoreSetUnrestrictedFuncCall {
 	f.mutex.Lock()
-	history := make([]ExternalServiceStoreRepoCountFuncCall, len(f.history))
+	history := make([]ExternalServiceStoreSetUnrestrictedFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ExternalServiceStoreRepoCountFuncCall is an object that describes an
-// invocation of method RepoCount on an instance of
+// ExternalServiceStoreSetUnrestrictedFuncCall is an object that describes
+// an invocation of method SetUnrestricted on an instance of
 // MockExternalServiceStore.
-type ExternalServiceStoreRepoCountFuncCall struct {
+type ExternalServiceStoreSetUnrestrictedFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
 	Arg1 int64
-	// Result0 is the value of the 1st result returned from this method
+	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Result0 int32
-	// Result1 is the value of the 2nd result returned from this method
+	Arg2 bool
+	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result1 error
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c ExternalServiceStoreRepoCountFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c ExternalServiceStoreSetUnrestrictedFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ExternalServiceStoreRepoCountFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c ExternalServiceStoreSetUnrestrictedFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
 // ExternalServiceStoreSyncDueFunc describes the behavior when the SyncDue
@@ -119,6 +119,12 @@ func (s *AWSCodeCommitSource) ExternalServices() types.ExternalServices {
 	return types.ExternalServices{s.svc}
 }
 
+// CheckConnection assumes the code host is reachable; errors surface on the
+// first sync.
+func (s *AWSCodeCommitSource) CheckConnection(ctx context.Context) error {
+	return nil
+}
+
 func (s *AWSCodeCommitSource) makeRepo(r *awscodecommit.Repository) (*types.Repo, error) {
 	urn := s.svc.URN()
 	serviceID := awscodecommit.ServiceID(s.awsPartition, s.awsRegion, r.AccountID)
@@ -90,6 +90,12 @@ func (s BitbucketCloudSource) ExternalServices() types.ExternalServices {
 	return types.ExternalServices{s.svc}
 }
 
+// CheckConnection assumes the code host is reachable; errors surface on the
+// first sync.
+func (s BitbucketCloudSource) CheckConnection(ctx context.Context) error {
+	return nil
+}
+
 func (s BitbucketCloudSource) makeRepo(r *bitbucketcloud.Repo) *types.Repo {
 	host, err := url.Parse(s.config.Url)
 	if err != nil {
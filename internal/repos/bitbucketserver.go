@@ -113,6 +113,12 @@ func (s BitbucketServerSource) ExternalServices() types.ExternalServices {
 	return types.ExternalServices{s.svc}
 }
 
+// CheckConnection assumes the code host is reachable; errors surface on the
+// first sync.
+func (s BitbucketServerSource) CheckConnection(ctx context.Context) error {
+	return nil
+}
+
 func (s BitbucketServerSource) makeRepo(repo *bitbucketserver.Repo, isArchived bool) *types.Repo {
 	host, err := url.Parse(s.config.Url)
 	if err != nil {
@@ -14,6 +14,19 @@ func ConfRepoListUpdateInterval() time.Duration {
 	return time.Duration(v) * time.Minute
 }
 
+func ConfRepoListUpdateIntervalMax() time.Duration {
+	v := conf.Get().RepoListUpdateIntervalMax
+	if v == 0 { //  default to 8 hours
+		v = 480
+	}
+	d := time.Duration(v) * time.Minute
+	// The cap can't be lower than the floor.
+	if min := ConfRepoListUpdateInterval(); d < min {
+		return min
+	}
+	return d
+}
+
 func ConfRepoConcurrentExternalServiceSyncers() int {
 	v := conf.Get().RepoConcurrentExternalServiceSyncers
 	if v <= 0 {
@@ -305,6 +305,17 @@ func (s GithubSource) ExternalServices() types.ExternalServices {
 	return types.ExternalServices{s.svc}
 }
 
+// CheckConnection tests the connection by fetching the authenticated user.
+// Unauthenticated connections are assumed reachable; their errors surface on
+// the first sync.
+func (s GithubSource) CheckConnection(ctx context.Context) error {
+	if s.config.Token == "" {
+		return nil
+	}
+	_, err := s.v3Client.GetAuthenticatedUser(ctx)
+	return errors.Wrap(err, "connection check failed")
+}
+
 // GetRepo returns the Github repository with the given name and owner
 // ("org/repo-name")
 func (s GithubSource) GetRepo(ctx context.Context, nameWithOwner string) (*types.Repo, error) {
@@ -179,6 +179,11 @@ func (s GitLabSource) ExternalServices() types.ExternalServices {
 	return types.ExternalServices{s.svc}
 }
 
+// CheckConnection tests the connection by fetching the authenticated user.
+func (s GitLabSource) CheckConnection(ctx context.Context) error {
+	return errors.Wrap(s.client.ValidateToken(ctx), "connection check failed")
+}
+
 func (s GitLabSource) makeRepo(proj *gitlab.Project) *types.Repo {
 	urn := s.svc.URN()
 	return &types.Repo{
@@ -89,6 +89,12 @@ func (s GitoliteSource) ExternalServices() types.ExternalServices {
 	return types.ExternalServices{s.svc}
 }
 
+// CheckConnection assumes the code host is reachable; errors surface on the
+// first sync.
+func (s GitoliteSource) CheckConnection(ctx context.Context) error {
+	return nil
+}
+
 func (s GitoliteSource) excludes(gr *gitolite.Repo, r *types.Repo) bool {
 	return s.exclude(gr.Name) ||
 		strings.ContainsAny(string(r.Name), "\\^$|()[]*?{},")
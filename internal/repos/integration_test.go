@@ -31,23 +31,36 @@ func TestIntegration(t *testing.T) {
 	}{
 		{"SyncRateLimiters", testSyncRateLimiters},
 		{"EnqueueSyncJobs", testStoreEnqueueSyncJobs},
+		{"EnqueueSyncJobsBatched", testStoreEnqueueSyncJobsBatched},
 		{"EnqueueSingleSyncJob", testStoreEnqueueSingleSyncJob},
+		{"EnqueueFailedSyncJobs", testStoreEnqueueFailedSyncJobs},
+		{"EnqueueSyncJobsPaused", testStoreEnqueueSyncJobsPaused},
+		{"ListReposPendingDeletion", testStoreListReposPendingDeletion},
 		{"ListExternalServiceUserIDsByRepoID", testStoreListExternalServiceUserIDsByRepoID},
 		{"ListExternalServicePrivateRepoIDsByUserID", testStoreListExternalServicePrivateRepoIDsByUserID},
 		{"Syncer/SyncWorker", testSyncWorkerPlumbing},
 		{"Syncer/Sync", testSyncerSync},
 		{"Syncer/SyncRepo", testSyncRepo},
+		{"Syncer/SyncReposBatch", testSyncReposBatch},
 		{"Syncer/Run", testSyncRun},
+		{"Syncer/RunSkipsInitialUnmodifiedDiff", testSyncRunSkipsInitialUnmodifiedDiff},
+		{"Syncer/ResumesFromCursor", testSyncResumesFromCursor},
+		{"Syncer/LogsDiffCounts", testSyncLogsDiffCounts},
+		{"Syncer/SourceTimeout", testSyncSourceTimeout},
 		{"Syncer/MultipleServices", testSyncerMultipleServices},
 		{"Syncer/OrphanedRepos", testOrphanedRepo},
+		{"Syncer/PreserveOnError", testPreserveOnError},
+		{"Syncer/NonFatalErrors", testNonFatalErrors},
 		{"Syncer/CloudDefaultExternalServicesDontSync", testCloudDefaultExternalServicesDontSync},
 		{"Syncer/DeleteExternalService", testDeleteExternalService},
 		{"Syncer/AbortSyncWhenThereIsRepoLimitError", testAbortSyncWhenThereIsRepoLimitError},
+		{"Syncer/OrgReposMaxPerOrgOverride", testOrgReposMaxPerOrgOverride},
 		{"Syncer/UserAndOrgReposAreCountedCorrectly", testUserAndOrgReposAreCountedCorrectly},
 		{"Syncer/UserAddedRepos", testUserAddedRepos},
 		{"Syncer/NameConflictOnRename", testNameOnConflictOnRename},
 		{"Syncer/ConflictingSyncers", testConflictingSyncers},
 		{"Syncer/SyncRepoMaintainsOtherSources", testSyncRepoMaintainsOtherSources},
+		{"Syncer/SyncRepoBackgroundRetries", testSyncRepoBackgroundRetries},
 		{"Syncer/SyncReposWithLastErrors", testSyncReposWithLastErrors},
 		{"Syncer/SyncReposWithLastErrorsHitRateLimit", testSyncReposWithLastErrorsHitsRateLimiter},
 	} {
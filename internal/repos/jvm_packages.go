@@ -185,6 +185,12 @@ func (s *JVMPackagesSource) ExternalServices() types.ExternalServices {
 	return types.ExternalServices{s.svc}
 }
 
+// CheckConnection assumes the code host is reachable; errors surface on the
+// first sync.
+func (s *JVMPackagesSource) CheckConnection(ctx context.Context) error {
+	return nil
+}
+
 func MavenDependencies(connection schema.JVMPackagesConnection) (dependencies []*reposource.MavenDependency, err error) {
 	for _, dep := range connection.Maven.Dependencies {
 		dependency, err := reposource.ParseMavenDependency(dep)
@@ -3,6 +3,7 @@ package repos
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/inconshreveable/log15"
 	"github.com/prometheus/client_golang/prometheus"
@@ -50,6 +51,16 @@ var (
 		Help: "Total number of synced repositories",
 	}, []string{tagState})
 
+	enqueueFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "src_repoupdater_syncer_enqueue_failures_total",
+		Help: "Total number of failed attempts to enqueue sync jobs",
+	})
+
+	sourcingRate = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "src_repoupdater_syncer_sourcing_rate",
+		Help: "Repositories sourced per second by the currently running sync. A drop indicates the code host is throttling us. Zero when no sync is running.",
+	}, []string{"kind"})
+
 	purgeSuccess = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "src_repoupdater_purge_success",
 		Help: "Incremented each time we remove a repository clone.",
@@ -91,6 +102,16 @@ var (
 	})
 )
 
+// recordSourcingRate sets the sourcing rate gauge for the given external
+// service kind to sourced repos per second. A non-positive elapsed duration
+// leaves the gauge untouched.
+func recordSourcingRate(kind string, sourced int, elapsed time.Duration) {
+	if elapsed <= 0 {
+		return
+	}
+	sourcingRate.WithLabelValues(kind).Set(float64(sourced) / elapsed.Seconds())
+}
+
 func MustRegisterMetrics(db dbutil.DB, sourcegraphDotCom bool) {
 	scanCount := func(sql string) (float64, error) {
 		row := db.QueryRowContext(context.Background(), sql)
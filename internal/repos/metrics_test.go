@@ -0,0 +1,24 @@
+package repos
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestRecordSourcingRate(t *testing.T) {
+	recordSourcingRate("GITHUB", 10, 2*time.Second)
+
+	if have, want := testutil.ToFloat64(sourcingRate.WithLabelValues("GITHUB")), 5.0; have != want {
+		t.Fatalf("unexpected sourcing rate. want=%f have=%f", want, have)
+	}
+
+	// A non-positive elapsed duration leaves the gauge untouched instead of
+	// dividing by zero.
+	recordSourcingRate("GITHUB", 20, 0)
+
+	if have, want := testutil.ToFloat64(sourcingRate.WithLabelValues("GITHUB")), 5.0; have != want {
+		t.Fatalf("unexpected sourcing rate. want=%f have=%f", want, have)
+	}
+}
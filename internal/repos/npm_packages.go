@@ -157,6 +157,12 @@ func (s *NPMPackagesSource) ExternalServices() types.ExternalServices {
 	return types.ExternalServices{s.svc}
 }
 
+// CheckConnection assumes the code host is reachable; errors surface on the
+// first sync.
+func (s *NPMPackagesSource) CheckConnection(ctx context.Context) error {
+	return nil
+}
+
 func (s *NPMPackagesSource) SetDB(db dbutil.DB) {
 	once.Do(func() {
 		observationContext = &observation.Context{
@@ -81,6 +81,12 @@ func (s OtherSource) ExternalServices() types.ExternalServices {
 	return types.ExternalServices{s.svc}
 }
 
+// CheckConnection assumes the code host is reachable; errors surface on the
+// first sync.
+func (s OtherSource) CheckConnection(ctx context.Context) error {
+	return nil
+}
+
 func (s OtherSource) cloneURLs() ([]*url.URL, error) {
 	if len(s.conn.Repos) == 0 {
 		return nil, nil
@@ -94,6 +94,12 @@ func (s *PagureSource) ExternalServices() types.ExternalServices {
 	return types.ExternalServices{s.svc}
 }
 
+// CheckConnection assumes the code host is reachable; errors surface on the
+// first sync.
+func (s *PagureSource) CheckConnection(ctx context.Context) error {
+	return nil
+}
+
 func (s *PagureSource) makeRepo(p *pagure.Project) (*types.Repo, error) {
 	urn := s.svc.URN()
 
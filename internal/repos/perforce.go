@@ -99,3 +99,9 @@ func (s PerforceSource) makeRepo(depot string) *types.Repo {
 func (s PerforceSource) ExternalServices() types.ExternalServices {
 	return types.ExternalServices{s.svc}
 }
+
+// CheckConnection assumes the code host is reachable; errors surface on the
+// first sync.
+func (s PerforceSource) CheckConnection(ctx context.Context) error {
+	return nil
+}
@@ -82,6 +82,12 @@ func (s *PhabricatorSource) ExternalServices() types.ExternalServices {
 	return types.ExternalServices{s.svc}
 }
 
+// CheckConnection assumes the code host is reachable; errors surface on the
+// first sync.
+func (s *PhabricatorSource) CheckConnection(ctx context.Context) error {
+	return nil
+}
+
 func (s *PhabricatorSource) makeRepo(repo *phabricator.Repo) (*types.Repo, error) {
 	var external []*phabricator.URI
 	builtin := make(map[string]*phabricator.URI)
@@ -443,6 +443,92 @@ func (s *updateScheduler) DebugDump(ctx context.Context, db dbutil.DB) interface
 	return &data
 }
 
+// SchedulerState is a typed snapshot of the update scheduler with a stable,
+// documented schema for JSON consumers, as opposed to the opaque blob
+// DebugDump renders into the HTML state template.
+type SchedulerState struct {
+	// Queue holds the repos waiting for or acquired for a git fetch, in
+	// dequeue order.
+	Queue []QueuedRepoUpdate `json:"queue"`
+	// Schedule holds the repos under periodic updates, soonest due first.
+	Schedule []ScheduledRepoUpdate `json:"schedule"`
+}
+
+// QueuedRepoUpdate describes one entry of the scheduler's update queue.
+type QueuedRepoUpdate struct {
+	ID       api.RepoID   `json:"id"`
+	Name     api.RepoName `json:"name"`
+	Priority string       `json:"priority"` // "low" or "high"
+	Seq      uint64       `json:"seq"`
+	// Updating is true once the repo has been acquired for a fetch that is
+	// still in progress.
+	Updating bool `json:"updating"`
+}
+
+// ScheduledRepoUpdate describes one entry of the scheduler's periodic
+// schedule.
+type ScheduledRepoUpdate struct {
+	ID              api.RepoID   `json:"id"`
+	Name            api.RepoName `json:"name"`
+	IntervalSeconds int          `json:"interval_seconds"`
+	Due             time.Time    `json:"due"`
+}
+
+func (p priority) String() string {
+	if p == priorityHigh {
+		return "high"
+	}
+	return "low"
+}
+
+// DebugState returns a typed snapshot of the scheduler's queue and schedule.
+func (s *updateScheduler) DebugState() *SchedulerState {
+	state := &SchedulerState{}
+
+	s.updateQueue.mu.Lock()
+	queue := updateQueue{
+		heap: make([]*repoUpdate, len(s.updateQueue.heap)),
+	}
+	for i, update := range s.updateQueue.heap {
+		updateCopy := *update
+		queue.heap[i] = &updateCopy
+	}
+	s.updateQueue.mu.Unlock()
+
+	for len(queue.heap) > 0 {
+		update := heap.Pop(&queue).(*repoUpdate)
+		state.Queue = append(state.Queue, QueuedRepoUpdate{
+			ID:       update.Repo.ID,
+			Name:     update.Repo.Name,
+			Priority: update.Priority.String(),
+			Seq:      update.Seq,
+			Updating: update.Updating,
+		})
+	}
+
+	s.schedule.mu.Lock()
+	schedule := schedule{
+		heap: make([]*scheduledRepoUpdate, len(s.schedule.heap)),
+	}
+	for i, update := range s.schedule.heap {
+		updateCopy := *update
+		schedule.heap[i] = &updateCopy
+	}
+	s.schedule.mu.Unlock()
+
+	for len(schedule.heap) > 0 {
+		update := heap.Pop(&schedule).(*scheduledRepoUpdate)
+		state.Schedule = append(state.Schedule, ScheduledRepoUpdate{
+			ID:              update.Repo.ID,
+			Name:            update.Repo.Name,
+			IntervalSeconds: int(update.Interval / time.Second),
+			Due:             update.Due,
+		})
+	}
+
+	return state
+}
+
 // ScheduleInfo returns the current schedule info for a repo.
 func (s *updateScheduler) ScheduleInfo(id api.RepoID) *protocol.RepoUpdateSchedulerInfoResult {
 	var result protocol.RepoUpdateSchedulerInfoResult
@@ -3,6 +3,8 @@ package repos
 import (
 	"container/heap"
 	"context"
+	"encoding/json"
+	"fmt"
 	"reflect"
 	"testing"
 	"time"
@@ -864,6 +866,51 @@ func TestScheduleInsertNew(t *testing.T) {
 	assertFront(repo2.Name)
 }
 
+func TestDebugState(t *testing.T) {
+	_, stop := startRecording()
+	defer stop()
+
+	s := NewUpdateScheduler()
+	s.upsert(&types.Repo{ID: 1, Name: "a"}, true)
+	s.UpdateOnce(2, "b")
+
+	state := s.DebugState()
+
+	// The high priority manual update dequeues before the low priority one.
+	if len(state.Queue) != 2 {
+		t.Fatalf("want 2 queued updates, got %+v", state.Queue)
+	}
+	if state.Queue[0].Name != "b" || state.Queue[0].Priority != "high" {
+		t.Errorf("want repo b at high priority first, got %+v", state.Queue[0])
+	}
+	if state.Queue[1].Name != "a" || state.Queue[1].Priority != "low" {
+		t.Errorf("want repo a at low priority second, got %+v", state.Queue[1])
+	}
+	if len(state.Schedule) != 1 || state.Schedule[0].Name != "a" {
+		t.Fatalf("want only repo a on the schedule, got %+v", state.Schedule)
+	}
+
+	// The JSON field names are a stable schema for external consumers.
+	data, err := json.Marshal(state.Queue[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := fmt.Sprintf(`{"id":2,"name":"b","priority":"high","seq":%d,"updating":false}`, state.Queue[0].Seq)
+	if string(data) != want {
+		t.Errorf("want queue entry JSON %s, got %s", want, data)
+	}
+
+	data, err = json.Marshal(state.Schedule[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = fmt.Sprintf(`{"id":1,"name":"a","interval_seconds":%d,"due":%q}`,
+		state.Schedule[0].IntervalSeconds, state.Schedule[0].Due.Format(time.RFC3339Nano))
+	if string(data) != want {
+		t.Errorf("want schedule entry JSON %s, got %s", want, data)
+	}
+}
+
 type mockRandomGenerator struct{}
 
 func (m *mockRandomGenerator) Int63n(n int64) int64 {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
@@ -39,6 +40,17 @@ func NewSourcer(cf *httpcli.Factory, decs ...func(Source) Source) Sourcer {
 
 // NewSource returns a repository yielding Source from the given ExternalService configuration.
 func NewSource(svc *types.ExternalService, cf *httpcli.Factory) (Source, error) {
+	// Normalize the config up front so individual sources parse plain JSON and
+	// a commented (JSONC) config never breaks a sync.
+	if strings.TrimSpace(svc.Config) != "" {
+		config, err := conf.NormalizeJSONC(svc.Config)
+		if err != nil {
+			return nil, errors.Wrapf(err, "normalizing config for external service %d", svc.ID)
+		}
+		svc = svc.Clone()
+		svc.Config = config
+	}
+
 	switch strings.ToUpper(svc.Kind) {
 	case extsvc.KindGitHub:
 		return NewGithubSource(svc, cf)
@@ -69,6 +81,25 @@ func NewSource(svc *types.ExternalService, cf *httpcli.Factory) (Source, error)
 	}
 }
 
+// TestConnection builds a source for the given external service kind and
+// config — without persisting anything — and performs a cheap authenticated
+// call against the code host. It complements the static validation in
+// ValidateConfig: a config can be well-formed and still carry revoked
+// credentials. The returned error keeps its type, so callers can distinguish
+// bad credentials (errcode.IsUnauthorized) and insufficient permissions
+// (errcode.IsForbidden) from plain connectivity failures.
+func TestConnection(ctx context.Context, sourcer Sourcer, kind, config string) error {
+	src, err := sourcer(&types.ExternalService{Kind: kind, Config: config})
+	if err != nil {
+		return errors.Wrap(err, "building source from config")
+	}
+
+	if err := src.CheckConnection(ctx); err != nil {
+		return errors.Wrap(err, "checking connection")
+	}
+	return nil
+}
+
 // A Source yields repositories to be stored and analysed by Sourcegraph.
 // Successive calls to its ListRepos method may yield different results.
 type Source interface {
@@ -77,6 +108,23 @@ type Source interface {
 	ListRepos(context.Context, chan SourceResult)
 	// ExternalServices returns the ExternalServices for the Source.
 	ExternalServices() types.ExternalServices
+	// CheckConnection returns an error if the Source cannot reach or
+	// authenticate against its code host. It's a cheap check run before the
+	// first scheduled sync of a newly configured external service.
+	CheckConnection(context.Context) error
+}
+
+// CursorSource captures the optional pagination cursor methods of a Source.
+// Sources that implement it can resume listing from an opaque cursor persisted
+// on the sync job row, so a restarted job skips pages already processed by a
+// crashed attempt. SetCursor is called once before ListRepos; Cursor is read
+// as results are streamed.
+type CursorSource interface {
+	// Cursor returns an opaque token representing listing progress, or the
+	// empty string if there is none.
+	Cursor() string
+	// SetCursor resumes a subsequent ListRepos from the given token.
+	SetCursor(string)
 }
 
 // RepoGetter captures the optional GetRepo method of a Source. It's used only
@@ -85,6 +133,13 @@ type RepoGetter interface {
 	GetRepo(context.Context, string) (*types.Repo, error)
 }
 
+// RepoBatchGetter captures the optional GetRepos method of a Source, which
+// fetches metadata for several repos in one call. Syncer.SyncRepos prefers it
+// over per-repo GetRepo calls when the source implements it.
+type RepoBatchGetter interface {
+	GetRepos(ctx context.Context, paths []string) ([]*types.Repo, error)
+}
+
 type DBSource interface {
 	Source
 	SetDB(dbutil.DB)
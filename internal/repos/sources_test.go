@@ -22,12 +22,14 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/phabricator"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/httptestutil"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/internal/types/typestest"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
@@ -724,3 +726,34 @@ func getAWSEnv(envVar string) string {
 	}
 	return s
 }
+
+func TestTestConnection(t *testing.T) {
+	ctx := context.Background()
+	svc := &types.ExternalService{Kind: extsvc.KindGitHub, Config: `{"url": "https://github.com"}`}
+
+	t.Run("healthy connection", func(t *testing.T) {
+		sourcer := NewFakeSourcer(nil, NewFakeSource(svc, nil))
+		if err := TestConnection(ctx, sourcer, svc.Kind, svc.Config); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	})
+
+	t.Run("bad credentials keep their type", func(t *testing.T) {
+		sourcer := NewFakeSourcer(nil, NewFakeSource(svc, &ErrUnauthorized{}))
+		err := TestConnection(ctx, sourcer, svc.Kind, svc.Config)
+		if err == nil {
+			t.Fatal("expected error, got none")
+		}
+		if !errcode.IsUnauthorized(err) {
+			t.Fatalf("want an unauthorized error, got %q", err)
+		}
+	})
+
+	t.Run("invalid config fails to build a source", func(t *testing.T) {
+		sourcer := NewFakeSourcer(errors.New("unexpected end of JSON input"), nil)
+		err := TestConnection(ctx, sourcer, svc.Kind, "{")
+		if err == nil {
+			t.Fatal("expected error, got none")
+		}
+	})
+}
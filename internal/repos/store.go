@@ -586,14 +586,73 @@ WHERE NOT EXISTS (
 	AND (
 		j.state IN ('queued', 'processing')
 		OR es.cloud_default
+		OR es.paused
 	)
 )
 `, extSvcID, extSvcID)
 	return s.Exec(ctx, q)
 }
 
+// EnqueueFailedSyncJobs enqueues a fresh sync job for every external service
+// whose most recent sync job errored, returning the number of jobs enqueued.
+// Like EnqueueSingleSyncJob it skips cloud_default external services.
+func (s *Store) EnqueueFailedSyncJobs(ctx context.Context) (enqueued int, err error) {
+	tr, ctx := s.trace(ctx, "Store.EnqueueFailedSyncJobs")
+
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	q := sqlf.Sprintf(enqueueFailedSyncJobsQueryFmtstr)
+	if err = s.QueryRow(ctx, q).Scan(&enqueued); err != nil {
+		return 0, err
+	}
+	return enqueued, nil
+}
+
+const enqueueFailedSyncJobsQueryFmtstr = `
+WITH latest AS (
+    SELECT DISTINCT ON (external_service_id) external_service_id, state
+    FROM external_service_sync_jobs
+    ORDER BY external_service_id, id DESC
+),
+enqueued AS (
+    INSERT INTO external_service_sync_jobs (external_service_id)
+    SELECT es.id
+    FROM external_services es
+    JOIN latest ON latest.external_service_id = es.id
+    WHERE latest.state = 'errored'
+    AND es.deleted_at IS NULL
+    AND NOT es.cloud_default
+    AND NOT es.paused
+    RETURNING id
+)
+SELECT COUNT(*) FROM enqueued
+`
+
 // EnqueueSyncJobs enqueues sync jobs for all external services that are due.
-func (s *Store) EnqueueSyncJobs(ctx context.Context, isCloud bool) (err error) {
+// A positive batchSize enqueues them in chunks of at most that many, so a huge
+// backlog of due services doesn't land on the queue in one burst; zero or less
+// enqueues everything at once.
+func (s *Store) EnqueueSyncJobs(ctx context.Context, isCloud bool, batchSize int) error {
+	if batchSize <= 0 {
+		_, err := s.EnqueueSyncJobsBatch(ctx, isCloud, 0)
+		return err
+	}
+
+	for {
+		enqueued, err := s.EnqueueSyncJobsBatch(ctx, isCloud, batchSize)
+		if err != nil || enqueued < batchSize {
+			return err
+		}
+	}
+}
+
+// EnqueueSyncJobsBatch enqueues sync jobs for at most limit due external
+// services and returns how many were enqueued. A limit of zero or less
+// enqueues all due services at once.
+func (s *Store) EnqueueSyncJobsBatch(ctx context.Context, isCloud bool, limit int) (enqueued int, err error) {
 	tr, ctx := s.trace(ctx, "Store.EnqueueSyncJobs")
 
 	defer func(began time.Time) {
@@ -609,8 +668,15 @@ func (s *Store) EnqueueSyncJobs(ctx context.Context, isCloud bool) (err error) {
 	if isCloud {
 		filter = "cloud_default = false"
 	}
-	q := sqlf.Sprintf(enqueueSyncJobsQueryFmtstr, sqlf.Sprintf(filter))
-	return s.Exec(ctx, q)
+	limitClause := sqlf.Sprintf("")
+	if limit > 0 {
+		limitClause = sqlf.Sprintf("LIMIT %s", limit)
+	}
+	q := sqlf.Sprintf(enqueueSyncJobsQueryFmtstr, sqlf.Sprintf(filter), limitClause)
+	if err := s.QueryRow(ctx, q).Scan(&enqueued); err != nil {
+		return 0, err
+	}
+	return enqueued, nil
 }
 
 // We ignore Phabricator repos here as they are currently synced using
@@ -621,6 +687,7 @@ WITH due AS (
     FROM external_services
     WHERE (next_sync_at <= clock_timestamp() OR next_sync_at IS NULL)
     AND deleted_at IS NULL
+    AND NOT paused
     AND LOWER(kind) != 'phabricator'
     AND %s
 ),
@@ -628,11 +695,84 @@ busy AS (
     SELECT DISTINCT external_service_id id FROM external_service_sync_jobs
     WHERE state = 'queued'
     OR state = 'processing'
+),
+candidates AS (
+    SELECT id from due EXCEPT SELECT id from busy
+    %s
+),
+enqueued AS (
+    INSERT INTO external_service_sync_jobs (external_service_id)
+    SELECT id FROM candidates
+    RETURNING id
 )
-INSERT INTO external_service_sync_jobs (external_service_id)
-SELECT id from due EXCEPT SELECT id from busy
+SELECT COUNT(*) FROM enqueued
 `
 
+// SetSyncJobCursor persists the pagination cursor reached by the given sync
+// job. The cursor lives on the job row, so it survives worker crashes and lets
+// a restarted job resume near where it left off.
+func (s *Store) SetSyncJobCursor(ctx context.Context, jobID int64, cursor string) (err error) {
+	tr, ctx := s.trace(ctx, "Store.SetSyncJobCursor")
+
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	return s.Exec(ctx, sqlf.Sprintf(`
+UPDATE external_service_sync_jobs SET cursor = %s WHERE id = %s
+`, cursor, jobID))
+}
+
+// GetSyncJobCursor returns the pagination cursor persisted for the given sync
+// job, or the empty string if none was recorded.
+func (s *Store) GetSyncJobCursor(ctx context.Context, jobID int64) (cursor string, err error) {
+	tr, ctx := s.trace(ctx, "Store.GetSyncJobCursor")
+
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	cursor, _, err = basestore.ScanFirstString(s.Query(ctx, sqlf.Sprintf(`
+SELECT cursor FROM external_service_sync_jobs WHERE id = %s
+`, jobID)))
+	return cursor, err
+}
+
+// ListReposPendingDeletion returns all repos that have been soft deleted but
+// may still have a clone on gitserver awaiting the purge worker. DeletedAt on
+// each returned repo is the time the repo was scheduled for deletion.
+func (s *Store) ListReposPendingDeletion(ctx context.Context) (pending []*types.Repo, err error) {
+	tr, ctx := s.trace(ctx, "Store.ListReposPendingDeletion")
+
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	q := sqlf.Sprintf(`
+		SELECT id, name, deleted_at
+		FROM repo
+		WHERE deleted_at IS NOT NULL
+		ORDER BY deleted_at ASC
+	`)
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r types.Repo
+		if err := rows.Scan(&r.ID, &r.Name, &r.DeletedAt); err != nil {
+			return nil, err
+		}
+		pending = append(pending, &r)
+	}
+	return pending, rows.Err()
+}
+
 // ListSyncJobs returns all sync jobs.
 func (s *Store) ListSyncJobs(ctx context.Context) ([]SyncJob, error) {
 	q := sqlf.Sprintf(`
@@ -155,7 +155,7 @@ func testStoreEnqueueSyncJobs(store *repos.Store) func(*testing.T) {
 					t.Fatalf("failed to setup store: %v", err)
 				}
 
-				err := store.EnqueueSyncJobs(ctx, tc.ignoreSiteAdmin)
+				err := store.EnqueueSyncJobs(ctx, tc.ignoreSiteAdmin, 0)
 				if have, want := fmt.Sprint(err), fmt.Sprint(tc.err); have != want {
 					t.Errorf("error:\nhave: %v\nwant: %v", have, want)
 				}
@@ -186,6 +186,63 @@ func testStoreEnqueueSyncJobs(store *repos.Store) func(*testing.T) {
 	}
 }
 
+func testStoreEnqueueSyncJobsBatched(store *repos.Store) func(*testing.T) {
+	return func(t *testing.T) {
+		ctx := context.Background()
+		clock := timeutil.NewFakeClock(time.Now(), 0)
+		now := clock.Now()
+
+		t.Cleanup(func() {
+			if err := store.Exec(ctx, sqlf.Sprintf("DELETE FROM external_service_sync_jobs;DELETE FROM external_services")); err != nil {
+				t.Fatal(err)
+			}
+		})
+
+		// Ten due services with a batch size of three enqueue in chunks of
+		// 3, 3, 3, 1, after which nothing is left.
+		stored := generateExternalServices(10, mkExternalServices(now)...).With(func(s *types.ExternalService) {
+			s.NextSyncAt = now.Add(-10 * time.Second)
+		})
+		if err := store.ExternalServiceStore.Upsert(ctx, stored...); err != nil {
+			t.Fatalf("failed to setup store: %v", err)
+		}
+
+		var chunks []int
+		for {
+			enqueued, err := store.EnqueueSyncJobsBatch(ctx, false, 3)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if enqueued == 0 {
+				break
+			}
+			chunks = append(chunks, enqueued)
+		}
+
+		if diff := cmp.Diff([]int{3, 3, 3, 1}, chunks); diff != "" {
+			t.Fatalf("unexpected chunks (-want +got):\n%s", diff)
+		}
+
+		jobs, err := store.ListSyncJobs(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		gotIDs := make([]int64, 0, len(jobs))
+		for _, job := range jobs {
+			gotIDs = append(gotIDs, job.ExternalServiceID)
+		}
+		sort.Slice(gotIDs, func(i, j int) bool { return gotIDs[i] < gotIDs[j] })
+
+		want := stored.IDs()
+		sort.Slice(want, func(i, j int) bool { return want[i] < want[j] })
+
+		if diff := cmp.Diff(want, gotIDs); diff != "" {
+			t.Fatal(diff)
+		}
+	}
+}
+
 func testStoreEnqueueSingleSyncJob(store *repos.Store) func(*testing.T) {
 	return func(t *testing.T) {
 		clock := timeutil.NewFakeClock(time.Now(), 0)
@@ -289,6 +346,156 @@ func testStoreEnqueueSingleSyncJob(store *repos.Store) func(*testing.T) {
 	}
 }
 
+func testStoreEnqueueSyncJobsPaused(store *repos.Store) func(*testing.T) {
+	return func(t *testing.T) {
+		clock := timeutil.NewFakeClock(time.Now(), 0)
+		now := clock.Now()
+
+		ctx := context.Background()
+		t.Cleanup(func() {
+			if err := store.Exec(ctx, sqlf.Sprintf("DELETE FROM external_service_sync_jobs;DELETE FROM external_services")); err != nil {
+				t.Fatal(err)
+			}
+		})
+		service := types.ExternalService{
+			Kind:        extsvc.KindGitHub,
+			DisplayName: "Github - Test",
+			Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc"}`,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+
+		confGet := func() *conf.Unified {
+			return &conf.Unified{}
+		}
+		externalServices := database.ExternalServicesWith(store)
+		if err := externalServices.Create(ctx, confGet, &service); err != nil {
+			t.Fatal(err)
+		}
+
+		assertCount := func(t *testing.T, want int) {
+			t.Helper()
+			var count int
+			if err := store.QueryRow(ctx, sqlf.Sprintf("SELECT COUNT(*) FROM external_service_sync_jobs")).Scan(&count); err != nil {
+				t.Fatal(err)
+			}
+			if count != want {
+				t.Fatalf("Expected %d rows, got %d", want, count)
+			}
+		}
+
+		// A paused service is skipped by both enqueueing paths.
+		if err := externalServices.Pause(ctx, service.ID); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := store.EnqueueSyncJobs(ctx, false, 0); err != nil {
+			t.Fatal(err)
+		}
+		assertCount(t, 0)
+
+		if err := store.EnqueueSingleSyncJob(ctx, service.ID); err != nil {
+			t.Fatal(err)
+		}
+		assertCount(t, 0)
+
+		// After resuming, enqueueing works again.
+		if err := externalServices.Resume(ctx, service.ID); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := store.EnqueueSyncJobs(ctx, false, 0); err != nil {
+			t.Fatal(err)
+		}
+		assertCount(t, 1)
+	}
+}
+
+func testStoreEnqueueFailedSyncJobs(store *repos.Store) func(*testing.T) {
+	return func(t *testing.T) {
+		clock := timeutil.NewFakeClock(time.Now(), 0)
+		now := clock.Now()
+
+		ctx := context.Background()
+		t.Cleanup(func() {
+			if err := store.Exec(ctx, sqlf.Sprintf("DELETE FROM external_service_sync_jobs;DELETE FROM external_services")); err != nil {
+				t.Fatal(err)
+			}
+		})
+
+		newService := func(name string) *types.ExternalService {
+			return &types.ExternalService{
+				Kind:        extsvc.KindGitHub,
+				DisplayName: name,
+				Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc"}`,
+				CreatedAt:   now,
+				UpdatedAt:   now,
+			}
+		}
+
+		confGet := func() *conf.Unified {
+			return &conf.Unified{}
+		}
+		succeeded := newService("Github - Succeeded")
+		failed := newService("Github - Failed")
+		recovered := newService("Github - Recovered")
+		for _, svc := range []*types.ExternalService{succeeded, failed, recovered} {
+			if err := database.ExternalServicesWith(store).Create(ctx, confGet, svc); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		addJob := func(t *testing.T, svcID int64, state string) {
+			t.Helper()
+			q := sqlf.Sprintf("INSERT INTO external_service_sync_jobs (external_service_id, state) VALUES (%s, %s)", svcID, state)
+			if err := store.Exec(ctx, q); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		// One service succeeded, one failed, and one failed but has since
+		// completed a newer sync. Only the failure should be re-enqueued.
+		addJob(t, succeeded.ID, "completed")
+		addJob(t, failed.ID, "errored")
+		addJob(t, recovered.ID, "errored")
+		addJob(t, recovered.ID, "completed")
+
+		enqueued, err := store.EnqueueFailedSyncJobs(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if enqueued != 1 {
+			t.Fatalf("Expected 1 enqueued job, got %d", enqueued)
+		}
+
+		var count int
+		q := sqlf.Sprintf("SELECT COUNT(*) FROM external_service_sync_jobs WHERE external_service_id = %s AND state = 'queued'", failed.ID)
+		if err := store.QueryRow(ctx, q).Scan(&count); err != nil {
+			t.Fatal(err)
+		}
+		if count != 1 {
+			t.Fatalf("Expected 1 queued job for the failed service, got %d", count)
+		}
+
+		// A cloud default service with a failed sync is skipped.
+		if err := store.Exec(ctx, sqlf.Sprintf("UPDATE external_service_sync_jobs SET state='errored'")); err != nil {
+			t.Fatal(err)
+		}
+		failed.CloudDefault = true
+		if err := store.ExternalServiceStore.Upsert(ctx, failed); err != nil {
+			t.Fatal(err)
+		}
+
+		enqueued, err = store.EnqueueFailedSyncJobs(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if enqueued != 2 {
+			t.Fatalf("Expected 2 enqueued jobs, got %d", enqueued)
+		}
+	}
+}
+
 func testStoreListExternalServiceUserIDsByRepoID(store *repos.Store) func(*testing.T) {
 	return func(t *testing.T) {
 		ctx := context.Background()
@@ -427,6 +634,42 @@ VALUES
 	}
 }
 
+func testStoreListReposPendingDeletion(store *repos.Store) func(*testing.T) {
+	return func(t *testing.T) {
+		ctx := context.Background()
+		t.Cleanup(func() {
+			if err := store.Exec(ctx, sqlf.Sprintf("DELETE FROM repo")); err != nil {
+				t.Fatal(err)
+			}
+		})
+
+		deletedAt := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+		q := sqlf.Sprintf(`
+			INSERT INTO repo (id, name, deleted_at)
+			VALUES (1, 'foo', NULL),
+			       (2, 'DELETED-1-bar', %s),
+			       (3, 'DELETED-2-baz', %s)
+		`, deletedAt.Add(time.Hour), deletedAt)
+		if err := store.Exec(ctx, q); err != nil {
+			t.Fatal(err)
+		}
+
+		pending, err := store.ListReposPendingDeletion(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Only the soft-deleted repos are listed, oldest deletion first.
+		want := []*types.Repo{
+			{ID: 3, Name: "DELETED-2-baz", DeletedAt: deletedAt},
+			{ID: 2, Name: "DELETED-1-bar", DeletedAt: deletedAt.Add(time.Hour)},
+		}
+		if diff := cmp.Diff(want, pending); diff != "" {
+			t.Fatalf("Mismatch (-want +got):\n%s", diff)
+		}
+	}
+}
+
 func mkRepos(n int, base ...*types.Repo) types.Repos {
 	if len(base) == 0 {
 		return nil
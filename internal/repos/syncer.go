@@ -6,6 +6,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/inconshreveable/log15"
@@ -50,8 +51,101 @@ type Syncer struct {
 	// If zero, we'll read from config instead.
 	UserReposMaxPerSite int
 
+	// UserReposMaxPerUserOverrides raises (or lowers) the per-namespace repo
+	// limit for specific user namespaces, keyed by user ID. Users without an
+	// entry fall back to UserReposMaxPerUser.
+	UserReposMaxPerUserOverrides map[int32]int
+
+	// OrgReposMaxPerOrgOverrides raises (or lowers) the per-namespace repo
+	// limit for specific organization namespaces, keyed by org ID. Orgs
+	// without an entry fall back to UserReposMaxPerUser.
+	OrgReposMaxPerOrgOverrides map[int32]int
+
+	// SyncRepoBackgroundRetries is the number of attempts the background path
+	// of SyncRepo makes before giving up on a transient error. If zero, a
+	// default of 3 is used.
+	SyncRepoBackgroundRetries int
+
+	// SyncRepoBackgroundBackoff is the initial wait between background retry
+	// attempts, doubling after each failure. If zero, a default of 1 second is
+	// used.
+	SyncRepoBackgroundBackoff time.Duration
+
+	// SourceTimeouts bounds the sourcing phase of a sync per external service
+	// kind (e.g. extsvc.KindGitHub). A sync whose source doesn't finish
+	// listing within the budget is abandoned with a SourceTimeoutError. Kinds
+	// without an entry are not bounded.
+	SourceTimeouts map[string]time.Duration
+
+	// AdditionalPackageHosts lists external service types treated as package
+	// hosts alongside extsvc.CodeHost.IsPackageHost, so lazy-syncing can be
+	// enabled for a newly-supported ecosystem without a release.
+	AdditionalPackageHosts []string
+
+	// NonFatalErrors overrides the fatality classification of sourcing errors
+	// per external service kind (e.g. extsvc.KindGitHub). An error the
+	// predicate reports as non-fatal is treated like a transient failure even
+	// if it would normally be fatal (unauthorized, forbidden, account
+	// suspended), so it never triggers deletion of unseen repos. Useful for
+	// code hosts that answer 403 to rate-limited clients. Kinds without an
+	// entry keep the default classification.
+	NonFatalErrors map[string]func(error) bool
+
+	// PreserveOnError, if set, skips deleting unseen repos whenever a sync
+	// finished with errors, regardless of error fatality or service
+	// ownership. Unseen repos are then only removed by a clean full sync, at
+	// the cost of repos the code host no longer yields lingering until one
+	// succeeds.
+	PreserveOnError bool
+
 	// Ensure that we only run one sync per repo at a time
 	syncGroup singleflight.Group
+
+	// inFlightMu guards inFlightSyncs.
+	inFlightMu sync.Mutex
+	// inFlightSyncs tracks the external service syncs that are currently
+	// running, keyed by external service ID.
+	inFlightSyncs map[int64]time.Time
+}
+
+// InFlightSync describes an external service sync that is currently being
+// processed, for the /in-flight-syncs debug endpoint.
+type InFlightSync struct {
+	ExternalServiceID int64     `json:"external_service_id"`
+	StartedAt         time.Time `json:"started_at"`
+}
+
+// startInFlightSync records that a sync of the given external service has
+// begun.
+func (s *Syncer) startInFlightSync(id int64) {
+	s.inFlightMu.Lock()
+	defer s.inFlightMu.Unlock()
+	if s.inFlightSyncs == nil {
+		s.inFlightSyncs = make(map[int64]time.Time)
+	}
+	s.inFlightSyncs[id] = s.Now()
+}
+
+// finishInFlightSync removes the given external service from the in-flight
+// registry. It is deferred so entries are dropped even when a sync panics.
+func (s *Syncer) finishInFlightSync(id int64) {
+	s.inFlightMu.Lock()
+	defer s.inFlightMu.Unlock()
+	delete(s.inFlightSyncs, id)
+}
+
+// InFlightSyncs returns the external service syncs currently being processed,
+// ordered by external service ID.
+func (s *Syncer) InFlightSyncs() []InFlightSync {
+	s.inFlightMu.Lock()
+	defer s.inFlightMu.Unlock()
+
+	syncs := make([]InFlightSync, 0, len(s.inFlightSyncs))
+	for id, startedAt := range s.inFlightSyncs {
+		syncs = append(syncs, InFlightSync{ExternalServiceID: id, StartedAt: startedAt})
+	}
+	sort.Slice(syncs, func(i, j int) bool { return syncs[i].ExternalServiceID < syncs[j].ExternalServiceID })
+	return syncs
 }
 
 // RunOptions contains options customizing Run behaviour.
@@ -59,7 +153,19 @@ type RunOptions struct {
 	EnqueueInterval func() time.Duration // Defaults to 1 minute
 	IsCloud         bool                 // Defaults to false
 	MinSyncInterval func() time.Duration // Defaults to 1 minute
+	MaxSyncInterval func() time.Duration // Defaults to 8 hours
 	DequeueInterval time.Duration        // Default to 10 seconds
+
+	// EnqueueBatchSize is the maximum number of sync jobs enqueued per
+	// statement, so a huge backlog of due services is spread over several
+	// smaller inserts. Defaults to 500; a negative value disables batching.
+	EnqueueBatchSize int
+
+	// SkipInitialUnmodifiedDiff skips sending every stored repo as Unmodified
+	// on the Synced channel at startup. On very large instances that initial
+	// diff is a big allocation, and consumers that only react to changes
+	// don't need it.
+	SkipInitialUnmodifiedDiff bool
 }
 
 // Run runs the Sync at the specified interval.
@@ -70,11 +176,17 @@ func (s *Syncer) Run(ctx context.Context, store *Store, opts RunOptions) error {
 	if opts.MinSyncInterval == nil {
 		opts.MinSyncInterval = func() time.Duration { return time.Minute }
 	}
+	if opts.MaxSyncInterval == nil {
+		opts.MaxSyncInterval = func() time.Duration { return 8 * time.Hour }
+	}
 	if opts.DequeueInterval == 0 {
 		opts.DequeueInterval = 10 * time.Second
 	}
+	if opts.EnqueueBatchSize == 0 {
+		opts.EnqueueBatchSize = 500
+	}
 
-	if !opts.IsCloud {
+	if !opts.IsCloud && !opts.SkipInitialUnmodifiedDiff {
 		s.initialUnmodifiedDiffFromStore(ctx, store)
 	}
 
@@ -82,6 +194,7 @@ func (s *Syncer) Run(ctx context.Context, store *Store, opts RunOptions) error {
 		syncer:          s,
 		store:           store,
 		minSyncInterval: opts.MinSyncInterval,
+		maxSyncInterval: opts.MaxSyncInterval,
 	}, SyncWorkerOptions{
 		WorkerInterval:       opts.DequeueInterval,
 		NumHandlers:          ConfRepoConcurrentExternalServiceSyncers(),
@@ -97,7 +210,9 @@ func (s *Syncer) Run(ctx context.Context, store *Store, opts RunOptions) error {
 
 	for ctx.Err() == nil {
 		if !conf.Get().DisableAutoCodeHostSyncs {
-			err := store.EnqueueSyncJobs(ctx, opts.IsCloud)
+			err := enqueueWithRetry(ctx, func(ctx context.Context) error {
+				return store.EnqueueSyncJobs(ctx, opts.IsCloud, opts.EnqueueBatchSize)
+			}, enqueueSyncJobsRetries, enqueueSyncJobsRetryInterval)
 			if err != nil && s.Logger != nil {
 				s.Logger.Error("Enqueuing sync jobs", "error", err)
 			}
@@ -108,10 +223,34 @@ func (s *Syncer) Run(ctx context.Context, store *Store, opts RunOptions) error {
 	return ctx.Err()
 }
 
+// enqueueSyncJobsRetries and enqueueSyncJobsRetryInterval bound the retries
+// performed when enqueueing sync jobs fails.
+const (
+	enqueueSyncJobsRetries       = 3
+	enqueueSyncJobsRetryInterval = 5 * time.Second
+)
+
+// enqueueWithRetry calls enqueue, retrying up to retries times with interval
+// between attempts, so that a transient error doesn't delay all syncing by a
+// full EnqueueInterval. Each failed attempt increments enqueueFailures.
+func enqueueWithRetry(ctx context.Context, enqueue func(context.Context) error, retries int, interval time.Duration) (err error) {
+	for attempt := 0; ; attempt++ {
+		if err = enqueue(ctx); err == nil {
+			return nil
+		}
+		enqueueFailures.Inc()
+		if attempt >= retries || ctx.Err() != nil {
+			return err
+		}
+		sleep(ctx, interval)
+	}
+}
+
 type syncHandler struct {
 	syncer          *Syncer
 	store           *Store
 	minSyncInterval func() time.Duration
+	maxSyncInterval func() time.Duration
 }
 
 func (s *syncHandler) Handle(ctx context.Context, record workerutil.Record) (err error) {
@@ -120,7 +259,7 @@ func (s *syncHandler) Handle(ctx context.Context, record workerutil.Record) (err
 		return errors.Errorf("expected repos.SyncJob, got %T", record)
 	}
 
-	return s.syncer.SyncExternalService(ctx, sj.ExternalServiceID, s.minSyncInterval())
+	return s.syncer.syncExternalService(ctx, int64(sj.ID), sj.ExternalServiceID, s.minSyncInterval(), s.maxSyncInterval())
 }
 
 // sleep is a context aware time.Sleep
@@ -247,6 +386,31 @@ func (d Diff) Len() int {
 	return len(d.Deleted) + len(d.Modified) + len(d.Added) + len(d.Unmodified)
 }
 
+// Merge adds the repos of other to d, so the diffs of many single-repo syncs
+// can be accumulated into one full-run diff for logging and metrics. A repo
+// already present in any bucket of d keeps its existing classification and is
+// not added again, so no repo appears in more than one bucket after a merge.
+func (d *Diff) Merge(other Diff) {
+	seen := make(map[api.RepoID]struct{}, d.Len())
+	for _, r := range d.Repos() {
+		seen[r.ID] = struct{}{}
+	}
+
+	merge := func(dst *types.Repos, src types.Repos) {
+		for _, r := range src {
+			if _, ok := seen[r.ID]; ok {
+				continue
+			}
+			seen[r.ID] = struct{}{}
+			*dst = append(*dst, r)
+		}
+	}
+	merge(&d.Added, other.Added)
+	merge(&d.Deleted, other.Deleted)
+	merge(&d.Modified, other.Modified)
+	merge(&d.Unmodified, other.Unmodified)
+}
+
 // SyncRepo syncs a single repository by name and associates it with an external service.
 //
 // It works for repos from:
@@ -294,7 +458,7 @@ func (s *Syncer) SyncRepo(ctx context.Context, name api.RepoName, background boo
 			// We don't care about the return value here, but we still want to ensure that
 			// only one is in flight at a time.
 			_, _, _ = s.syncGroup.Do(string(name), func() (interface{}, error) {
-				updatedRepo, err := s.syncRepo(ctx, codehost, name, repo)
+				updatedRepo, err := s.syncRepoWithRetries(ctx, codehost, name, repo)
 				if err != nil {
 					log15.Error("Error syncing repo in the background", "name", name, "error", err)
 				}
@@ -313,6 +477,60 @@ func (s *Syncer) SyncRepo(ctx context.Context, name api.RepoName, background boo
 	return updatedRepo.(*types.Repo), nil
 }
 
+// syncRepoWithRetries calls syncRepo, retrying transient failures with
+// exponential backoff. Fatal errors (not-found, unauthorized, forbidden,
+// account suspended) are returned immediately since retrying them cannot
+// succeed.
+func (s *Syncer) syncRepoWithRetries(ctx context.Context, codehost *extsvc.CodeHost, name api.RepoName, stored *types.Repo) (repo *types.Repo, err error) {
+	attempts := s.SyncRepoBackgroundRetries
+	if attempts == 0 {
+		attempts = 3
+	}
+	backoff := s.SyncRepoBackgroundBackoff
+	if backoff == 0 {
+		backoff = time.Second
+	}
+
+	fatal := func(err error) bool {
+		return errcode.IsNotFound(err) ||
+			errcode.IsUnauthorized(err) ||
+			errcode.IsForbidden(err) ||
+			errcode.IsAccountSuspended(err)
+	}
+
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		repo, err = s.syncRepo(ctx, codehost, name, stored)
+		if err == nil || fatal(err) {
+			break
+		}
+	}
+
+	return repo, err
+}
+
+// isPackageHost reports whether the codehost serves package repos, either by
+// the built-in predicate or by being listed in AdditionalPackageHosts.
+func (s *Syncer) isPackageHost(codehost *extsvc.CodeHost) bool {
+	if codehost.IsPackageHost() {
+		return true
+	}
+	for _, serviceType := range s.AdditionalPackageHosts {
+		if strings.EqualFold(serviceType, codehost.ServiceType) {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *Syncer) syncRepo(
 	ctx context.Context,
 	codehost *extsvc.CodeHost,
@@ -330,7 +548,7 @@ func (s *Syncer) syncRepo(
 		// out in the next full background sync as long as we add them to that table.
 		//
 		// This permits lazy-syncing of package repos in on-prem instances as well as in cloud.
-		OnlyCloudDefault: !codehost.IsPackageHost(),
+		OnlyCloudDefault: !s.isPackageHost(codehost),
 		LimitOffset:      &database.LimitOffset{Limit: 1},
 	})
 	if err != nil {
@@ -396,6 +614,94 @@ func (s *Syncer) syncRepo(
 	return repo, nil
 }
 
+// SyncRepos syncs multiple repositories by name, all hosted on the same code
+// host, and associates them with an external service. It has the same
+// restrictions as SyncRepo but fetches metadata for all repos with a single
+// call when the source implements RepoBatchGetter, falling back to one GetRepo
+// call per repo otherwise. Private repos are skipped rather than synced.
+func (s *Syncer) SyncRepos(ctx context.Context, names []api.RepoName) (synced []*types.Repo, err error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	codehost := extsvc.CodeHostOf(names[0], extsvc.PublicCodeHosts...)
+	if codehost == nil {
+		return nil, errors.Wrapf(
+			&database.RepoNotFoundErr{Name: names[0]},
+			"no public code host for %q", names[0],
+		)
+	}
+	for _, name := range names[1:] {
+		if extsvc.CodeHostOf(name, extsvc.PublicCodeHosts...) != codehost {
+			return nil, errors.Newf("SyncRepos: %q is not hosted on %q", name, codehost.ServiceID)
+		}
+	}
+
+	var svc *types.ExternalService
+	ctx, save := s.observeSync(ctx, "Syncer.SyncRepos", "")
+	defer func() { save(svc, err) }()
+
+	svcs, err := s.Store.ExternalServiceStore.List(ctx, database.ExternalServicesListOptions{
+		Kinds:            []string{extsvc.TypeToKind(codehost.ServiceType)},
+		OnlyCloudDefault: !s.isPackageHost(codehost),
+		LimitOffset:      &database.LimitOffset{Limit: 1},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing external services")
+	}
+	if len(svcs) != 1 {
+		return nil, errors.Wrapf(
+			&database.RepoNotFoundErr{Name: names[0]},
+			"cloud default external service of type %q not found", codehost.ServiceType,
+		)
+	}
+	svc = svcs[0]
+
+	src, err := s.Sourcer(svc)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := strings.TrimPrefix(codehost.ServiceID, "https://")
+	paths := make([]string, 0, len(names))
+	for _, name := range names {
+		paths = append(paths, strings.TrimPrefix(string(name), prefix))
+	}
+
+	var fetched []*types.Repo
+	if bg, ok := src.(RepoBatchGetter); ok {
+		fetched, err = bg.GetRepos(ctx, paths)
+		if err != nil {
+			return nil, err
+		}
+	} else if rg, ok := src.(RepoGetter); ok {
+		for _, path := range paths {
+			repo, err := rg.GetRepo(ctx, path)
+			if err != nil {
+				return nil, err
+			}
+			fetched = append(fetched, repo)
+		}
+	} else {
+		return nil, errors.Wrapf(
+			&database.RepoNotFoundErr{Name: names[0]},
+			"can't get repo metadata for service of type %q", codehost.ServiceType,
+		)
+	}
+
+	for _, repo := range fetched {
+		if repo.Private {
+			continue
+		}
+		if _, err := s.sync(ctx, svc, repo); err != nil {
+			return nil, err
+		}
+		synced = append(synced, repo)
+	}
+
+	return synced, nil
+}
+
 // RepoLimitError is produced by Syncer.ExternalServiceSync when a user's sync job
 // exceeds the user added repo limits.
 type RepoLimitError struct {
@@ -442,6 +748,21 @@ func (e *RepoLimitError) Error() string {
 	}
 }
 
+// A SourceTimeoutError is recorded on a sync whose source didn't finish
+// listing repos within the per-kind budget configured in
+// Syncer.SourceTimeouts.
+type SourceTimeoutError struct {
+	// Kind of the external service whose sourcing timed out.
+	Kind string
+
+	// Timeout is the budget that was exceeded.
+	Timeout time.Duration
+}
+
+func (e *SourceTimeoutError) Error() string {
+	return fmt.Sprintf("sourcing repos for kind %s did not finish within %s", e.Kind, e.Timeout)
+}
+
 func (s *Syncer) notifyDeleted(ctx context.Context, deleted ...api.RepoID) {
 	var d Diff
 	for _, id := range deleted {
@@ -464,6 +785,11 @@ func (s *Syncer) notifyDeleted(ctx context.Context, deleted ...api.RepoID) {
 // the lazy-added repos.
 var ErrCloudDefaultSync = errors.New("cloud default external services can't be synced")
 
+// ErrSyncPaused is returned when SyncExternalService is invoked for an
+// external service whose syncing has been paused by an admin. The enqueueing
+// paths skip paused services, so this only guards direct invocations.
+var ErrSyncPaused = errors.New("syncing of this external service is paused")
+
 // SyncExternalService syncs repos using the supplied external service in a streaming fashion, rather than batch.
 // This allows very large sync jobs (i.e. that source potentially millions of repos) to incrementally persist changes.
 // Deletes of repositories that were not sourced are done at the end.
@@ -471,9 +797,35 @@ func (s *Syncer) SyncExternalService(
 	ctx context.Context,
 	externalServiceID int64,
 	minSyncInterval time.Duration,
+	maxSyncInterval time.Duration,
+) error {
+	return s.syncExternalService(ctx, 0, externalServiceID, minSyncInterval, maxSyncInterval)
+}
+
+// syncExternalService implements SyncExternalService. A non-zero jobID
+// associates progress log lines with the sync job being processed.
+func (s *Syncer) syncExternalService(
+	ctx context.Context,
+	jobID int64,
+	externalServiceID int64,
+	minSyncInterval time.Duration,
+	maxSyncInterval time.Duration,
 ) (err error) {
 	s.log().Info("Syncing external service", "serviceID", externalServiceID)
 
+	s.startInFlightSync(externalServiceID)
+	defer s.finishInFlightSync(externalServiceID)
+
+	logJob := func(line string) {
+		if jobID == 0 {
+			return
+		}
+		if err := s.Store.ExternalServiceStore.AppendSyncJobLog(ctx, jobID, line); err != nil {
+			s.log().Warn("syncer: failed to append sync job log", "jobID", jobID, "error", err)
+		}
+	}
+	logJob("sync started")
+
 	var svc *types.ExternalService
 	ctx, save := s.observeSync(ctx, "Syncer.SyncExternalService", "")
 	defer func() { save(svc, err) }()
@@ -494,6 +846,10 @@ func (s *Syncer) SyncExternalService(
 		return ErrCloudDefaultSync
 	}
 
+	if svc.Paused {
+		return ErrSyncPaused
+	}
+
 	// Unless our site config explicitly allows private code or the user has the
 	// "AllowUserExternalServicePrivate" tag, user added external services should
 	// only sync public code.
@@ -512,12 +868,41 @@ func (s *Syncer) SyncExternalService(
 		return err
 	}
 
+	// If the source supports pagination cursors and we're processing a sync
+	// job, resume from any cursor persisted by a previous, crashed attempt at
+	// this job. Errored jobs get fresh rows and so always start from scratch.
+	cursorSource, _ := src.(CursorSource)
+	resumedFromCursor := false
+	var lastCursor string
+	if cursorSource != nil && jobID != 0 {
+		cursor, err := s.Store.GetSyncJobCursor(ctx, jobID)
+		if err != nil {
+			return errors.Wrap(err, "fetching sync job cursor")
+		}
+		if cursor != "" {
+			cursorSource.SetCursor(cursor)
+			resumedFromCursor = true
+			lastCursor = cursor
+			logJob(fmt.Sprintf("resuming from cursor %q", cursor))
+		}
+	}
+
 	results := make(chan SourceResult)
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
+	// Bound only the sourcing phase by the per-kind timeout, if one is
+	// configured, so a code host that hangs doesn't occupy a handler
+	// indefinitely while syncs that make steady progress are unaffected.
+	sourceCtx, cancelSource := context.WithCancel(ctx)
+	sourceTimeout := s.SourceTimeouts[svc.Kind]
+	if sourceTimeout > 0 {
+		sourceCtx, cancelSource = context.WithTimeout(ctx, sourceTimeout)
+	}
+	defer cancelSource()
+
 	go func() {
-		src.ListRepos(ctx, results)
+		src.ListRepos(sourceCtx, results)
 		close(results)
 	}()
 
@@ -525,13 +910,20 @@ func (s *Syncer) SyncExternalService(
 	seen := make(map[api.RepoID]struct{})
 	var errs error
 	fatal := func(err error) bool {
+		if nonFatal := s.NonFatalErrors[svc.Kind]; nonFatal != nil && nonFatal(err) {
+			return false
+		}
 		return errcode.IsUnauthorized(err) ||
 			errcode.IsForbidden(err) ||
 			errcode.IsAccountSuspended(err)
 	}
 
 	// Insert or update repos as they are sourced. Keep track of what was seen
-	// so we can remove anything else at the end.
+	// so we can remove anything else at the end. Accumulate the merged diff
+	// across the run so it can be reported at completion.
+	sourcingStart := time.Now()
+	sourcedCount := 0
+	var runDiff Diff
 	for res := range results {
 		if err := res.Err; err != nil {
 			s.log().Error("syncer: error from codehost",
@@ -540,6 +932,8 @@ func (s *Syncer) SyncExternalService(
 			errs = errors.Append(errs, errors.Wrapf(err, "fetching from code host %s", svc.DisplayName))
 
 			if fatal(err) {
+				logJob(fmt.Sprintf("fatal error from code host: %s", err))
+
 				// Delete all external service repos of this external service
 				seen = map[api.RepoID]struct{}{}
 				break
@@ -549,6 +943,9 @@ func (s *Syncer) SyncExternalService(
 		}
 
 		sourced := res.Repo
+		sourcedCount++
+		recordSourcingRate(svc.Kind, sourcedCount, time.Since(sourcingStart))
+
 		if !allowed(sourced) {
 			continue
 		}
@@ -572,9 +969,38 @@ func (s *Syncer) SyncExternalService(
 			seen[r.ID] = struct{}{}
 		}
 
+		runDiff.Merge(diff)
+
 		modified = modified || len(diff.Modified)+len(diff.Added) > 0
+
+		// Persist the source's cursor as it advances so a crashed job can
+		// resume from here. Failures to persist are not fatal: the sync
+		// continues and at worst a restart re-lists a few extra pages.
+		if cursorSource != nil && jobID != 0 {
+			if cursor := cursorSource.Cursor(); cursor != lastCursor {
+				if err := s.Store.SetSyncJobCursor(ctx, jobID, cursor); err != nil {
+					s.log().Warn("syncer: failed to persist sync job cursor",
+						"svc", svc.DisplayName, "jobID", jobID, "error", err)
+				} else {
+					lastCursor = cursor
+				}
+			}
+		}
+	}
+
+	// A deadline on sourceCtx means the source was cut off before it finished
+	// listing. Record it distinctly so a hung code host is visible as such
+	// rather than as an ordinary sourcing error.
+	if sourceTimeout > 0 && errors.Is(sourceCtx.Err(), context.DeadlineExceeded) {
+		timeoutErr := &SourceTimeoutError{Kind: svc.Kind, Timeout: sourceTimeout}
+		errs = errors.Append(errs, timeoutErr)
+		logJob(timeoutErr.Error())
 	}
 
+	// Reset the rate gauge so it doesn't keep reporting the last value of a
+	// finished sync.
+	sourcingRate.WithLabelValues(svc.Kind).Set(0)
+
 	// We don't delete any repos of site-level external services if there were any
 	// errors during a sync.
 	//
@@ -583,8 +1009,14 @@ func (s *Syncer) SyncExternalService(
 	//
 	// Site-level external services can own lots of repos and are managed by site admins.
 	// It's preferable to have them fix any invalidated token manually rather than deleting the repos automatically.
+	//
+	// With PreserveOnError set we never delete on an errored sync, fatal or
+	// not, and rely on the next clean sync to remove unseen repos.
+	// A resumed sync only saw the pages after the persisted cursor, so seen is
+	// partial and deleting unseen repos would remove repos sourced by the
+	// earlier attempt. Skip deletion and let the next full sync reconcile.
 	deleted := 0
-	if errs == nil || (!svc.IsSiteOwned() && fatal(errs)) {
+	if !resumedFromCursor && (errs == nil || (!s.PreserveOnError && !svc.IsSiteOwned() && fatal(errs))) {
 		// Remove associations and any repos that are no longer associated with any
 		// external service.
 		//
@@ -604,14 +1036,23 @@ func (s *Syncer) SyncExternalService(
 		if deleted > 0 {
 			s.log().Warn("syncer: deleted not seen repos",
 				"svc", svc.DisplayName, "id", svc.ID, "seen", len(seen), "deleted", deleted, "error", err)
+			logJob(fmt.Sprintf("deleted %d repos that were not seen", deleted))
 		}
 	}
 
 	now := s.Now()
 	modified = modified || deleted > 0
-	interval := calcSyncInterval(now, svc.LastSyncAt, minSyncInterval, modified, errs)
-
-	s.log().Debug("Synced external service", "id", externalServiceID, "backoff duration", interval)
+	totalDeleted := len(runDiff.Deleted) + deleted
+	interval := calcSyncInterval(now, svc.LastSyncAt, minSyncInterval, maxSyncInterval, modified, errs)
+
+	s.log().Info("Synced external service",
+		"id", externalServiceID,
+		"added", len(runDiff.Added),
+		"modified", len(runDiff.Modified),
+		"deleted", totalDeleted,
+		"unmodified", len(runDiff.Unmodified),
+		"backoff duration", interval,
+	)
 	svc.NextSyncAt = now.Add(interval)
 	svc.LastSyncAt = now
 
@@ -620,6 +1061,14 @@ func (s *Syncer) SyncExternalService(
 		errs = errors.Append(errs, errors.Wrap(err, "upserting external service"))
 	}
 
+	counts := fmt.Sprintf("%d added, %d modified, %d deleted, %d unmodified",
+		len(runDiff.Added), len(runDiff.Modified), totalDeleted, len(runDiff.Unmodified))
+	if errs == nil {
+		logJob(fmt.Sprintf("sync finished, %d repos seen: %s", len(seen), counts))
+	} else {
+		logJob(fmt.Sprintf("sync finished with errors, %d repos seen (%s): %s", len(seen), counts, errs))
+	}
+
 	return errs
 }
 
@@ -637,9 +1086,63 @@ func (s *Syncer) userReposMaxPerUser() uint64 {
 	return uint64(s.UserReposMaxPerUser)
 }
 
-// syncs a sourced repo of a given external service, returning a diff with a single repo.
-func (s *Syncer) sync(ctx context.Context, svc *types.ExternalService, sourced *types.Repo) (d Diff, err error) {
+// userReposMaxForNamespace returns the effective per-namespace repo limit for
+// the given user or org namespace, taking any configured override into
+// account.
+func (s *Syncer) userReposMaxForNamespace(userID, orgID int32) uint64 {
+	if userID != 0 {
+		if n := s.UserReposMaxPerUserOverrides[userID]; n > 0 {
+			return uint64(n)
+		}
+	} else if orgID != 0 {
+		if n := s.OrgReposMaxPerOrgOverrides[orgID]; n > 0 {
+			return uint64(n)
+		}
+	}
+	return s.userReposMaxPerUser()
+}
+
+// syncerStore is the subset of Store that sync depends on, extracted as an
+// interface so the conflict and limit branches of sync can be unit tested
+// with a fake instead of a database.
+type syncerStore interface {
+	Transact(ctx context.Context) (syncerStore, error)
+	Done(err error) error
+	CreateExternalServiceRepo(ctx context.Context, svc *types.ExternalService, r *types.Repo) error
+	UpdateExternalServiceRepo(ctx context.Context, svc *types.ExternalService, r *types.Repo) error
+	DeleteExternalServiceReposNotIn(ctx context.Context, svc *types.ExternalService, ids map[api.RepoID]struct{}) ([]api.RepoID, error)
+	CountNamespacedRepos(ctx context.Context, userID, orgID int32) (uint64, error)
+	ExternalServices() database.ExternalServiceStore
+	Repos() database.RepoStore
+}
+
+// storeAdapter adapts *Store, whose Transact returns the concrete type and
+// whose sub-stores are fields, to the syncerStore interface.
+type storeAdapter struct{ *Store }
+
+func (s storeAdapter) Transact(ctx context.Context) (syncerStore, error) {
 	tx, err := s.Store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return storeAdapter{tx}, nil
+}
+
+func (s storeAdapter) ExternalServices() database.ExternalServiceStore {
+	return s.ExternalServiceStore
+}
+
+func (s storeAdapter) Repos() database.RepoStore {
+	return s.RepoStore
+}
+
+// syncs a sourced repo of a given external service, returning a diff with a single repo.
+func (s *Syncer) sync(ctx context.Context, svc *types.ExternalService, sourced *types.Repo) (Diff, error) {
+	return s.syncWith(ctx, storeAdapter{s.Store}, svc, sourced)
+}
+
+func (s *Syncer) syncWith(ctx context.Context, store syncerStore, svc *types.ExternalService, sourced *types.Repo) (d Diff, err error) {
+	tx, err := store.Transact(ctx)
 	if err != nil {
 		return Diff{}, errors.Wrap(err, "syncer: opening transaction")
 	}
@@ -661,7 +1164,7 @@ func (s *Syncer) sync(ctx context.Context, svc *types.ExternalService, sourced *
 		}
 	}()
 
-	stored, err := tx.RepoStore.List(ctx, database.ReposListOptions{
+	stored, err := tx.Repos().List(ctx, database.ReposListOptions{
 		Names:          []string{string(sourced.Name)},
 		ExternalRepos:  []api.ExternalRepoSpec{sourced.ExternalRepo},
 		IncludeBlocked: true,
@@ -686,7 +1189,7 @@ func (s *Syncer) sync(ctx context.Context, svc *types.ExternalService, sourced *
 		}
 
 		// invariant: conflicting can't be nil due to our database constraints
-		if err = tx.RepoStore.Delete(ctx, conflicting.ID); err != nil {
+		if err = tx.Repos().Delete(ctx, conflicting.ID); err != nil {
 			return Diff{}, errors.Wrap(err, "syncer: failed to delete conflicting repo")
 		}
 
@@ -719,8 +1222,9 @@ func (s *Syncer) sync(ctx context.Context, svc *types.ExternalService, sourced *
 				return Diff{}, errors.Wrap(err, "counting repos added by user or organization")
 			}
 
-			// TODO: For now we are using the same limit for users as for organizations
-			userLimit, siteLimit := s.userReposMaxPerUser(), s.userReposMaxPerSite()
+			// Organizations share the user limit unless an override is
+			// configured for their namespace.
+			userLimit, siteLimit := s.userReposMaxForNamespace(svc.NamespaceUserID, svc.NamespaceOrgID), s.userReposMaxPerSite()
 			if siteAdded >= siteLimit || userAdded >= userLimit {
 				return Diff{}, &RepoLimitError{
 					SiteAdded:  siteAdded,
@@ -782,10 +1286,15 @@ func calcSyncInterval(
 	now time.Time,
 	lastSync time.Time,
 	minSyncInterval time.Duration,
+	maxSyncInterval time.Duration,
 	modified bool,
 	err error,
 ) time.Duration {
-	const maxSyncInterval = 8 * time.Hour
+	// A misconfigured cap below the floor would invert the clamp, so treat the
+	// floor as the effective cap in that case.
+	if maxSyncInterval < minSyncInterval {
+		maxSyncInterval = minSyncInterval
+	}
 
 	// Special case, we've never synced
 	if err == nil && (lastSync.IsZero() || modified) {
@@ -0,0 +1,348 @@
+package repos
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// fakeSyncerStore is an in-memory syncerStore for unit testing sync without
+// a database.
+type fakeSyncerStore struct {
+	repos *database.MockRepoStore
+
+	createCalls int
+	updateCalls int
+}
+
+func (s *fakeSyncerStore) Transact(ctx context.Context) (syncerStore, error) { return s, nil }
+func (s *fakeSyncerStore) Done(err error) error                              { return err }
+
+func (s *fakeSyncerStore) CreateExternalServiceRepo(ctx context.Context, svc *types.ExternalService, r *types.Repo) error {
+	s.createCalls++
+	return nil
+}
+
+func (s *fakeSyncerStore) UpdateExternalServiceRepo(ctx context.Context, svc *types.ExternalService, r *types.Repo) error {
+	s.updateCalls++
+	return nil
+}
+
+func (s *fakeSyncerStore) DeleteExternalServiceReposNotIn(ctx context.Context, svc *types.ExternalService, ids map[api.RepoID]struct{}) ([]api.RepoID, error) {
+	return nil, nil
+}
+
+func (s *fakeSyncerStore) CountNamespacedRepos(ctx context.Context, userID, orgID int32) (uint64, error) {
+	return 0, nil
+}
+
+func (s *fakeSyncerStore) ExternalServices() database.ExternalServiceStore {
+	return database.NewMockExternalServiceStore()
+}
+
+func (s *fakeSyncerStore) Repos() database.RepoStore { return s.repos }
+
+func TestSyncWithFakeStore(t *testing.T) {
+	ctx := context.Background()
+	svc := &types.ExternalService{ID: 1, Kind: extsvc.KindGitHub}
+
+	spec := api.ExternalRepoSpec{ID: "ext1", ServiceType: extsvc.TypeGitHub, ServiceID: "https://github.com/"}
+	sourced := &types.Repo{Name: "github.com/foo/bar", ExternalRepo: spec, Description: "new"}
+
+	testCases := map[string]struct {
+		stored       []*types.Repo
+		wantAdded    int
+		wantModified int
+		wantCreates  int
+		wantUpdates  int
+		wantDeletes  int
+	}{
+		"no stored repo creates": {
+			stored:      nil,
+			wantAdded:   1,
+			wantCreates: 1,
+		},
+		"one stored repo updates": {
+			stored: []*types.Repo{
+				{ID: 1, Name: "github.com/foo/bar", ExternalRepo: spec, Description: "old"},
+			},
+			wantModified: 1,
+			wantUpdates:  1,
+		},
+		"two stored repos resolve the conflict": {
+			stored: []*types.Repo{
+				{ID: 1, Name: "github.com/foo/bar", ExternalRepo: spec, Description: "old"},
+				{ID: 2, Name: "github.com/foo/bar-conflict", ExternalRepo: api.ExternalRepoSpec{ID: "ext2", ServiceType: extsvc.TypeGitHub, ServiceID: "https://github.com/"}},
+			},
+			wantModified: 1,
+			wantUpdates:  1,
+			wantDeletes:  1,
+		},
+	}
+
+	for label, testCase := range testCases {
+		t.Run(label, func(t *testing.T) {
+			repoStore := database.NewMockRepoStore()
+			repoStore.ListFunc.SetDefaultReturn(testCase.stored, nil)
+			store := &fakeSyncerStore{repos: repoStore}
+
+			syncer := &Syncer{}
+			d, err := syncer.syncWith(ctx, store, svc, sourced)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(d.Added) != testCase.wantAdded {
+				t.Errorf("want %d added, got %d", testCase.wantAdded, len(d.Added))
+			}
+			if len(d.Modified) != testCase.wantModified {
+				t.Errorf("want %d modified, got %d", testCase.wantModified, len(d.Modified))
+			}
+			if store.createCalls != testCase.wantCreates {
+				t.Errorf("want %d creates, got %d", testCase.wantCreates, store.createCalls)
+			}
+			if store.updateCalls != testCase.wantUpdates {
+				t.Errorf("want %d updates, got %d", testCase.wantUpdates, store.updateCalls)
+			}
+			if deletes := len(repoStore.DeleteFunc.History()); deletes != testCase.wantDeletes {
+				t.Errorf("want %d deletes, got %d", testCase.wantDeletes, deletes)
+			}
+		})
+	}
+}
+
+func TestDiffMerge(t *testing.T) {
+	repo := func(id api.RepoID) *types.Repo {
+		return &types.Repo{ID: id}
+	}
+
+	var merged Diff
+	for _, diff := range []Diff{
+		{Added: types.Repos{repo(1)}},
+		{Modified: types.Repos{repo(2)}},
+		{Unmodified: types.Repos{repo(3)}},
+		{Deleted: types.Repos{repo(4)}},
+		// A repo seen again keeps its first classification, even if the later
+		// diff puts it in another bucket.
+		{Added: types.Repos{repo(1)}, Modified: types.Repos{repo(1), repo(5)}},
+	} {
+		merged.Merge(diff)
+	}
+
+	want := Diff{
+		Added:      types.Repos{repo(1)},
+		Deleted:    types.Repos{repo(4)},
+		Modified:   types.Repos{repo(2), repo(5)},
+		Unmodified: types.Repos{repo(3)},
+	}
+
+	ids := func(rs types.Repos) []api.RepoID {
+		var ids []api.RepoID
+		for _, r := range rs {
+			ids = append(ids, r.ID)
+		}
+		return ids
+	}
+
+	for _, bucket := range []struct {
+		name       string
+		have, want types.Repos
+	}{
+		{"Added", merged.Added, want.Added},
+		{"Deleted", merged.Deleted, want.Deleted},
+		{"Modified", merged.Modified, want.Modified},
+		{"Unmodified", merged.Unmodified, want.Unmodified},
+	} {
+		have, want := ids(bucket.have), ids(bucket.want)
+		if len(have) != len(want) {
+			t.Fatalf("%s: want %v, got %v", bucket.name, want, have)
+		}
+		for i := range want {
+			if have[i] != want[i] {
+				t.Fatalf("%s: want %v, got %v", bucket.name, want, have)
+			}
+		}
+	}
+}
+
+func TestCalcSyncInterval(t *testing.T) {
+	now := time.Now()
+	min := time.Minute
+	max := 8 * time.Hour
+
+	testCases := map[string]struct {
+		lastSync time.Time
+		max      time.Duration
+		modified bool
+		err      error
+		want     time.Duration
+	}{
+		"never synced": {
+			max:  max,
+			want: min,
+		},
+		"modified resets to the floor": {
+			lastSync: now.Add(-time.Hour),
+			max:      max,
+			modified: true,
+			want:     min,
+		},
+		"unmodified backs off": {
+			lastSync: now.Add(-30 * time.Minute),
+			max:      max,
+			want:     time.Hour,
+		},
+		"backoff is capped": {
+			lastSync: now.Add(-10 * time.Hour),
+			max:      max,
+			want:     max,
+		},
+		"custom cap clamps lower": {
+			lastSync: now.Add(-10 * time.Hour),
+			max:      2 * time.Hour,
+			want:     2 * time.Hour,
+		},
+		"cap below the floor falls back to the floor": {
+			lastSync: now.Add(-10 * time.Hour),
+			max:      time.Second,
+			want:     min,
+		},
+		"errored sync backs off": {
+			lastSync: now.Add(-30 * time.Minute),
+			max:      max,
+			modified: true,
+			err:      errors.New("boom"),
+			want:     time.Hour,
+		},
+	}
+
+	for label, testCase := range testCases {
+		t.Run(label, func(t *testing.T) {
+			have := calcSyncInterval(now, testCase.lastSync, min, testCase.max, testCase.modified, testCase.err)
+			if have != testCase.want {
+				t.Errorf("want %s, got %s", testCase.want, have)
+			}
+		})
+	}
+}
+
+func TestIsPackageHost(t *testing.T) {
+	pythonPackages := extsvc.NewCodeHost(&url.URL{Host: "python"}, "pythonPackages")
+
+	s := &Syncer{}
+	if !s.isPackageHost(extsvc.NPMPackages) {
+		t.Error("want built-in package hosts to be recognized")
+	}
+	if s.isPackageHost(extsvc.GitHubDotCom) {
+		t.Error("want github.com not to be treated as a package host")
+	}
+	if s.isPackageHost(pythonPackages) {
+		t.Error("want unconfigured service types not to be treated as package hosts")
+	}
+
+	s.AdditionalPackageHosts = []string{"PythonPackages"}
+	if !s.isPackageHost(pythonPackages) {
+		t.Error("want configured service types to be treated as package hosts")
+	}
+}
+
+func TestInFlightSyncs(t *testing.T) {
+	now := time.Now()
+	s := &Syncer{Now: func() time.Time { return now }}
+
+	if got := s.InFlightSyncs(); len(got) != 0 {
+		t.Fatalf("want no in-flight syncs initially, got %v", got)
+	}
+
+	// A running sync appears, with the time it started.
+	s.startInFlightSync(42)
+	got := s.InFlightSyncs()
+	if len(got) != 1 || got[0].ExternalServiceID != 42 || !got[0].StartedAt.Equal(now) {
+		t.Fatalf("want service 42 in flight since %s, got %v", now, got)
+	}
+
+	// Concurrent syncs are reported ordered by service ID.
+	s.startInFlightSync(7)
+	got = s.InFlightSyncs()
+	if len(got) != 2 || got[0].ExternalServiceID != 7 || got[1].ExternalServiceID != 42 {
+		t.Fatalf("want services 7 and 42 in flight, got %v", got)
+	}
+
+	// A finished sync disappears.
+	s.finishInFlightSync(42)
+	got = s.InFlightSyncs()
+	if len(got) != 1 || got[0].ExternalServiceID != 7 {
+		t.Fatalf("want only service 7 in flight, got %v", got)
+	}
+
+	s.finishInFlightSync(7)
+	if got := s.InFlightSyncs(); len(got) != 0 {
+		t.Fatalf("want no in-flight syncs, got %v", got)
+	}
+}
+
+func TestEnqueueWithRetry(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("transient failure is retried quickly", func(t *testing.T) {
+		calls := 0
+		enqueue := func(context.Context) error {
+			calls++
+			if calls == 1 {
+				return errors.New("boom")
+			}
+			return nil
+		}
+
+		start := time.Now()
+		if err := enqueueWithRetry(ctx, enqueue, 3, time.Millisecond); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if calls != 2 {
+			t.Fatalf("want 2 enqueue attempts, got %d", calls)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Fatalf("retry took too long: %s", elapsed)
+		}
+	})
+
+	t.Run("retries are bounded", func(t *testing.T) {
+		calls := 0
+		enqueue := func(context.Context) error {
+			calls++
+			return errors.New("boom")
+		}
+
+		if err := enqueueWithRetry(ctx, enqueue, 3, time.Millisecond); err == nil {
+			t.Fatal("expected error, got none")
+		}
+		if calls != 4 {
+			t.Fatalf("want 4 enqueue attempts, got %d", calls)
+		}
+	})
+
+	t.Run("cancelled context stops retrying", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		calls := 0
+		enqueue := func(context.Context) error {
+			calls++
+			return errors.New("boom")
+		}
+
+		if err := enqueueWithRetry(ctx, enqueue, 3, time.Millisecond); err == nil {
+			t.Fatal("expected error, got none")
+		}
+		if calls != 1 {
+			t.Fatalf("want 1 enqueue attempt, got %d", calls)
+		}
+	})
+}
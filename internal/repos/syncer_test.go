@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -18,6 +20,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
@@ -570,7 +573,7 @@ func testSyncerSync(s *repos.Store) func(*testing.T) {
 				}
 
 				for _, svc := range tc.svcs {
-					err := syncer.SyncExternalService(ctx, svc.ID, time.Millisecond)
+					err := syncer.SyncExternalService(ctx, svc.ID, time.Millisecond, 8*time.Hour)
 
 					if have, want := fmt.Sprint(err), tc.err; !strings.Contains(have, want) {
 						t.Errorf("error %q doesn't contain %q", have, want)
@@ -819,6 +822,439 @@ func testSyncRun(store *repos.Store) func(t *testing.T) {
 	}
 }
 
+func testSyncRunSkipsInitialUnmodifiedDiff(store *repos.Store) func(t *testing.T) {
+	return func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		svc := &types.ExternalService{
+			Config: `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc"}`,
+			Kind:   extsvc.KindGitHub,
+		}
+
+		if err := store.ExternalServiceStore.Upsert(ctx, svc); err != nil {
+			t.Fatal(err)
+		}
+
+		mk := func(name string) *types.Repo {
+			return &types.Repo{
+				Name:     api.RepoName(name),
+				Metadata: &github.Repository{},
+				ExternalRepo: api.ExternalRepoSpec{
+					ID:          name,
+					ServiceID:   "https://github.com",
+					ServiceType: svc.Kind,
+				},
+			}
+		}
+
+		stored := types.Repos{mk("initial")}.With(typestest.Opt.RepoSources(svc.URN()))
+		sourced := types.Repos{
+			mk("initial").With(func(r *types.Repo) { r.Description = "updated" }),
+		}
+
+		syncer := &repos.Syncer{
+			Sourcer: repos.NewFakeSourcer(nil, repos.NewFakeSource(svc, nil, sourced...)),
+			Store:   store,
+			Synced:  make(chan repos.Diff),
+			Now:     time.Now,
+		}
+
+		if err := store.RepoStore.Create(ctx, stored...); err != nil {
+			t.Fatal(err)
+		}
+
+		done := make(chan error)
+		go func() {
+			done <- syncer.Run(ctx, store, repos.RunOptions{
+				EnqueueInterval:           func() time.Duration { return time.Second },
+				MinSyncInterval:           func() time.Duration { return 1 * time.Millisecond },
+				DequeueInterval:           1 * time.Millisecond,
+				SkipInitialUnmodifiedDiff: true,
+			})
+		}()
+
+		ignore := cmpopts.IgnoreFields(types.Repo{}, "ID", "CreatedAt", "UpdatedAt", "Sources")
+
+		// With the initial diff skipped, the first thing sent down Synced is
+		// the result of the first sync, not the stored repos.
+		diff := <-syncer.Synced
+		if d := cmp.Diff(repos.Diff{Modified: sourced[:1]}, diff, ignore); d != "" {
+			t.Fatalf("Synced mismatch (-want +got):\n%s", d)
+		}
+
+		cancel()
+		err := <-done
+		if err != nil && err != context.Canceled {
+			t.Fatal(err)
+		}
+	}
+}
+
+// pagingSource is a fake Source that yields its pages in order and implements
+// repos.CursorSource, resuming from the page index given as cursor.
+type pagingSource struct {
+	svc   *types.ExternalService
+	pages []types.Repos
+
+	mu          sync.Mutex
+	cursor      string
+	resumedFrom string
+}
+
+func (s *pagingSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
+
+func (s *pagingSource) CheckConnection(context.Context) error { return nil }
+
+func (s *pagingSource) Cursor() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cursor
+}
+
+func (s *pagingSource) SetCursor(cursor string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cursor = cursor
+	s.resumedFrom = cursor
+}
+
+func (s *pagingSource) ResumedFrom() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.resumedFrom
+}
+
+func (s *pagingSource) ListRepos(ctx context.Context, results chan repos.SourceResult) {
+	s.mu.Lock()
+	start, _ := strconv.Atoi(s.cursor)
+	s.mu.Unlock()
+
+	for i := start; i < len(s.pages); i++ {
+		for _, r := range s.pages[i] {
+			select {
+			case <-ctx.Done():
+				return
+			case results <- repos.SourceResult{Source: s, Repo: r}:
+			}
+		}
+
+		s.mu.Lock()
+		s.cursor = strconv.Itoa(i + 1)
+		s.mu.Unlock()
+	}
+}
+
+func testSyncResumesFromCursor(store *repos.Store) func(t *testing.T) {
+	return func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		svc := &types.ExternalService{
+			Config: `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc"}`,
+			Kind:   extsvc.KindGitHub,
+		}
+
+		if err := store.ExternalServiceStore.Upsert(ctx, svc); err != nil {
+			t.Fatal(err)
+		}
+
+		mk := func(name string) *types.Repo {
+			return &types.Repo{
+				Name:     api.RepoName(name),
+				Metadata: &github.Repository{},
+				ExternalRepo: api.ExternalRepoSpec{
+					ID:          name,
+					ServiceID:   "https://github.com",
+					ServiceType: svc.Kind,
+				},
+			}
+		}
+
+		// Simulate a crashed sync job that processed the first page, persisted
+		// its cursor and stored the repo before dying.
+		stored := types.Repos{mk("one")}.With(typestest.Opt.RepoSources(svc.URN()))
+		if err := store.RepoStore.Create(ctx, stored...); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := store.EnqueueSingleSyncJob(ctx, svc.ID); err != nil {
+			t.Fatal(err)
+		}
+		var jobID int64
+		q := sqlf.Sprintf("SELECT id FROM external_service_sync_jobs WHERE external_service_id = %s", svc.ID)
+		if err := store.QueryRow(ctx, q).Scan(&jobID); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.SetSyncJobCursor(ctx, jobID, "1"); err != nil {
+			t.Fatal(err)
+		}
+
+		src := &pagingSource{
+			svc:   svc,
+			pages: []types.Repos{{mk("one")}, {mk("two")}, {mk("three")}},
+		}
+
+		syncer := &repos.Syncer{
+			Sourcer: repos.NewFakeSourcer(nil, src),
+			Store:   store,
+			Synced:  make(chan repos.Diff),
+			Now:     time.Now,
+		}
+
+		done := make(chan error)
+		go func() {
+			done <- syncer.Run(ctx, store, repos.RunOptions{
+				EnqueueInterval:           func() time.Duration { return time.Hour },
+				MinSyncInterval:           func() time.Duration { return time.Hour },
+				DequeueInterval:           1 * time.Millisecond,
+				SkipInitialUnmodifiedDiff: true,
+			})
+		}()
+
+		ignore := cmpopts.IgnoreFields(types.Repo{}, "ID", "CreatedAt", "UpdatedAt", "Sources")
+
+		// The resumed sync should skip the already-processed first page and
+		// only source the remaining two.
+		diff := <-syncer.Synced
+		if d := cmp.Diff(repos.Diff{Added: types.Repos{mk("two")}}, diff, ignore); d != "" {
+			t.Fatalf("Synced mismatch (-want +got):\n%s", d)
+		}
+
+		diff = <-syncer.Synced
+		if d := cmp.Diff(repos.Diff{Added: types.Repos{mk("three")}}, diff, ignore); d != "" {
+			t.Fatalf("Synced mismatch (-want +got):\n%s", d)
+		}
+
+		if got := src.ResumedFrom(); got != "1" {
+			t.Fatalf("want source to resume from cursor %q, got %q", "1", got)
+		}
+
+		// Wait for the job to finish so we can observe the final state.
+		deadline := time.Now().Add(10 * time.Second)
+		for {
+			var state string
+			q := sqlf.Sprintf("SELECT state FROM external_service_sync_jobs WHERE id = %s", jobID)
+			if err := store.QueryRow(ctx, q).Scan(&state); err != nil {
+				t.Fatal(err)
+			}
+			if state == "completed" {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("sync job did not complete, state %q", state)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		// The cursor should have advanced past the seeded value.
+		if cursor, err := store.GetSyncJobCursor(ctx, jobID); err != nil {
+			t.Fatal(err)
+		} else if cursor == "" || cursor == "1" {
+			t.Fatalf("want cursor to advance past %q, got %q", "1", cursor)
+		}
+
+		// The repo sourced by the crashed attempt must not be deleted, even
+		// though the resumed sync never saw it.
+		rs, err := store.RepoStore.List(ctx, database.ReposListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		names := make(map[string]bool, len(rs))
+		for _, r := range rs {
+			names[string(r.Name)] = true
+		}
+		for _, name := range []string{"one", "two", "three"} {
+			if !names[name] {
+				t.Fatalf("want repo %q to exist after resumed sync, got %v", name, names)
+			}
+		}
+
+		cancel()
+		err = <-done
+		if err != nil && err != context.Canceled {
+			t.Fatal(err)
+		}
+	}
+}
+
+func testSyncLogsDiffCounts(store *repos.Store) func(t *testing.T) {
+	return func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		svc := &types.ExternalService{
+			Config: `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc"}`,
+			Kind:   extsvc.KindGitHub,
+		}
+
+		if err := store.ExternalServiceStore.Upsert(ctx, svc); err != nil {
+			t.Fatal(err)
+		}
+
+		mk := func(name string) *types.Repo {
+			return &types.Repo{
+				Name:     api.RepoName(name),
+				Metadata: &github.Repository{},
+				ExternalRepo: api.ExternalRepoSpec{
+					ID:          name,
+					ServiceID:   "https://github.com",
+					ServiceType: svc.Kind,
+				},
+			}
+		}
+
+		// "one" will come back modified, "extra" won't be seen and gets
+		// deleted, and "two" is new.
+		stored := types.Repos{mk("one"), mk("extra")}.With(typestest.Opt.RepoSources(svc.URN()))
+		if err := store.RepoStore.Create(ctx, stored...); err != nil {
+			t.Fatal(err)
+		}
+
+		sourced := []*types.Repo{
+			mk("one").With(func(r *types.Repo) { r.Description = "updated" }),
+			mk("two"),
+		}
+
+		if err := store.EnqueueSingleSyncJob(ctx, svc.ID); err != nil {
+			t.Fatal(err)
+		}
+		var jobID int64
+		q := sqlf.Sprintf("SELECT id FROM external_service_sync_jobs WHERE external_service_id = %s", svc.ID)
+		if err := store.QueryRow(ctx, q).Scan(&jobID); err != nil {
+			t.Fatal(err)
+		}
+
+		syncer := &repos.Syncer{
+			Sourcer: repos.NewFakeSourcer(nil, repos.NewFakeSource(svc, nil, sourced...)),
+			Store:   store,
+			Synced:  make(chan repos.Diff, 4),
+			Now:     time.Now,
+		}
+
+		done := make(chan error)
+		go func() {
+			done <- syncer.Run(ctx, store, repos.RunOptions{
+				EnqueueInterval:           func() time.Duration { return time.Hour },
+				MinSyncInterval:           func() time.Duration { return time.Hour },
+				DequeueInterval:           1 * time.Millisecond,
+				SkipInitialUnmodifiedDiff: true,
+			})
+		}()
+
+		deadline := time.Now().Add(10 * time.Second)
+		for {
+			var state string
+			q := sqlf.Sprintf("SELECT state FROM external_service_sync_jobs WHERE id = %s", jobID)
+			if err := store.QueryRow(ctx, q).Scan(&state); err != nil {
+				t.Fatal(err)
+			}
+			if state == "completed" {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("sync job did not complete, state %q", state)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		logs, err := store.ExternalServiceStore.GetSyncJobLogs(ctx, jobID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(logs) == 0 {
+			t.Fatal("want sync job logs, got none")
+		}
+		last := logs[len(logs)-1]
+		want := "1 added, 1 modified, 1 deleted, 0 unmodified"
+		if !strings.Contains(last, want) {
+			t.Fatalf("want final log line to contain %q, got %q", want, last)
+		}
+
+		cancel()
+		err = <-done
+		if err != nil && err != context.Canceled {
+			t.Fatal(err)
+		}
+	}
+}
+
+// blockingSource is a fake Source whose ListRepos never yields and only
+// returns once its context is done.
+type blockingSource struct {
+	svc *types.ExternalService
+}
+
+func (s *blockingSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
+
+func (s *blockingSource) CheckConnection(context.Context) error { return nil }
+
+func (s *blockingSource) ListRepos(ctx context.Context, results chan repos.SourceResult) {
+	<-ctx.Done()
+}
+
+func testSyncSourceTimeout(store *repos.Store) func(t *testing.T) {
+	return func(t *testing.T) {
+		ctx := context.Background()
+
+		svc := &types.ExternalService{
+			Config: `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc"}`,
+			Kind:   extsvc.KindGitHub,
+		}
+
+		if err := store.ExternalServiceStore.Upsert(ctx, svc); err != nil {
+			t.Fatal(err)
+		}
+
+		stored := types.Repos{&types.Repo{
+			Name:     "initial",
+			Metadata: &github.Repository{},
+			ExternalRepo: api.ExternalRepoSpec{
+				ID:          "initial",
+				ServiceID:   "https://github.com",
+				ServiceType: svc.Kind,
+			},
+		}}.With(typestest.Opt.RepoSources(svc.URN()))
+		if err := store.RepoStore.Create(ctx, stored...); err != nil {
+			t.Fatal(err)
+		}
+
+		syncer := &repos.Syncer{
+			Sourcer: repos.NewFakeSourcer(nil, &blockingSource{svc: svc}),
+			Store:   store,
+			Now:     time.Now,
+			SourceTimeouts: map[string]time.Duration{
+				extsvc.KindGitHub: 50 * time.Millisecond,
+			},
+		}
+
+		start := time.Now()
+		err := syncer.SyncExternalService(ctx, svc.ID, time.Hour, 8*time.Hour)
+		if err == nil {
+			t.Fatal("want sync to fail with a timeout error, got nil")
+		}
+		if !errors.HasType(err, &repos.SourceTimeoutError{}) {
+			t.Fatalf("want SourceTimeoutError, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > 10*time.Second {
+			t.Fatalf("sync took %s, want it abandoned shortly after the timeout", elapsed)
+		}
+
+		// A timed-out sync saw nothing, but its repos must not be deleted.
+		rs, err := store.RepoStore.List(ctx, database.ReposListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rs) != 1 || rs[0].Name != "initial" {
+			t.Fatalf("want stored repo to survive a timed-out sync, got %v", rs)
+		}
+	}
+}
+
 func testSyncerMultipleServices(store *repos.Store) func(t *testing.T) {
 	return func(t *testing.T) {
 		ctx, cancel := context.WithCancel(context.Background())
@@ -980,30 +1416,207 @@ func testSyncerMultipleServices(store *repos.Store) func(t *testing.T) {
 	}
 }
 
-func testOrphanedRepo(store *repos.Store) func(*testing.T) {
+func testOrphanedRepo(store *repos.Store) func(*testing.T) {
+	return func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		now := time.Now()
+
+		svc1 := &types.ExternalService{
+			Kind:        extsvc.KindGitHub,
+			DisplayName: "Github - Test1",
+			Config:      `{"url": "https://github.com"}`,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		svc2 := &types.ExternalService{
+			Kind:        extsvc.KindGitHub,
+			DisplayName: "Github - Test2",
+			Config:      `{"url": "https://github.com"}`,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+
+		// setup services
+		if err := store.ExternalServiceStore.Upsert(ctx, svc1, svc2); err != nil {
+			t.Fatal(err)
+		}
+
+		githubRepo := &types.Repo{
+			Name:     "github.com/org/foo",
+			Metadata: &github.Repository{},
+			ExternalRepo: api.ExternalRepoSpec{
+				ID:          "foo-external-12345",
+				ServiceID:   "https://github.com/",
+				ServiceType: extsvc.TypeGitHub,
+			},
+		}
+
+		// Add two services, both pointing at the same repo
+
+		// Sync first service
+		syncer := &repos.Syncer{
+			Sourcer: func(service *types.ExternalService) (repos.Source, error) {
+				s := repos.NewFakeSource(svc1, nil, githubRepo)
+				return s, nil
+			},
+			Store: store,
+			Now:   time.Now,
+		}
+		if err := syncer.SyncExternalService(ctx, svc1.ID, 10*time.Second, 8*time.Hour); err != nil {
+			t.Fatal(err)
+		}
+
+		// Sync second service
+		syncer.Sourcer = func(service *types.ExternalService) (repos.Source, error) {
+			s := repos.NewFakeSource(svc2, nil, githubRepo)
+			return s, nil
+		}
+		if err := syncer.SyncExternalService(ctx, svc2.ID, 10*time.Second, 8*time.Hour); err != nil {
+			t.Fatal(err)
+		}
+
+		// Confirm that there are two relationships
+		assertSourceCount(ctx, t, store, 2)
+
+		// We should have no deleted repos
+		assertDeletedRepoCount(ctx, t, store, 0)
+
+		// Remove the repo from one service and sync again
+		syncer.Sourcer = func(services *types.ExternalService) (repos.Source, error) {
+			s := repos.NewFakeSource(svc1, nil)
+			return s, nil
+		}
+		if err := syncer.SyncExternalService(ctx, svc1.ID, 10*time.Second, 8*time.Hour); err != nil {
+			t.Fatal(err)
+		}
+
+		// Confirm that the repository hasn't been deleted
+		rs, err := store.RepoStore.List(ctx, database.ReposListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rs) != 1 {
+			t.Fatalf("Expected 1 repo, got %d", len(rs))
+		}
+
+		// Confirm that there is one relationship
+		assertSourceCount(ctx, t, store, 1)
+
+		// We should have no deleted repos
+		assertDeletedRepoCount(ctx, t, store, 0)
+
+		// Remove the repo from the second service and sync again
+		syncer.Sourcer = func(service *types.ExternalService) (repos.Source, error) {
+			s := repos.NewFakeSource(svc2, nil)
+			return s, nil
+		}
+		if err := syncer.SyncExternalService(ctx, svc2.ID, 10*time.Second, 8*time.Hour); err != nil {
+			t.Fatal(err)
+		}
+
+		// Confirm that there no relationships
+		assertSourceCount(ctx, t, store, 0)
+
+		// We should have one deleted repo
+		assertDeletedRepoCount(ctx, t, store, 1)
+	}
+}
+
+func testPreserveOnError(store *repos.Store) func(*testing.T) {
+	return func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		now := time.Now()
+
+		var userID int32
+		err := store.QueryRow(ctx, sqlf.Sprintf("INSERT INTO users (username) VALUES ('preserve-user') RETURNING id")).
+			Scan(&userID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		userService := &types.ExternalService{
+			Kind:            extsvc.KindGitHub,
+			DisplayName:     "Github - User",
+			Config:          `{"url": "https://github.com"}`,
+			CreatedAt:       now,
+			UpdatedAt:       now,
+			NamespaceUserID: userID,
+		}
+
+		if err := store.ExternalServiceStore.Upsert(ctx, userService); err != nil {
+			t.Fatal(err)
+		}
+
+		githubRepo := &types.Repo{
+			Name:     "github.com/org/foo",
+			Metadata: &github.Repository{},
+			ExternalRepo: api.ExternalRepoSpec{
+				ID:          "foo-external-12345",
+				ServiceID:   "https://github.com/",
+				ServiceType: extsvc.TypeGitHub,
+			},
+		}
+
+		syncer := &repos.Syncer{
+			Sourcer: func(service *types.ExternalService) (repos.Source, error) {
+				s := repos.NewFakeSource(userService, nil, githubRepo)
+				return s, nil
+			},
+			Store:           store,
+			Now:             time.Now,
+			PreserveOnError: true,
+		}
+		if err := syncer.SyncExternalService(ctx, userService.ID, 10*time.Second, 8*time.Hour); err != nil {
+			t.Fatal(err)
+		}
+
+		assertSourceCount(ctx, t, store, 1)
+		assertDeletedRepoCount(ctx, t, store, 0)
+
+		// Sync again with a fatal error. Without PreserveOnError this would
+		// delete all repos of the user-owned service.
+		syncer.Sourcer = func(service *types.ExternalService) (repos.Source, error) {
+			s := repos.NewFakeSource(userService, &repos.ErrUnauthorized{})
+			return s, nil
+		}
+		if err := syncer.SyncExternalService(ctx, userService.ID, 10*time.Second, 8*time.Hour); err == nil {
+			t.Fatal("expected an error, got none")
+		}
+
+		// The repo and its source association must still be there.
+		assertSourceCount(ctx, t, store, 1)
+		assertDeletedRepoCount(ctx, t, store, 0)
+	}
+}
+
+func testNonFatalErrors(store *repos.Store) func(*testing.T) {
 	return func(t *testing.T) {
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
 
 		now := time.Now()
 
-		svc1 := &types.ExternalService{
-			Kind:        extsvc.KindGitHub,
-			DisplayName: "Github - Test1",
-			Config:      `{"url": "https://github.com"}`,
-			CreatedAt:   now,
-			UpdatedAt:   now,
+		var userID int32
+		err := store.QueryRow(ctx, sqlf.Sprintf("INSERT INTO users (username) VALUES ('non-fatal-user') RETURNING id")).
+			Scan(&userID)
+		if err != nil {
+			t.Fatal(err)
 		}
-		svc2 := &types.ExternalService{
-			Kind:        extsvc.KindGitHub,
-			DisplayName: "Github - Test2",
-			Config:      `{"url": "https://github.com"}`,
-			CreatedAt:   now,
-			UpdatedAt:   now,
+
+		userService := &types.ExternalService{
+			Kind:            extsvc.KindGitHub,
+			DisplayName:     "Github - User",
+			Config:          `{"url": "https://github.com"}`,
+			CreatedAt:       now,
+			UpdatedAt:       now,
+			NamespaceUserID: userID,
 		}
 
-		// setup services
-		if err := store.ExternalServiceStore.Upsert(ctx, svc1, svc2); err != nil {
+		if err := store.ExternalServiceStore.Upsert(ctx, userService); err != nil {
 			t.Fatal(err)
 		}
 
@@ -1017,73 +1630,50 @@ func testOrphanedRepo(store *repos.Store) func(*testing.T) {
 			},
 		}
 
-		// Add two services, both pointing at the same repo
-
-		// Sync first service
 		syncer := &repos.Syncer{
 			Sourcer: func(service *types.ExternalService) (repos.Source, error) {
-				s := repos.NewFakeSource(svc1, nil, githubRepo)
+				s := repos.NewFakeSource(userService, nil, githubRepo)
 				return s, nil
 			},
 			Store: store,
 			Now:   time.Now,
+			// The code host answers 403 while rate-limiting, so forbidden
+			// errors from it must not be treated as fatal.
+			NonFatalErrors: map[string]func(error) bool{
+				extsvc.KindGitHub: errcode.IsForbidden,
+			},
 		}
-		if err := syncer.SyncExternalService(ctx, svc1.ID, 10*time.Second); err != nil {
-			t.Fatal(err)
-		}
-
-		// Sync second service
-		syncer.Sourcer = func(service *types.ExternalService) (repos.Source, error) {
-			s := repos.NewFakeSource(svc2, nil, githubRepo)
-			return s, nil
-		}
-		if err := syncer.SyncExternalService(ctx, svc2.ID, 10*time.Second); err != nil {
+		if err := syncer.SyncExternalService(ctx, userService.ID, 10*time.Second, 8*time.Hour); err != nil {
 			t.Fatal(err)
 		}
 
-		// Confirm that there are two relationships
-		assertSourceCount(ctx, t, store, 2)
-
-		// We should have no deleted repos
+		assertSourceCount(ctx, t, store, 1)
 		assertDeletedRepoCount(ctx, t, store, 0)
 
-		// Remove the repo from one service and sync again
-		syncer.Sourcer = func(services *types.ExternalService) (repos.Source, error) {
-			s := repos.NewFakeSource(svc1, nil)
+		// Sync again with a forbidden error. Without the override this is
+		// fatal and would delete all repos of the user-owned service.
+		syncer.Sourcer = func(service *types.ExternalService) (repos.Source, error) {
+			s := repos.NewFakeSource(userService, &repos.ErrForbidden{})
 			return s, nil
 		}
-		if err := syncer.SyncExternalService(ctx, svc1.ID, 10*time.Second); err != nil {
-			t.Fatal(err)
-		}
-
-		// Confirm that the repository hasn't been deleted
-		rs, err := store.RepoStore.List(ctx, database.ReposListOptions{})
-		if err != nil {
-			t.Fatal(err)
-		}
-		if len(rs) != 1 {
-			t.Fatalf("Expected 1 repo, got %d", len(rs))
+		if err := syncer.SyncExternalService(ctx, userService.ID, 10*time.Second, 8*time.Hour); err == nil {
+			t.Fatal("expected an error, got none")
 		}
 
-		// Confirm that there is one relationship
+		// The repo and its source association must still be there.
 		assertSourceCount(ctx, t, store, 1)
-
-		// We should have no deleted repos
 		assertDeletedRepoCount(ctx, t, store, 0)
 
-		// Remove the repo from the second service and sync again
+		// An unauthorized error isn't covered by the override and stays fatal.
 		syncer.Sourcer = func(service *types.ExternalService) (repos.Source, error) {
-			s := repos.NewFakeSource(svc2, nil)
+			s := repos.NewFakeSource(userService, &repos.ErrUnauthorized{})
 			return s, nil
 		}
-		if err := syncer.SyncExternalService(ctx, svc2.ID, 10*time.Second); err != nil {
-			t.Fatal(err)
+		if err := syncer.SyncExternalService(ctx, userService.ID, 10*time.Second, 8*time.Hour); err == nil {
+			t.Fatal("expected an error, got none")
 		}
 
-		// Confirm that there no relationships
 		assertSourceCount(ctx, t, store, 0)
-
-		// We should have one deleted repo
 		assertDeletedRepoCount(ctx, t, store, 1)
 	}
 }
@@ -1128,7 +1718,7 @@ func testCloudDefaultExternalServicesDontSync(store *repos.Store) func(*testing.
 			Now:   time.Now,
 		}
 
-		have := syncer.SyncExternalService(ctx, svc1.ID, 10*time.Second)
+		have := syncer.SyncExternalService(ctx, svc1.ID, 10*time.Second, 8*time.Hour)
 		want := repos.ErrCloudDefaultSync
 
 		if have != want {
@@ -1185,7 +1775,7 @@ func testConflictingSyncers(store *repos.Store) func(*testing.T) {
 			Store: store,
 			Now:   time.Now,
 		}
-		if err := syncer.SyncExternalService(ctx, svc1.ID, 10*time.Second); err != nil {
+		if err := syncer.SyncExternalService(ctx, svc1.ID, 10*time.Second, 8*time.Hour); err != nil {
 			t.Fatal(err)
 		}
 
@@ -1198,7 +1788,7 @@ func testConflictingSyncers(store *repos.Store) func(*testing.T) {
 			Store: store,
 			Now:   time.Now,
 		}
-		if err := syncer.SyncExternalService(ctx, svc2.ID, 10*time.Second); err != nil {
+		if err := syncer.SyncExternalService(ctx, svc2.ID, 10*time.Second, 8*time.Hour); err != nil {
 			t.Fatal(err)
 		}
 
@@ -1242,7 +1832,7 @@ func testConflictingSyncers(store *repos.Store) func(*testing.T) {
 			Store: tx1,
 			Now:   time.Now,
 		}
-		if err := syncer.SyncExternalService(ctx, svc1.ID, 10*time.Second); err != nil {
+		if err := syncer.SyncExternalService(ctx, svc1.ID, 10*time.Second, 8*time.Hour); err != nil {
 			t.Fatal(err)
 		}
 
@@ -1260,7 +1850,7 @@ func testConflictingSyncers(store *repos.Store) func(*testing.T) {
 
 		errChan := make(chan error)
 		go func() {
-			errChan <- syncer2.SyncExternalService(ctx, svc2.ID, 10*time.Second)
+			errChan <- syncer2.SyncExternalService(ctx, svc2.ID, 10*time.Second, 8*time.Hour)
 		}()
 
 		tx1.Done(nil)
@@ -1339,7 +1929,7 @@ func testSyncRepoMaintainsOtherSources(store *repos.Store) func(*testing.T) {
 			Store: store,
 			Now:   time.Now,
 		}
-		if err := syncer.SyncExternalService(ctx, svc1.ID, 10*time.Second); err != nil {
+		if err := syncer.SyncExternalService(ctx, svc1.ID, 10*time.Second, 8*time.Hour); err != nil {
 			t.Fatal(err)
 		}
 
@@ -1352,7 +1942,7 @@ func testSyncRepoMaintainsOtherSources(store *repos.Store) func(*testing.T) {
 			Store: store,
 			Now:   time.Now,
 		}
-		if err := syncer.SyncExternalService(ctx, svc2.ID, 10*time.Second); err != nil {
+		if err := syncer.SyncExternalService(ctx, svc2.ID, 10*time.Second, 8*time.Hour); err != nil {
 			t.Fatal(err)
 		}
 
@@ -1377,6 +1967,214 @@ func testSyncRepoMaintainsOtherSources(store *repos.Store) func(*testing.T) {
 	}
 }
 
+// flakyRepoGetter wraps a FakeSource with a GetRepo that fails with a
+// transient error on the first call and succeeds afterwards.
+type flakyRepoGetter struct {
+	*repos.FakeSource
+	calls int
+}
+
+func (s *flakyRepoGetter) GetRepo(ctx context.Context, name string) (*types.Repo, error) {
+	s.calls++
+	if s.calls == 1 {
+		return nil, errors.New("transient code host error")
+	}
+	return s.FakeSource.GetRepo(ctx, name)
+}
+
+func testSyncRepoBackgroundRetries(store *repos.Store) func(*testing.T) {
+	return func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		now := time.Now()
+
+		svc := &types.ExternalService{
+			Kind:         extsvc.KindGitHub,
+			DisplayName:  "Github - Test1",
+			Config:       `{"url": "https://github.com"}`,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+			CloudDefault: true,
+		}
+
+		if err := store.ExternalServiceStore.Upsert(ctx, svc); err != nil {
+			t.Fatal(err)
+		}
+
+		githubRepo := &types.Repo{
+			Name:     "github.com/org/foo",
+			Metadata: &github.Repository{},
+			ExternalRepo: api.ExternalRepoSpec{
+				ID:          "foo-external-12345",
+				ServiceID:   "https://github.com/",
+				ServiceType: extsvc.TypeGitHub,
+			},
+			// Make the stored repo stale so SyncRepo doesn't skip the sync.
+			UpdatedAt: now.Add(-time.Hour),
+		}
+
+		if err := store.RepoStore.Create(ctx, githubRepo); err != nil {
+			t.Fatal(err)
+		}
+
+		sourced := githubRepo.With(func(r *types.Repo) {
+			r.Description = "updated description"
+		})
+
+		src := &flakyRepoGetter{FakeSource: repos.NewFakeSource(svc, nil, sourced)}
+		syncer := &repos.Syncer{
+			Sourcer: func(service *types.ExternalService) (repos.Source, error) {
+				return src, nil
+			},
+			Store:                     store,
+			Now:                       time.Now,
+			Synced:                    make(chan repos.Diff, 1),
+			SyncRepoBackgroundBackoff: time.Millisecond,
+		}
+
+		if _, err := syncer.SyncRepo(ctx, githubRepo.Name, true); err != nil {
+			t.Fatal(err)
+		}
+
+		// The first GetRepo call fails with a transient error, so the repo is
+		// only synced once the background path retries.
+		select {
+		case <-syncer.Synced:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for background sync to be retried")
+		}
+
+		if src.calls != 2 {
+			t.Fatalf("want 2 GetRepo calls, got %d", src.calls)
+		}
+
+		have, err := store.RepoStore.GetByName(ctx, githubRepo.Name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have.Description != sourced.Description {
+			t.Fatalf("repo not updated by background sync: %+v", have)
+		}
+	}
+}
+
+// batchRepoGetter wraps a FakeSource with a GetRepos implementation, recording
+// how often the batched and per-repo paths are taken.
+type batchRepoGetter struct {
+	*repos.FakeSource
+	batchCalls  int
+	singleCalls int
+}
+
+func (s *batchRepoGetter) GetRepos(ctx context.Context, paths []string) ([]*types.Repo, error) {
+	s.batchCalls++
+	rs := make([]*types.Repo, 0, len(paths))
+	for _, path := range paths {
+		r, err := s.FakeSource.GetRepo(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		rs = append(rs, r)
+	}
+	return rs, nil
+}
+
+func (s *batchRepoGetter) GetRepo(ctx context.Context, path string) (*types.Repo, error) {
+	s.singleCalls++
+	return s.FakeSource.GetRepo(ctx, path)
+}
+
+func testSyncReposBatch(store *repos.Store) func(*testing.T) {
+	return func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		now := time.Now()
+
+		svc := &types.ExternalService{
+			Kind:         extsvc.KindGitHub,
+			DisplayName:  "Github - Test1",
+			Config:       `{"url": "https://github.com"}`,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+			CloudDefault: true,
+		}
+
+		if err := store.ExternalServiceStore.Upsert(ctx, svc); err != nil {
+			t.Fatal(err)
+		}
+
+		var sourced types.Repos
+		for _, name := range []string{"foo", "bar"} {
+			sourced = append(sourced, &types.Repo{
+				Name:     api.RepoName("github.com/org/" + name),
+				Metadata: &github.Repository{},
+				ExternalRepo: api.ExternalRepoSpec{
+					ID:          name + "-external-12345",
+					ServiceID:   "https://github.com/",
+					ServiceType: extsvc.TypeGitHub,
+				},
+			})
+		}
+
+		names := []api.RepoName{"github.com/org/foo", "github.com/org/bar"}
+
+		t.Run("batched", func(t *testing.T) {
+			src := &batchRepoGetter{FakeSource: repos.NewFakeSource(svc, nil, sourced...)}
+			syncer := &repos.Syncer{
+				Sourcer: func(service *types.ExternalService) (repos.Source, error) {
+					return src, nil
+				},
+				Store:  store,
+				Now:    time.Now,
+				Synced: make(chan repos.Diff, len(names)),
+			}
+
+			synced, err := syncer.SyncRepos(ctx, names)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(synced) != len(names) {
+				t.Fatalf("want %d synced repos, got %d", len(names), len(synced))
+			}
+
+			if src.batchCalls != 1 {
+				t.Errorf("want 1 GetRepos call, got %d", src.batchCalls)
+			}
+			if src.singleCalls != 0 {
+				t.Errorf("want 0 GetRepo calls, got %d", src.singleCalls)
+			}
+
+			for _, name := range names {
+				if _, err := store.RepoStore.GetByName(ctx, name); err != nil {
+					t.Errorf("repo %q not synced: %s", name, err)
+				}
+			}
+		})
+
+		t.Run("falls back to per-repo", func(t *testing.T) {
+			src := repos.NewFakeSource(svc, nil, sourced...)
+			syncer := &repos.Syncer{
+				Sourcer: func(service *types.ExternalService) (repos.Source, error) {
+					return src, nil
+				},
+				Store:  store,
+				Now:    time.Now,
+				Synced: make(chan repos.Diff, len(names)),
+			}
+
+			synced, err := syncer.SyncRepos(ctx, names)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(synced) != len(names) {
+				t.Fatalf("want %d synced repos, got %d", len(names), len(synced))
+			}
+		})
+	}
+}
+
 func testUserAddedRepos(store *repos.Store) func(*testing.T) {
 	return func(t *testing.T) {
 		ctx, cancel := context.WithCancel(context.Background())
@@ -1453,7 +2251,7 @@ func testUserAddedRepos(store *repos.Store) func(*testing.T) {
 			Store: store,
 			Now:   time.Now,
 		}
-		if err := syncer.SyncExternalService(ctx, adminService.ID, 10*time.Second); err != nil {
+		if err := syncer.SyncExternalService(ctx, adminService.ID, 10*time.Second, 8*time.Hour); err != nil {
 			t.Fatal(err)
 		}
 
@@ -1469,7 +2267,7 @@ func testUserAddedRepos(store *repos.Store) func(*testing.T) {
 			Store: store,
 			Now:   time.Now,
 		}
-		if err := syncer.SyncExternalService(ctx, adminService.ID, 10*time.Second); err != nil {
+		if err := syncer.SyncExternalService(ctx, adminService.ID, 10*time.Second, 8*time.Hour); err != nil {
 			t.Fatal(err)
 		}
 
@@ -1485,7 +2283,7 @@ func testUserAddedRepos(store *repos.Store) func(*testing.T) {
 			Store: store,
 			Now:   time.Now,
 		}
-		if err := syncer.SyncExternalService(ctx, userService.ID, 10*time.Second); err != nil {
+		if err := syncer.SyncExternalService(ctx, userService.ID, 10*time.Second, 8*time.Hour); err != nil {
 			t.Fatal(err)
 		}
 
@@ -1507,7 +2305,7 @@ func testUserAddedRepos(store *repos.Store) func(*testing.T) {
 			Store: store,
 			Now:   time.Now,
 		}
-		if err := syncer.SyncExternalService(ctx, userService.ID, 10*time.Second); err != nil {
+		if err := syncer.SyncExternalService(ctx, userService.ID, 10*time.Second, 8*time.Hour); err != nil {
 			t.Fatal(err)
 		}
 
@@ -1536,7 +2334,7 @@ func testUserAddedRepos(store *repos.Store) func(*testing.T) {
 			Store: store,
 			Now:   time.Now,
 		}
-		if err := syncer.SyncExternalService(ctx, userService.ID, 10*time.Second); err != nil {
+		if err := syncer.SyncExternalService(ctx, userService.ID, 10*time.Second, 8*time.Hour); err != nil {
 			t.Fatal(err)
 		}
 
@@ -1557,7 +2355,7 @@ func testUserAddedRepos(store *repos.Store) func(*testing.T) {
 			Store:               store,
 			UserReposMaxPerUser: 1,
 		}
-		if err := syncer.SyncExternalService(ctx, userService.ID, 10*time.Second); err == nil {
+		if err := syncer.SyncExternalService(ctx, userService.ID, 10*time.Second, 8*time.Hour); err == nil {
 			t.Fatal("Expected an error, got none")
 		}
 
@@ -1571,7 +2369,7 @@ func testUserAddedRepos(store *repos.Store) func(*testing.T) {
 			Store:               store,
 			UserReposMaxPerSite: 1,
 		}
-		if err := syncer.SyncExternalService(ctx, userService.ID, 10*time.Second); err == nil {
+		if err := syncer.SyncExternalService(ctx, userService.ID, 10*time.Second, 8*time.Hour); err == nil {
 			t.Fatal("Expected an error, got none")
 		}
 	}
@@ -1638,7 +2436,7 @@ func testNameOnConflictOnRename(store *repos.Store) func(*testing.T) {
 			Store: store,
 			Now:   time.Now,
 		}
-		if err := syncer.SyncExternalService(ctx, svc1.ID, 10*time.Second); err != nil {
+		if err := syncer.SyncExternalService(ctx, svc1.ID, 10*time.Second, 8*time.Hour); err != nil {
 			t.Fatal(err)
 		}
 
@@ -1651,7 +2449,7 @@ func testNameOnConflictOnRename(store *repos.Store) func(*testing.T) {
 			Store: store,
 			Now:   time.Now,
 		}
-		if err := syncer.SyncExternalService(ctx, svc2.ID, 10*time.Second); err != nil {
+		if err := syncer.SyncExternalService(ctx, svc2.ID, 10*time.Second, 8*time.Hour); err != nil {
 			t.Fatal(err)
 		}
 
@@ -1669,7 +2467,7 @@ func testNameOnConflictOnRename(store *repos.Store) func(*testing.T) {
 			Store: store,
 			Now:   time.Now,
 		}
-		if err := syncer.SyncExternalService(ctx, svc1.ID, 10*time.Second); err != nil {
+		if err := syncer.SyncExternalService(ctx, svc1.ID, 10*time.Second, 8*time.Hour); err != nil {
 			t.Fatal(err)
 		}
 
@@ -1742,7 +2540,7 @@ func testDeleteExternalService(store *repos.Store) func(*testing.T) {
 			Store: store,
 			Now:   time.Now,
 		}
-		if err := syncer.SyncExternalService(ctx, svc1.ID, 10*time.Second); err != nil {
+		if err := syncer.SyncExternalService(ctx, svc1.ID, 10*time.Second, 8*time.Hour); err != nil {
 			t.Fatal(err)
 		}
 
@@ -1755,7 +2553,7 @@ func testDeleteExternalService(store *repos.Store) func(*testing.T) {
 			Store: store,
 			Now:   time.Now,
 		}
-		if err := syncer.SyncExternalService(ctx, svc2.ID, 10*time.Second); err != nil {
+		if err := syncer.SyncExternalService(ctx, svc2.ID, 10*time.Second, 8*time.Hour); err != nil {
 			t.Fatal(err)
 		}
 
@@ -1869,7 +2667,7 @@ func testAbortSyncWhenThereIsRepoLimitError(store *repos.Store) func(*testing.T)
 				UserReposMaxPerUser: 1,
 			}
 
-			if err := syncer.SyncExternalService(ctx, svc.ID, 10*time.Second); err != nil {
+			if err := syncer.SyncExternalService(ctx, svc.ID, 10*time.Second, 8*time.Hour); err != nil {
 				var me errors.MultiError
 				if !errors.As(err, &me) {
 					t.Fatalf("Expected error.MultiError, got: %T", err)
@@ -1898,6 +2696,78 @@ func testAbortSyncWhenThereIsRepoLimitError(store *repos.Store) func(*testing.T)
 	}
 }
 
+func testOrgReposMaxPerOrgOverride(store *repos.Store) func(*testing.T) {
+	return func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		now := time.Now()
+
+		// create fake org
+		orgName := "sample-org102"
+		org, err := database.OrgsWith(store).Create(ctx, orgName, &orgName)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// create fake source
+		svc := &types.ExternalService{
+			Kind:           extsvc.KindGitHub,
+			DisplayName:    "Github - Test1",
+			Config:         `{"url": "https://github.com"}`,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+			NamespaceOrgID: org.ID,
+		}
+
+		// setup services
+		if err := store.ExternalServiceStore.Upsert(ctx, svc); err != nil {
+			t.Fatal(err)
+		}
+
+		githubRepo := &types.Repo{
+			Name:     "github.com/org/foo",
+			Metadata: &github.Repository{},
+			ExternalRepo: api.ExternalRepoSpec{
+				ID:          "foo-external-12345",
+				ServiceID:   "https://github.com/",
+				ServiceType: extsvc.TypeGitHub,
+			},
+		}
+
+		githubRepo2 := &types.Repo{
+			Name:     "github.com/org/foo2",
+			Metadata: &github.Repository{},
+			ExternalRepo: api.ExternalRepoSpec{
+				ID:          "foo-external-123456",
+				ServiceID:   "https://github.com/",
+				ServiceType: extsvc.TypeGitHub,
+			},
+		}
+
+		syncer := &repos.Syncer{
+			Sourcer: func(service *types.ExternalService) (repos.Source, error) {
+				s := repos.NewFakeSource(svc, nil, githubRepo, githubRepo2)
+				return s, nil
+			},
+			Store:               store,
+			Now:                 time.Now,
+			UserReposMaxPerSite: 10,
+			UserReposMaxPerUser: 1,
+			OrgReposMaxPerOrgOverrides: map[int32]int{
+				org.ID: 2,
+			},
+		}
+
+		// The override lets the org exceed the default per-namespace limit of 1.
+		if err := syncer.SyncExternalService(ctx, svc.ID, 10*time.Second, 8*time.Hour); err != nil {
+			t.Fatal(err)
+		}
+
+		assertSourceCount(ctx, t, store, 2)
+	}
+}
+
 func testUserAndOrgReposAreCountedCorrectly(store *repos.Store) func(*testing.T) {
 	return func(t *testing.T) {
 		ctx, cancel := context.WithCancel(context.Background())
@@ -2004,7 +2874,7 @@ func testUserAndOrgReposAreCountedCorrectly(store *repos.Store) func(*testing.T)
 				UserReposMaxPerUser: 3,
 			}
 
-			if err := syncer.SyncExternalService(ctx, svc.ID, 10*time.Second); err != nil {
+			if err := syncer.SyncExternalService(ctx, svc.ID, 10*time.Second, 8*time.Hour); err != nil {
 				t.Fatal("Error occurred. Should not happen because neither site nor user/org limit is exceeded.")
 			}
 			repoIdx += 2
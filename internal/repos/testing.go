@@ -64,3 +64,8 @@ func (s FakeSource) GetRepo(ctx context.Context, name string) (*types.Repo, erro
 func (s FakeSource) ExternalServices() types.ExternalServices {
 	return types.ExternalServices{s.svc}
 }
+
+// CheckConnection returns the error that the FakeSource was instantiated with.
+func (s FakeSource) CheckConnection(ctx context.Context) error {
+	return s.err
+}
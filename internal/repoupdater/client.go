@@ -270,6 +270,36 @@ func (c *Client) SyncExternalService(
 	return &result, nil
 }
 
+// CheckExternalServiceConnection requests a connection check for the external
+// service with the given id. A check failure is returned in the result's Error
+// field, not as an error.
+func (c *Client) CheckExternalServiceConnection(
+	ctx context.Context,
+	id int64,
+) (*protocol.ExternalServiceCheckConnectionResult, error) {
+	req := &protocol.ExternalServiceCheckConnectionRequest{ExternalServiceID: id}
+	resp, err := c.httpPost(ctx, "check-external-service-connection", req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read response body")
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return nil, errors.New(string(bs))
+	}
+
+	var result protocol.ExternalServiceCheckConnectionResult
+	if err := json.Unmarshal(bs, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // RepoExternalServices requests the external services associated with a
 // repository with the given id.
 func (c *Client) RepoExternalServices(ctx context.Context, id api.RepoID) ([]api.ExternalService, error) {
@@ -280,3 +280,15 @@ type ExternalServiceSyncResult struct {
 	ExternalService api.ExternalService
 	Error           string
 }
+
+// ExternalServiceCheckConnectionRequest is a request to check that the external
+// service with the given ID can reach and authenticate against its code host.
+type ExternalServiceCheckConnectionRequest struct {
+	ExternalServiceID int64
+}
+
+// ExternalServiceCheckConnectionResult is a result type of an external
+// service's check connection request. Error is empty when the check passed.
+type ExternalServiceCheckConnectionResult struct {
+	Error string
+}
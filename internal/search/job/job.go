@@ -34,6 +34,13 @@ type Args struct {
 	OnSourcegraphDotCom bool
 	Zoekt               zoekt.Streamer
 	SearcherURLs        *endpoint.Map
+
+	// RepoResultsOnly, when true, limits the constructed job tree to
+	// RepoSearch and ComputeExcludedRepos jobs, skipping text, structural,
+	// symbol, commit, and diff search jobs. Callers that only need repository
+	// results (e.g., repo pickers) use this to avoid paying for job
+	// construction they don't need.
+	RepoResultsOnly bool
 }
 
 // ToSearchJob converts a query parse tree to the _internal_ representation
@@ -57,6 +64,12 @@ func ToSearchJob(jargs *Args, q query.Q) (Job, error) {
 	// still relies on all of args. In time it should depend only on the bits it truly needs.
 	args.RepoOptions = repoOptions
 
+	if jargs.RepoResultsOnly {
+		// Restrict the result types so that only the repo search and excluded
+		// repos jobs below are constructed.
+		args.ResultTypes = result.TypeRepo
+	}
+
 	repoUniverseSearch, skipRepoSubsetSearch, onlyRunSearcher := jobMode(args, jargs.SearchInputs.PatternType, jargs.OnSourcegraphDotCom)
 
 	var requiredJobs, optionalJobs []Job
@@ -219,7 +232,7 @@ func ToSearchJob(jargs *Args, q query.Q) (Job, error) {
 			})
 		}
 
-		if jargs.SearchInputs.PatternType == query.SearchTypeStructural && args.PatternInfo.Pattern != "" {
+		if jargs.SearchInputs.PatternType == query.SearchTypeStructural && args.PatternInfo.Pattern != "" && !jargs.RepoResultsOnly {
 			typ := search.TextRequest
 			zoektQuery, err := search.QueryToZoektQuery(args.PatternInfo, &args.Features, typ)
 			if err != nil {
@@ -150,6 +150,38 @@ func TestToSearchInputs(t *testing.T) {
 `).Equal(t, test("type:file type:path type:repo type:commit type:symbol repo:test test", search.Batch, query.ParseRegexp))
 }
 
+func TestToSearchJobRepoResultsOnly(t *testing.T) {
+	test := func(input string) string {
+		q, _ := query.ParseLiteral(input)
+		args := &Args{
+			SearchInputs: &run.SearchInputs{
+				UserSettings: &schema.Settings{},
+				PatternType:  query.SearchTypeLiteral,
+				Protocol:     search.Streaming,
+			},
+			OnSourcegraphDotCom: true,
+			RepoResultsOnly:     true,
+		}
+
+		j, _ := ToSearchJob(args, q)
+		return "\n" + PrettySexp(j) + "\n"
+	}
+
+	// No text, structural, or symbol search jobs are constructed, only repo
+	// search and excluded repos computation.
+	autogold.Want("repo results only for global search", `
+(PARALLEL
+  Repo
+  ComputeExcludedRepos)
+`).Equal(t, test(`foo`))
+
+	autogold.Want("repo results only for nonglobal search", `
+(PARALLEL
+  Repo
+  ComputeExcludedRepos)
+`).Equal(t, test(`foo repo:sourcegraph/sourcegraph`))
+}
+
 func TestToEvaluateJob(t *testing.T) {
 	test := func(input string, protocol search.Protocol) string {
 		q, _ := query.ParseLiteral(input)
@@ -24,12 +24,20 @@ func writeSep(b *bytes.Buffer, sep, indent string, depth int) {
 	}
 }
 
+// SexpOptions configures the output of SexpFormat.
+type SexpOptions struct {
+	// HideWrappers elides the TIMEOUT and LIMIT wrapper nodes, rendering
+	// their children in their place. This gives a cleaner structural view of
+	// a plan when the resource limits are not of interest.
+	HideWrappers bool
+}
+
 // SexpFormat controls the s-expression format that represents a Job. `sep`
 // specifies the separator between terms. If `indent` is not empty, `indent` is
 // prefixed the number of times corresponding to depth of the term in the tree.
 // See the `Sexp` and `PrettySexp` convenience functions to see how these
 // options are used.
-func SexpFormat(job Job, sep, indent string) string {
+func SexpFormat(job Job, sep, indent string, opts SexpOptions) string {
 	b := new(bytes.Buffer)
 	depth := 0
 	var writeSexp func(Job)
@@ -96,6 +104,10 @@ func SexpFormat(job Job, sep, indent string) string {
 			depth--
 			b.WriteString(")")
 		case *TimeoutJob:
+			if opts.HideWrappers {
+				writeSexp(j.child)
+				return
+			}
 			b.WriteString("(TIMEOUT")
 			depth++
 			writeSep(b, sep, indent, depth)
@@ -105,6 +117,10 @@ func SexpFormat(job Job, sep, indent string) string {
 			b.WriteString(")")
 			depth--
 		case *LimitJob:
+			if opts.HideWrappers {
+				writeSexp(j.child)
+				return
+			}
 			b.WriteString("(LIMIT")
 			depth++
 			writeSep(b, sep, indent, depth)
@@ -148,12 +164,18 @@ func SexpFormat(job Job, sep, indent string) string {
 
 // Sexp outputs the s-expression on a single line.
 func Sexp(job Job) string {
-	return SexpFormat(job, " ", "")
+	return SexpFormat(job, " ", "", SexpOptions{})
 }
 
 // PrettySexp outputs a formatted s-expression with two spaces of indentation, potentially spanning multiple lines.
 func PrettySexp(job Job) string {
-	return SexpFormat(job, "\n", "  ")
+	return SexpFormat(job, "\n", "  ", SexpOptions{})
+}
+
+// SexpStructural outputs a formatted s-expression like PrettySexp, but elides
+// the TIMEOUT and LIMIT wrapper nodes for a cleaner structural view.
+func SexpStructural(job Job) string {
+	return SexpFormat(job, "\n", "  ", SexpOptions{HideWrappers: true})
 }
 
 type NodeStyle int
@@ -171,6 +193,12 @@ func writeEdge(b *bytes.Buffer, depth, src, dst int) {
 }
 
 func writeNode(b *bytes.Buffer, depth int, style NodeStyle, id *int, label string) {
+	writeClassedNode(b, depth, style, id, label, "")
+}
+
+// writeClassedNode writes a node like writeNode and additionally annotates it
+// with a Mermaid class when class is non-empty.
+func writeClassedNode(b *bytes.Buffer, depth int, style NodeStyle, id *int, label, class string) {
 	open := "["
 	close := "]"
 	if style == RoundedStyle {
@@ -181,30 +209,68 @@ func writeNode(b *bytes.Buffer, depth int, style NodeStyle, id *int, label strin
 	b.WriteString(open)
 	b.WriteString(label)
 	b.WriteString(close)
+	if class != "" {
+		b.WriteString(":::")
+		b.WriteString(class)
+	}
 	writeSep(b, "\n", "  ", depth)
 	*id++
 }
 
+// universeNodeClass is the Mermaid class assigned to jobs that run over the
+// indexed (zoekt) universe when HighlightUniverseJobs is set.
+const universeNodeClass = "universe"
+
+const universeClassDef = "classDef universe fill:#d6eaf8,stroke:#2e86c1\n"
+
+type mermaidConfig struct {
+	highlightUniverse bool
+}
+
+// MermaidOption configures the output of PrettyMermaid.
+type MermaidOption func(*mermaidConfig)
+
+// HighlightUniverseJobs styles jobs that run over the indexed (zoekt) universe
+// (RepoUniverseText, RepoUniverseSymbol) with a distinct Mermaid class, making
+// it easy to see which parts of a search hit the indexed universe rather than
+// the RepoSubset* backends backed by searcher/gitserver.
+func HighlightUniverseJobs() MermaidOption {
+	return func(c *mermaidConfig) { c.highlightUniverse = true }
+}
+
 // PrettyMermaid outputs a Mermaid flowchart. See https://mermaid-js.github.io.
-func PrettyMermaid(job Job) string {
+func PrettyMermaid(job Job, opts ...MermaidOption) string {
+	var config mermaidConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
 	depth := 0
 	id := 0
 	b := new(bytes.Buffer)
 	b.WriteString("flowchart TB\n")
+	if config.highlightUniverse {
+		b.WriteString(universeClassDef)
+	}
 	var writeMermaid func(Job)
 	writeMermaid = func(job Job) {
 		if job == nil {
 			return
 		}
 		switch j := job.(type) {
+		case
+			*textsearch.RepoUniverseTextSearch,
+			*symbol.RepoUniverseSymbolSearch:
+			class := ""
+			if config.highlightUniverse {
+				class = universeNodeClass
+			}
+			writeClassedNode(b, depth, RoundedStyle, &id, j.Name(), class)
 		case
 			*run.RepoSearch,
 			*textsearch.RepoSubsetTextSearch,
-			*textsearch.RepoUniverseTextSearch,
 			*structural.StructuralSearch,
 			*commit.CommitSearch,
 			*symbol.RepoSubsetSymbolSearch,
-			*symbol.RepoUniverseSymbolSearch,
 			*repos.ComputeExcludedRepos,
 			*noopJob:
 			writeNode(b, depth, RoundedStyle, &id, j.Name())
@@ -313,11 +379,19 @@ func toJSON(job Job, verbose bool) interface{} {
 			return struct{}{}
 		}
 		switch j := job.(type) {
+		case *structural.StructuralSearch:
+			if verbose {
+				return map[string]interface{}{j.Name(): j}
+			}
+			// Structural search nodes carry their distinguishing parameters
+			// in the summary so users can debug why structural search is (or
+			// isn't) part of the plan.
+			return map[string]interface{}{j.Name(): j.DebugFields()}
+
 		case
 			*run.RepoSearch,
 			*textsearch.RepoSubsetTextSearch,
 			*textsearch.RepoUniverseTextSearch,
-			*structural.StructuralSearch,
 			*commit.CommitSearch,
 			*symbol.RepoSubsetSymbolSearch,
 			*symbol.RepoUniverseSymbolSearch,
@@ -8,6 +8,9 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	"github.com/sourcegraph/sourcegraph/internal/search/run"
+	"github.com/sourcegraph/sourcegraph/internal/search/structural"
+	"github.com/sourcegraph/sourcegraph/internal/search/symbol"
+	"github.com/sourcegraph/sourcegraph/internal/search/textsearch"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
@@ -59,6 +62,37 @@ func TestSexp(t *testing.T) {
 							NewNoopJob()))))))))
 }
 
+func TestSexpStructural(t *testing.T) {
+	job := NewFilterJob(
+		NewLimitJob(
+			100,
+			NewTimeoutJob(
+				50*1_000_000,
+				NewAndJob(
+					NewNoopJob(),
+					NewNoopJob()))))
+
+	autogold.Want("full sexp keeps wrappers", `
+(FILTER
+  SubRepoPermissions
+  (LIMIT
+    100
+    (TIMEOUT
+      50ms
+      (AND
+        NoopJob
+        NoopJob))))
+`).Equal(t, fmt.Sprintf("\n%s\n", PrettySexp(job)))
+
+	autogold.Want("structural sexp elides wrappers", `
+(FILTER
+  SubRepoPermissions
+  (AND
+    NoopJob
+    NoopJob))
+`).Equal(t, fmt.Sprintf("\n%s\n", SexpStructural(job)))
+}
+
 func TestPrettyMermaid(t *testing.T) {
 	autogold.Want("simple mermaid", `
 flowchart TB
@@ -131,6 +165,25 @@ flowchart TB
 							NewNoopJob()))))))))
 }
 
+func TestPrettyMermaidHighlightUniverseJobs(t *testing.T) {
+	autogold.Want("universe jobs get a distinct class", `
+flowchart TB
+classDef universe fill:#d6eaf8,stroke:#2e86c1
+0([PARALLEL])
+  0---1
+  1([RepoUniverseText]):::universe
+  0---2
+  2([RepoSubsetText])
+  0---3
+  3([RepoUniverseSymbol]):::universe
+  `).Equal(t, fmt.Sprintf("\n%s", PrettyMermaid(
+		NewParallelJob(
+			&textsearch.RepoUniverseTextSearch{},
+			&textsearch.RepoSubsetTextSearch{},
+			&symbol.RepoUniverseSymbolSearch{}),
+		HighlightUniverseJobs())))
+}
+
 func TestPrettyJSON(t *testing.T) {
 	autogold.Want("big JSON summary", `
 {
@@ -458,3 +511,32 @@ func TestPrettyJSON(t *testing.T) {
   ]
 }`).Equal(t, fmt.Sprintf("\n%s", test("repo:foo bar")))
 }
+
+func TestPrettyJSONStructural(t *testing.T) {
+	autogold.Want("structural search summary", `
+{
+  "PARALLEL": [
+    {
+      "Structural": {
+        "combyRuleSet": true,
+        "languages": [
+          "go"
+        ],
+        "useIndex": "yes"
+      }
+    },
+    "NoopJob"
+  ]
+}`).Equal(t, fmt.Sprintf("\n%s", PrettyJSON(
+		NewParallelJob(
+			&structural.StructuralSearch{
+				SearcherArgs: &search.SearcherParameters{
+					PatternInfo: &search.TextPatternInfo{
+						CombyRule: `where "a" == "a"`,
+						Languages: []string{"go"},
+					},
+				},
+				UseIndex: query.Yes,
+			},
+			NewNoopJob()))))
+}
@@ -193,3 +193,16 @@ func (s *StructuralSearch) Run(ctx context.Context, db database.DB, stream strea
 func (*StructuralSearch) Name() string {
 	return "Structural"
 }
+
+// DebugFields returns the parameters that distinguish this structural search,
+// so job printers can show why structural search is (or isn't) part of a plan.
+func (s *StructuralSearch) DebugFields() map[string]interface{} {
+	fields := map[string]interface{}{
+		"useIndex": string(s.UseIndex),
+	}
+	if s.SearcherArgs != nil && s.SearcherArgs.PatternInfo != nil {
+		fields["combyRuleSet"] = s.SearcherArgs.PatternInfo.CombyRule != ""
+		fields["languages"] = s.SearcherArgs.PatternInfo.Languages
+	}
+	return fields
+}
@@ -479,6 +479,7 @@ type ExternalService struct {
 	Unrestricted    bool  // Whether access to repositories belong to this external service is unrestricted.
 	CloudDefault    bool  // Whether this external service is our default public service on Cloud
 	HasWebhooks     *bool // Whether this external service has webhooks configured; calculated from Config
+	Paused          bool  // Whether background syncing of this external service is paused; toggled via Pause/Resume only
 }
 
 // ExternalServiceSyncJob represents an sync job for an external service
@@ -1121,6 +1122,32 @@ type SearchOnboarding struct {
 	CloseOnboardingTourClicked *int32
 }
 
+// UserAndEventCount is the number of unique users that fired an event and the
+// total number of times it fired.
+type UserAndEventCount struct {
+	UserCount  int32
+	EventCount int32
+}
+
+// CTAUsage counts daily interactions with the browser extension install
+// call-to-action, broken down by the page it was displayed on.
+type CTAUsage struct {
+	StartTime time.Time
+
+	// DisplayedByPage and ClickedByPage are keyed by the page the CTA appeared
+	// on ('file', 'search', 'repo', ...), as reported in the event's 'page'
+	// argument.
+	DisplayedByPage map[string]UserAndEventCount
+	ClickedByPage   map[string]UserAndEventCount
+
+	// DEPRECATED: These fields mirror the 'file' and 'search' entries of the
+	// maps above and are kept for BI pipeline compatibility.
+	DisplayedOnFilePage   UserAndEventCount
+	DisplayedOnSearchPage UserAndEventCount
+	ClickedOnFilePage     UserAndEventCount
+	ClickedOnSearchPage   UserAndEventCount
+}
+
 // Weekly usage statistics for the extensions platform
 type ExtensionsUsageStatistics struct {
 	WeekStart                  time.Time
@@ -12,8 +12,17 @@ type Config struct {
 	ManageBucket bool
 	Bucket       string
 	TTL          time.Duration
-	S3           S3Config
-	GCS          GCSConfig
+
+	// MaxRetries is the maximum number of times a transiently failing request
+	// against the backend is retried before the operation fails.
+	MaxRetries int
+
+	// UploadTimeout bounds each upload operation against the backend. A
+	// non-positive value means no timeout.
+	UploadTimeout time.Duration
+
+	S3  S3Config
+	GCS GCSConfig
 }
 
 func normalizeConfig(t Config) Config {
@@ -64,7 +64,7 @@ func TestS3ClientConfig(t *testing.T) {
 		},
 	}
 
-	cfg, err := s3ClientConfig(context.Background(), config.S3)
+	cfg, err := s3ClientConfig(context.Background(), config)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -23,6 +23,7 @@ type gcsObjectHandle interface {
 	NewRangeReader(ctx context.Context, offset, length int64) (io.ReadCloser, error)
 	NewWriter(ctx context.Context) io.WriteCloser
 	ComposerFrom(sources ...gcsObjectHandle) gcsComposer
+	Retryer(opts ...storage.RetryOption) gcsObjectHandle
 }
 
 type gcsComposer interface {
@@ -76,6 +77,10 @@ func (s *objectHandleShim) NewWriter(ctx context.Context) io.WriteCloser {
 	return s.handle.NewWriter(ctx)
 }
 
+func (s *objectHandleShim) Retryer(opts ...storage.RetryOption) gcsObjectHandle {
+	return &objectHandleShim{handle: s.handle.Retryer(opts...)}
+}
+
 func (s *objectHandleShim) ComposerFrom(sources ...gcsObjectHandle) gcsComposer {
 	var handles []*storage.ObjectHandle
 	for _, source := range sources {
@@ -17,12 +17,14 @@ import (
 )
 
 type gcsStore struct {
-	bucket       string
-	ttl          time.Duration
-	manageBucket bool
-	config       GCSConfig
-	client       gcsAPI
-	operations   *Operations
+	bucket        string
+	ttl           time.Duration
+	manageBucket  bool
+	maxRetries    int
+	uploadTimeout time.Duration
+	config        GCSConfig
+	client        gcsAPI
+	operations    *Operations
 }
 
 var _ Store = &gcsStore{}
@@ -40,7 +42,10 @@ func newGCSFromConfig(ctx context.Context, config Config, operations *Operations
 		return nil, err
 	}
 
-	return newGCSWithClient(&gcsAPIShim{client}, config.Bucket, config.TTL, config.ManageBucket, config.GCS, operations), nil
+	store := newGCSWithClient(&gcsAPIShim{client}, config.Bucket, config.TTL, config.ManageBucket, config.GCS, operations)
+	store.maxRetries = config.MaxRetries
+	store.uploadTimeout = config.UploadTimeout
+	return store, nil
 }
 
 func newGCSWithClient(client gcsAPI, bucket string, ttl time.Duration, manageBucket bool, config GCSConfig, operations *Operations) *gcsStore {
@@ -103,7 +108,28 @@ func (s *gcsStore) Upload(ctx context.Context, key string, r io.Reader) (_ int64
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	writer := s.client.Bucket(s.bucket).Object(key).NewWriter(ctx)
+	if s.uploadTimeout > 0 {
+		var timeoutCancel context.CancelFunc
+		ctx, timeoutCancel = context.WithTimeout(ctx, s.uploadTimeout)
+		defer timeoutCancel()
+	}
+
+	object := s.client.Bucket(s.bucket).Object(key)
+	if s.maxRetries > 0 {
+		// Uploads are not idempotent, so the client doesn't retry them by
+		// default. Opt into retries, bounded by counting attempts in the
+		// error func since this client version has no max-attempts option.
+		retries := 0
+		object = object.Retryer(
+			storage.WithPolicy(storage.RetryAlways),
+			storage.WithErrorFunc(func(err error) bool {
+				retries++
+				return retries <= s.maxRetries
+			}),
+		)
+	}
+
+	writer := object.NewWriter(ctx)
 	defer func() {
 		if closeErr := writer.Close(); closeErr != nil {
 			err = errors.Append(err, errors.Wrap(closeErr, "failed to close writer"))
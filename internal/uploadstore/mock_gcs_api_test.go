@@ -859,6 +859,9 @@ type MockGcsObjectHandle struct {
 	// NewWriterFunc is an instance of a mock function object controlling
 	// the behavior of the method NewWriter.
 	NewWriterFunc *GcsObjectHandleNewWriterFunc
+	// RetryerFunc is an instance of a mock function object controlling the
+	// behavior of the method Retryer.
+	RetryerFunc *GcsObjectHandleRetryerFunc
 }
 
 // NewMockGcsObjectHandle creates a new mock of the gcsObjectHandle
@@ -886,6 +889,11 @@ func NewMockGcsObjectHandle() *MockGcsObjectHandle {
 				return nil
 			},
 		},
+		RetryerFunc: &GcsObjectHandleRetryerFunc{
+			defaultHook: func(...storage.RetryOption) gcsObjectHandle {
+				return nil
+			},
+		},
 	}
 }
 
@@ -913,6 +921,11 @@ func NewStrictMockGcsObjectHandle() *MockGcsObjectHandle {
 				panic("unexpected invocation of MockGcsObjectHandle.NewWriter")
 			},
 		},
+		RetryerFunc: &GcsObjectHandleRetryerFunc{
+			defaultHook: func(...storage.RetryOption) gcsObjectHandle {
+				panic("unexpected invocation of MockGcsObjectHandle.Retryer")
+			},
+		},
 	}
 }
 
@@ -925,6 +938,7 @@ type surrogateMockGcsObjectHandle interface {
 	Delete(context.Context) error
 	NewRangeReader(context.Context, int64, int64) (io.ReadCloser, error)
 	NewWriter(context.Context) io.WriteCloser
+	Retryer(...storage.RetryOption) gcsObjectHandle
 }
 
 // NewMockGcsObjectHandleFrom creates a new mock of the MockGcsObjectHandle
@@ -944,6 +958,9 @@ func NewMockGcsObjectHandleFrom(i surrogateMockGcsObjectHandle) *MockGcsObjectHa
 		NewWriterFunc: &GcsObjectHandleNewWriterFunc{
 			defaultHook: i.NewWriter,
 		},
+		RetryerFunc: &GcsObjectHandleRetryerFunc{
+			defaultHook: i.Retryer,
+		},
 	}
 }
 
@@ -1373,3 +1390,112 @@ func (c GcsObjectHandleNewWriterFuncCall) Args() []interface{} {
 func (c GcsObjectHandleNewWriterFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
+
+// GcsObjectHandleRetryerFunc describes the behavior when the Retryer method
+// of the parent MockGcsObjectHandle instance is invoked.
+type GcsObjectHandleRetryerFunc struct {
+	defaultHook func(...storage.RetryOption) gcsObjectHandle
+	hooks       []func(...storage.RetryOption) gcsObjectHandle
+	history     []GcsObjectHandleRetryerFuncCall
+	mutex       sync.Mutex
+}
+
+// Retryer delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockGcsObjectHandle) Retryer(v0 ...storage.RetryOption) gcsObjectHandle {
+	r0 := m.RetryerFunc.nextHook()(v0...)
+	m.RetryerFunc.appendCall(GcsObjectHandleRetryerFuncCall{v0, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Retryer method of
+// the parent MockGcsObjectHandle instance is invoked and the hook queue is
+// empty.
+func (f *GcsObjectHandleRetryerFunc) SetDefaultHook(hook func(...storage.RetryOption) gcsObjectHandle) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Retryer method of the parent MockGcsObjectHandle instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *GcsObjectHandleRetryerFunc) PushHook(hook func(...storage.RetryOption) gcsObjectHandle) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GcsObjectHandleRetryerFunc) SetDefaultReturn(r0 gcsObjectHandle) {
+	f.SetDefaultHook(func(...storage.RetryOption) gcsObjectHandle {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GcsObjectHandleRetryerFunc) PushReturn(r0 gcsObjectHandle) {
+	f.PushHook(func(...storage.RetryOption) gcsObjectHandle {
+		return r0
+	})
+}
+
+func (f *GcsObjectHandleRetryerFunc) nextHook() func(...storage.RetryOption) gcsObjectHandle {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GcsObjectHandleRetryerFunc) appendCall(r0 GcsObjectHandleRetryerFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GcsObjectHandleRetryerFuncCall objects
+// describing the invocations of this function.
+func (f *GcsObjectHandleRetryerFunc) History() []GcsObjectHandleRetryerFuncCall {
+	f.mutex.Lock()
+	history := make([]GcsObjectHandleRetryerFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GcsObjectHandleRetryerFuncCall is an object that describes an invocation
+// of method Retryer on an instance of MockGcsObjectHandle.
+type GcsObjectHandleRetryerFuncCall struct {
+	// Arg0 is a slice containing the values of the variadic arguments
+	// passed to this method invocation.
+	Arg0 []storage.RetryOption
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 gcsObjectHandle
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation. The variadic slice argument is flattened in this array such
+// that one positional argument and three variadic arguments would result in
+// a slice of four, not two.
+func (c GcsObjectHandleRetryerFuncCall) Args() []interface{} {
+	trailing := []interface{}{}
+	for _, val := range c.Arg0 {
+		trailing = append(trailing, val)
+	}
+
+	return append([]interface{}{}, trailing...)
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GcsObjectHandleRetryerFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
@@ -25,6 +26,7 @@ import (
 type s3Store struct {
 	bucket                       string
 	manageBucket                 bool
+	uploadTimeout                time.Duration
 	client                       s3API
 	uploader                     s3Uploader
 	bucketLifecycleConfiguration *s3types.BucketLifecycleConfiguration
@@ -43,7 +45,7 @@ type S3Config struct {
 
 // newS3FromConfig creates a new store backed by AWS Simple Storage Service.
 func newS3FromConfig(ctx context.Context, config Config, operations *Operations) (Store, error) {
-	cfg, err := s3ClientConfig(ctx, config.S3)
+	cfg, err := s3ClientConfig(ctx, config)
 	if err != nil {
 		return nil, err
 	}
@@ -51,7 +53,9 @@ func newS3FromConfig(ctx context.Context, config Config, operations *Operations)
 	s3Client := s3.NewFromConfig(cfg, s3ClientOptions(config.Backend, config.S3))
 	api := &s3APIShim{s3Client}
 	uploader := &s3UploaderShim{manager.NewUploader(s3Client)}
-	return newS3WithClients(api, uploader, config.Bucket, config.ManageBucket, s3BucketLifecycleConfiguration(config.Backend, config.TTL), operations), nil
+	store := newS3WithClients(api, uploader, config.Bucket, config.ManageBucket, s3BucketLifecycleConfiguration(config.Backend, config.TTL), operations)
+	store.uploadTimeout = config.UploadTimeout
+	return store, nil
 }
 
 func newS3WithClients(client s3API, uploader s3Uploader, bucket string, manageBucket bool, lifecycleConfiguration *s3types.BucketLifecycleConfiguration, operations *Operations) *s3Store {
@@ -154,6 +158,12 @@ func (s *s3Store) Upload(ctx context.Context, key string, r io.Reader) (_ int64,
 	}})
 	defer endObservation(1, observation.Args{})
 
+	if s.uploadTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.uploadTimeout)
+		defer cancel()
+	}
+
 	cr := &countingReader{r: r}
 
 	if err := s.uploader.Upload(ctx, &s3.PutObjectInput{
@@ -318,14 +328,18 @@ func (r *countingReader) Read(p []byte) (n int, err error) {
 	return n, err
 }
 
-func s3ClientConfig(ctx context.Context, s3config S3Config) (aws.Config, error) {
+func s3ClientConfig(ctx context.Context, config Config) (aws.Config, error) {
 	optFns := []func(*awsconfig.LoadOptions) error{
-		awsconfig.WithRegion(s3config.Region),
+		awsconfig.WithRegion(config.S3.Region),
 		awsconfig.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(
-			s3config.AccessKeyID,
-			s3config.SecretAccessKey,
-			s3config.SessionToken,
+			config.S3.AccessKeyID,
+			config.S3.SecretAccessKey,
+			config.S3.SessionToken,
 		)),
+		// MaxRetries counts retries after the initial attempt.
+		awsconfig.WithRetryer(func() aws.Retryer {
+			return retry.AddWithMaxAttempts(retry.NewStandard(), config.MaxRetries+1)
+		}),
 	}
 
 	return awsconfig.LoadDefaultConfig(ctx, optFns...)
@@ -376,3 +376,4 @@ GROUP BY argument;
 const InsightsTotalCountPingName = `INSIGHT_TOTAL_COUNTS`
 const InsightsIntervalCountsPingName = `INSIGHT_TIME_INTERVALS`
 const InsightsOrgVisibleInsightsPingName = `INSIGHT_ORG_VISIBLE_INSIGHTS`
+const InsightsOrgVisibleInsightsByOrgPingName = `INSIGHT_ORG_VISIBLE_INSIGHTS_BY_ORG`
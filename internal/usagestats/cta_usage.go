@@ -0,0 +1,68 @@
+package usagestats
+
+import (
+	"context"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// GetCTAUsage returns the daily engagement with the browser extension install
+// call-to-action, grouped by the page it was displayed on.
+func GetCTAUsage(ctx context.Context, db database.DB) (*types.CTAUsage, error) {
+	now := timeNow()
+	query := sqlf.Sprintf(ctaUsageQuery, now)
+
+	usage := &types.CTAUsage{
+		StartTime:       now.UTC().Truncate(24 * time.Hour),
+		DisplayedByPage: map[string]types.UserAndEventCount{},
+		ClickedByPage:   map[string]types.UserAndEventCount{},
+	}
+
+	rows, err := db.QueryContext(ctx, query.Query(sqlf.PostgresBindVar), query.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	for rows.Next() {
+		var name, page string
+		var count types.UserAndEventCount
+		if err := rows.Scan(&name, &page, &count.UserCount, &count.EventCount); err != nil {
+			return nil, err
+		}
+
+		switch name {
+		case "InstallBrowserExtensionCTAShown":
+			usage.DisplayedByPage[page] = count
+		case "InstallBrowserExtensionCTAClicked":
+			usage.ClickedByPage[page] = count
+		}
+	}
+
+	// Mirror the file/search entries into the deprecated fixed fields.
+	usage.DisplayedOnFilePage = usage.DisplayedByPage["file"]
+	usage.DisplayedOnSearchPage = usage.DisplayedByPage["search"]
+	usage.ClickedOnFilePage = usage.ClickedByPage["file"]
+	usage.ClickedOnSearchPage = usage.ClickedByPage["search"]
+
+	return usage, err
+}
+
+var ctaUsageQuery = `
+SELECT
+	name,
+	argument->>'page' AS page,
+	COUNT(DISTINCT user_id) AS user_count,
+	COUNT(*) AS event_count
+FROM event_logs
+WHERE
+	name IN ('InstallBrowserExtensionCTAShown', 'InstallBrowserExtensionCTAClicked')
+	AND argument->>'page' IS NOT NULL
+	AND DATE(TIMEZONE('UTC', timestamp)) = DATE(TIMEZONE('UTC', %s::timestamp))
+GROUP BY name, page
+`
@@ -0,0 +1,65 @@
+package usagestats
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestGetCTAUsage(t *testing.T) {
+	ctx := context.Background()
+
+	defer func() {
+		timeNow = time.Now
+	}()
+
+	now := time.Date(2022, 2, 9, 12, 0, 0, 0, time.UTC)
+	mockTimeNow(now)
+
+	db := database.NewDB(dbtest.NewDB(t))
+
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO event_logs
+			(id, name, argument, url, user_id, anonymous_user_id, source, version, timestamp)
+		VALUES
+			(1, 'InstallBrowserExtensionCTAShown', '{"page": "file"}', '', 1, '420657f0-d443-4d16-ac7d-003d8cdc91ef', 'WEB', '3.36.0', $1::timestamp),
+			(2, 'InstallBrowserExtensionCTAShown', '{"page": "file"}', '', 2, '420657f0-d443-4d16-ac7d-003d8cdc19ac', 'WEB', '3.36.0', $1::timestamp),
+			(3, 'InstallBrowserExtensionCTAShown', '{"page": "repo"}', '', 1, '420657f0-d443-4d16-ac7d-003d8cdc91ef', 'WEB', '3.36.0', $1::timestamp),
+			(4, 'InstallBrowserExtensionCTAShown', '{"page": "repo"}', '', 1, '420657f0-d443-4d16-ac7d-003d8cdc91ef', 'WEB', '3.36.0', $1::timestamp),
+			(5, 'InstallBrowserExtensionCTAClicked', '{"page": "repo"}', '', 1, '420657f0-d443-4d16-ac7d-003d8cdc91ef', 'WEB', '3.36.0', $1::timestamp),
+			(6, 'InstallBrowserExtensionCTAClicked', '{"page": "search"}', '', 2, '420657f0-d443-4d16-ac7d-003d8cdc19ac', 'WEB', '3.36.0', $1::timestamp),
+			-- An event from another day must not be counted.
+			(7, 'InstallBrowserExtensionCTAShown', '{"page": "file"}', '', 1, '420657f0-d443-4d16-ac7d-003d8cdc91ef', 'WEB', '3.36.0', $1::timestamp - interval '1 day')
+	`, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	have, err := GetCTAUsage(ctx, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &types.CTAUsage{
+		StartTime: time.Date(2022, 2, 9, 0, 0, 0, 0, time.UTC),
+		DisplayedByPage: map[string]types.UserAndEventCount{
+			"file": {UserCount: 2, EventCount: 2},
+			"repo": {UserCount: 1, EventCount: 2},
+		},
+		ClickedByPage: map[string]types.UserAndEventCount{
+			"repo":   {UserCount: 1, EventCount: 1},
+			"search": {UserCount: 1, EventCount: 1},
+		},
+		DisplayedOnFilePage: types.UserAndEventCount{UserCount: 2, EventCount: 2},
+		ClickedOnSearchPage: types.UserAndEventCount{UserCount: 1, EventCount: 1},
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatal(diff)
+	}
+}
@@ -0,0 +1,111 @@
+package usagestats
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+)
+
+// streamEventLogsBatchSize is the number of event_logs rows fetched per
+// cursor page during a StreamEventLogs export.
+const streamEventLogsBatchSize = 1000
+
+// StreamedEventLog is one event_logs row as written by StreamEventLogs.
+type StreamedEventLog struct {
+	ID              int64           `json:"id"`
+	Name            string          `json:"name"`
+	UserID          int32           `json:"user_id"`
+	AnonymousUserID string          `json:"anonymous_user_id,omitempty"`
+	URL             string          `json:"url,omitempty"`
+	Source          string          `json:"source"`
+	Argument        json.RawMessage `json:"argument,omitempty"`
+	Timestamp       time.Time       `json:"timestamp"`
+}
+
+// StreamEventLogs writes the event_logs rows with one of the given names and
+// a timestamp in [since, until) to w as newline-delimited JSON, ordered by
+// timestamp. Rows are fetched one page at a time behind a (timestamp, id)
+// cursor, so arbitrarily large ranges can be exported without holding more
+// than a page in memory.
+func StreamEventLogs(ctx context.Context, db database.DB, names []string, since, until time.Time, w io.Writer) error {
+	enc := json.NewEncoder(w)
+
+	cursorTimestamp := since
+	var cursorID int64
+	for {
+		query := sqlf.Sprintf(streamEventLogsQuery,
+			pq.Array(names), until, cursorTimestamp, cursorTimestamp, cursorID, streamEventLogsBatchSize)
+
+		page, err := streamEventLogsPage(ctx, db, query)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range page {
+			if err := enc.Encode(event); err != nil {
+				return err
+			}
+		}
+
+		if len(page) < streamEventLogsBatchSize {
+			return nil
+		}
+		last := page[len(page)-1]
+		cursorTimestamp, cursorID = last.Timestamp, last.ID
+	}
+}
+
+// streamEventLogsPage fetches one cursor page, closing its rows before the
+// caller starts the next one.
+func streamEventLogsPage(ctx context.Context, db database.DB, query *sqlf.Query) (page []*StreamedEventLog, err error) {
+	rows, err := db.QueryContext(ctx, query.Query(sqlf.PostgresBindVar), query.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	for rows.Next() {
+		var event StreamedEventLog
+		if err := rows.Scan(
+			&event.ID,
+			&event.Name,
+			&event.UserID,
+			&event.AnonymousUserID,
+			&event.URL,
+			&event.Source,
+			&event.Argument,
+			&event.Timestamp,
+		); err != nil {
+			return nil, err
+		}
+		page = append(page, &event)
+	}
+
+	return page, err
+}
+
+var streamEventLogsQuery = `
+SELECT
+	id,
+	name,
+	user_id,
+	anonymous_user_id,
+	url,
+	source,
+	argument,
+	timestamp
+FROM event_logs
+WHERE
+	name = ANY (%s)
+	AND timestamp < %s
+	AND (timestamp > %s OR (timestamp = %s AND id > %s))
+ORDER BY timestamp, id
+LIMIT %s
+`
@@ -0,0 +1,84 @@
+package usagestats
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestStreamEventLogs(t *testing.T) {
+	ctx := context.Background()
+
+	db := database.NewDB(dbtest.NewDB(t))
+
+	since := time.Date(2022, 2, 9, 0, 0, 0, 0, time.UTC)
+
+	// Seed more matching events than fit in one cursor page, so the export
+	// has to paginate, plus events excluded by name or time range.
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO event_logs
+			(name, argument, url, user_id, anonymous_user_id, source, version, timestamp)
+		SELECT
+			'InstallBrowserExtensionCTAShown', '{"page": "file"}', '', 1, '', 'WEB', '3.36.0',
+			$1::timestamp + (i || ' seconds')::interval
+		FROM generate_series(0, $2 - 1) AS i
+	`, since, streamEventLogsBatchSize+10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO event_logs
+			(name, argument, url, user_id, anonymous_user_id, source, version, timestamp)
+		VALUES
+			-- Not one of the requested names.
+			('SearchResultsQueried', '{}', '', 1, '', 'WEB', '3.36.0', $1::timestamp),
+			-- Before the range.
+			('InstallBrowserExtensionCTAShown', '{}', '', 1, '', 'WEB', '3.36.0', $1::timestamp - interval '1 second'),
+			-- At the end of the half-open range.
+			('InstallBrowserExtensionCTAShown', '{}', '', 1, '', 'WEB', '3.36.0', $2::timestamp)
+	`, since, since.Add(time.Duration(streamEventLogsBatchSize+10)*time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	err = StreamEventLogs(ctx, db,
+		[]string{"InstallBrowserExtensionCTAShown", "InstallBrowserExtensionCTAClicked"},
+		since, since.Add(time.Duration(streamEventLogsBatchSize+10)*time.Second),
+		&buf,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var events []*StreamedEventLog
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var event StreamedEventLog
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("invalid NDJSON line %q: %s", scanner.Text(), err)
+		}
+		events = append(events, &event)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := streamEventLogsBatchSize + 10; len(events) != want {
+		t.Fatalf("want %d events, got %d", want, len(events))
+	}
+	for i, event := range events {
+		if event.Name != "InstallBrowserExtensionCTAShown" {
+			t.Fatalf("event %d: unexpected name %q", i, event.Name)
+		}
+		if want := since.Add(time.Duration(i) * time.Second); !event.Timestamp.Equal(want) {
+			t.Fatalf("event %d: want timestamp %s, got %s", i, want, event.Timestamp)
+		}
+	}
+}
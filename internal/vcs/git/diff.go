@@ -3,6 +3,7 @@ package git
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"os"
 	"strings"
@@ -101,6 +102,14 @@ func DiffSymbols(ctx context.Context, repo api.RepoName, commitA, commitB api.Co
 	return command.Output(ctx)
 }
 
+// DiffSymbolsWithSimilarity is like DiffSymbols but enables rename detection
+// (git's -M flag) with the given similarity threshold percentage.
+func DiffSymbolsWithSimilarity(ctx context.Context, repo api.RepoName, commitA, commitB api.CommitID, similarity int) ([]byte, error) {
+	command := gitserver.DefaultClient.Command("git", "diff", "-z", "--name-status", fmt.Sprintf("-M%d%%", similarity), string(commitA), string(commitB))
+	command.Repo = repo
+	return command.Output(ctx)
+}
+
 type DiffFileIterator struct {
 	rdr  io.ReadCloser
 	mfdr *diff.MultiFileDiffReader
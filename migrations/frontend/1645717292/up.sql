@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS external_service_sync_job_logs (
+    id BIGSERIAL PRIMARY KEY,
+    sync_job_id INTEGER NOT NULL REFERENCES external_service_sync_jobs (id) ON DELETE CASCADE,
+    message TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS external_service_sync_job_logs_sync_job_id_idx ON external_service_sync_job_logs (sync_job_id);
@@ -0,0 +1 @@
+ALTER TABLE external_service_sync_jobs DROP COLUMN IF EXISTS cursor;
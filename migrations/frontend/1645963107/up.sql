@@ -0,0 +1 @@
+ALTER TABLE external_service_sync_jobs ADD COLUMN IF NOT EXISTS cursor TEXT NOT NULL DEFAULT '';
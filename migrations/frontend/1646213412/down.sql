@@ -0,0 +1 @@
+ALTER TABLE external_services DROP COLUMN IF EXISTS paused;
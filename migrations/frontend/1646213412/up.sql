@@ -0,0 +1 @@
+ALTER TABLE external_services ADD COLUMN IF NOT EXISTS paused BOOLEAN NOT NULL DEFAULT FALSE;
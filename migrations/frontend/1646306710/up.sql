@@ -0,0 +1 @@
+ALTER TABLE lsif_dependency_repos ADD COLUMN IF NOT EXISTS first_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now();